@@ -273,7 +273,11 @@ func createEphemeralTUIOSInstance(width, height int) (tea.Model, []tea.ProgramOp
 		ShowKeys:        showKeys,
 		Width:           width,
 		Height:          height,
+		WatchConfig:     true,
 	})
+	if err := tuiosInstance.ApplyStartup(userConfig.Startup); err != nil {
+		log.Printf("Warning: %v", err)
+	}
 
 	return tuiosInstance, []tea.ProgramOption{
 		tea.WithFPS(config.NormalFPS),
@@ -343,6 +347,7 @@ func createDaemonTUIOSInstance(sessionName string, width, height int) (tea.Model
 		DaemonClient:              client,
 		SessionName:               sessionName,
 		EnableGraphicsPassthrough: true,
+		WatchConfig:               true,
 	})
 
 	// Restore state from daemon if available
@@ -368,6 +373,13 @@ func createDaemonTUIOSInstance(sessionName string, width, height int) (tea.Model
 		tuiosInstance.SyncDaemonPTYDimensions()
 	}
 
+	// Only a session this client created is set up by [startup]
+	if client.SessionCreated() {
+		if err := tuiosInstance.ApplyStartup(userConfig.Startup); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
 	// Register multi-client handlers
 	registerMultiClientHandlers(tuiosInstance, client)
 
@@ -20,6 +20,63 @@ func printConfigPath() error {
 	return nil
 }
 
+func showConfig(effective bool) error {
+	layers, untrusted, err := config.ConfigLayers()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Config files, lowest first:")
+	for _, layer := range layers {
+		note := ""
+		if _, err := os.Stat(layer.Path); os.IsNotExist(err) {
+			note = " (not created)"
+		}
+		fmt.Printf("  %-8s %s%s\n", layer.Kind, layer.Path, note)
+	}
+	if untrusted != "" {
+		fmt.Printf("  %-8s %s (ignored until trusted: tuios config trust)\n", config.LayerProject, untrusted)
+	}
+	if !effective {
+		return nil
+	}
+
+	cfg, sources, err := config.LoadConfigLayers(layers)
+	if err != nil {
+		return err
+	}
+	values, err := config.EffectiveValues(cfg, sources)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	for _, v := range values {
+		source := v.Source
+		if source == "" {
+			source = "default"
+		}
+		fmt.Printf("%s = %s  # %s\n", v.Key, v.Value, source)
+	}
+	return nil
+}
+
+func trustProjectConfig(path string) error {
+	if path == "" {
+		dir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("could not determine current directory: %w", err)
+		}
+		if path = config.FindProjectConfig(dir); path == "" {
+			return fmt.Errorf("no %s found in %s or its parents", config.ProjectConfigName, dir)
+		}
+	}
+	if err := config.TrustProjectConfig(path); err != nil {
+		return err
+	}
+	fmt.Printf("Trusted %s\n", path)
+	return nil
+}
+
 func editConfigFile() error {
 	configPath, err := config.GetConfigPath()
 	if err != nil {
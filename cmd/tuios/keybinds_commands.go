@@ -32,7 +32,7 @@ func generateWorkspaceActions() []string {
 	for i := 1; i <= 9; i++ {
 		actions = append(actions, fmt.Sprintf("move_and_follow_%d", i))
 	}
-	return actions
+	return append(actions, "workspace_picker")
 }
 
 func printKeybindingsTable(registry *config.KeybindRegistry) {
@@ -78,7 +78,7 @@ func printKeybindingsTable(registry *config.KeybindRegistry) {
 			Title: "Selection",
 			Actions: []string{
 				"toggle_selection", "toggle_selection_term",
-				"copy_selection", "paste_clipboard", "clear_selection",
+				"copy_selection", "paste_clipboard", "paste_register", "clear_selection",
 			},
 		},
 		{
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"charm.land/lipgloss/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/app"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+)
+
+func listNamedLayouts() error {
+	dir := config.GetLayoutDirectory()
+	names, err := app.ListNamedLayouts(dir)
+	if err != nil {
+		return err
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("4")).Bold(true)
+	pathStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	fmt.Printf("%s\n", headerStyle.Render("Saved Layouts"))
+	fmt.Printf("%s\n\n", pathStyle.Render("Location: "+dir))
+
+	if len(names) == 0 {
+		dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+		fmt.Printf("%s\n", dimStyle.Render("No saved layouts found"))
+		fmt.Printf("%s\n", dimStyle.Render("Use Ctrl+B, t, s in TUIOS or tuios run-command SaveLayout <name> to save one"))
+		return nil
+	}
+
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	for _, name := range names {
+		fmt.Printf("  %s\n", nameStyle.Render(name))
+	}
+
+	fmt.Printf("\n%d layout(s) found\n", len(names))
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Gaurav-Gosain/tuios/internal/config"
 	"github.com/Gaurav-Gosain/tuios/internal/session"
 	"github.com/Gaurav-Gosain/tuios/internal/theme"
 	"github.com/charmbracelet/fang"
@@ -36,6 +37,7 @@ var (
 	dockbarPosition     string
 	hideWindowButtons   bool
 	scrollbackLines     int
+	scrollbackPersist   bool
 	showKeys            bool
 	noAnimations        bool
 	windowTitlePosition string
@@ -66,6 +68,9 @@ comprehensive keyboard/mouse interactions.`,
   # Run with a specific theme
   tuios --theme dracula
 
+  # Run with the settings of the work profile
+  tuios --profile work
+
   # List all available themes
   tuios --list-themes
 
@@ -114,10 +119,12 @@ comprehensive keyboard/mouse interactions.`,
 	rootCmd.PersistentFlags().StringVar(&dockbarPosition, "dockbar-position", "", "Dockbar position: bottom, top, hidden (default: from config or bottom)")
 	rootCmd.PersistentFlags().BoolVar(&hideWindowButtons, "hide-window-buttons", false, "Hide window control buttons (minimize, maximize, close)")
 	rootCmd.PersistentFlags().IntVar(&scrollbackLines, "scrollback-lines", 0, "Number of lines to keep in scrollback buffer (default: from config or 10000, min: 100, max: 1000000)")
+	rootCmd.PersistentFlags().BoolVar(&scrollbackPersist, "scrollback-persist", false, "Keep unlimited scrollback history in compressed files on disk")
 	rootCmd.PersistentFlags().BoolVar(&showKeys, "show-keys", false, "Enable showkeys overlay to display pressed keys")
 	rootCmd.PersistentFlags().BoolVar(&noAnimations, "no-animations", false, "Disable UI animations for instant transitions")
 	rootCmd.PersistentFlags().StringVar(&windowTitlePosition, "window-title-position", "", "Window title position: bottom, top, hidden (default: from config or bottom)")
 	rootCmd.PersistentFlags().BoolVar(&hideClock, "hide-clock", false, "Hide the clock overlay")
+	rootCmd.PersistentFlags().StringVar(&config.Profile, "profile", "", "Config profile to lay over config.toml (profiles/<name>.toml in the config directory)")
 
 	var sshPort, sshHost, sshKeyPath, sshDefaultSession string
 	var sshEphemeral bool
@@ -201,7 +208,48 @@ This will overwrite your existing configuration after confirmation.`,
 		},
 	}
 
-	configCmd.AddCommand(configPathCmd, configEditCmd, configResetCmd)
+	var showEffective bool
+	configShowCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the config files in effect",
+		Long: `List the config files that make up the configuration, lowest first:
+config.toml, the profile selected with --profile and the .tuios.toml of the
+current project.
+
+With --effective, also print every setting of the merged configuration and
+the file it came from.`,
+		Example: `  # Which files are used in this directory
+  tuios config show
+
+  # The merged settings with the work profile
+  tuios --profile work config show --effective`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return showConfig(showEffective)
+		},
+	}
+	configShowCmd.Flags().BoolVar(&showEffective, "effective", false, "Print the merged settings and where each came from")
+
+	configTrustCmd := &cobra.Command{
+		Use:   "trust [path]",
+		Short: "Allow a project's .tuios.toml to be used",
+		Long: `Trust a project config so TUIOS lays it over your config when started in
+that project. A project config can run commands, so it is ignored until
+trusted, and trusting it again is needed after it changes.
+
+Without a path, the .tuios.toml of the current directory or its closest
+parent is trusted.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+			return trustProjectConfig(path)
+		},
+	}
+
+	configCmd.AddCommand(configPathCmd, configEditCmd, configResetCmd, configShowCmd, configTrustCmd)
 
 	keybindsCmd := &cobra.Command{
 		Use:     "keybinds",
@@ -730,6 +778,50 @@ Use --json for machine-readable output.`,
 	getWindowCmd.Flags().BoolVar(&getWindowJSON, "json", false, "Output as JSON")
 	_ = getWindowCmd.RegisterFlagCompletionFunc("session", completeSessionNames)
 
+	var exportSession, exportFormat, exportOutput, exportLines string
+	var exportCommands int
+	exportWindowCmd := &cobra.Command{
+		Use:   "export-window [id-or-name]",
+		Short: "Export a window's history as HTML, SVG, ANSI, text or asciicast",
+		Long: `Export the scrollback and screen of a window with colors, text
+attributes and hyperlinks preserved.
+
+If no ID or name is provided, the focused window is exported. Select part
+of the history with --lines (absolute line numbers, negative values count
+from the end) or --commands (the last N commands, requires shell integration
+or a recognizable prompt). The format defaults to the extension of --output,
+or plain text.`,
+		Example: `  # Export the focused window as a standalone HTML page
+  tuios export-window --format html -o history.html
+
+  # Export the last 3 commands of the "Server" window as SVG
+  tuios export-window "Server" --commands 3 -o server.svg
+
+  # Print the last 100 lines with ANSI colors
+  tuios export-window --format ansi --lines -100:
+
+  # Create an asciicast that can be replayed with asciinema
+  tuios export-window -o session.cast`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			window := ""
+			if len(args) > 0 {
+				window = args[0]
+			}
+			return runExportWindow(exportSession, window, exportFormat, exportOutput, exportLines, exportCommands)
+		},
+	}
+	exportWindowCmd.Flags().StringVarP(&exportSession, "session", "s", "", "Target session (default: most recently active)")
+	exportWindowCmd.Flags().StringVarP(&exportFormat, "format", "f", "", "Output format: html, svg, ansi, txt or cast")
+	exportWindowCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write to file instead of stdout")
+	exportWindowCmd.Flags().StringVar(&exportLines, "lines", "", "Line range START:END to export")
+	exportWindowCmd.Flags().IntVar(&exportCommands, "commands", 0, "Export only the last N commands")
+	exportWindowCmd.MarkFlagsMutuallyExclusive("lines", "commands")
+	_ = exportWindowCmd.RegisterFlagCompletionFunc("session", completeSessionNames)
+	_ = exportWindowCmd.RegisterFlagCompletionFunc("format", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"html", "svg", "ansi", "txt", "cast"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
 	var sessionInfoSession string
 	var sessionInfoJSON bool
 	sessionInfoCmd := &cobra.Command{
@@ -755,11 +847,68 @@ Use --json for machine-readable output.`,
 	sessionInfoCmd.Flags().BoolVar(&sessionInfoJSON, "json", false, "Output as JSON")
 	_ = sessionInfoCmd.RegisterFlagCompletionFunc("session", completeSessionNames)
 
-	rootCmd.AddCommand(sshCmd, configCmd, keybindsCmd, tapeCmd)
+	layoutsCmd := &cobra.Command{
+		Use:   "layouts",
+		Short: "List saved named layouts",
+		Long: `List the layouts saved with SaveLayout
+
+Named layouts store a workspace's tiling layout, split tree and ratios.
+Apply one to the current workspace's windows with LoadLayout.`,
+		Example: `  # Save and reapply a layout in a running session
+  tuios run-command SaveLayout review
+  tuios run-command LoadLayout review
+
+  # List saved layouts
+  tuios layouts`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return listNamedLayouts()
+		},
+	}
+
+	var themeImportName string
+	var themeImportForce bool
+
+	themeCmd := &cobra.Command{
+		Use:   "theme",
+		Short: "Manage custom themes",
+		Long:  `Manage the custom themes in the themes directory (~/.config/tuios/themes/)`,
+	}
+
+	themeImportCmd := &cobra.Command{
+		Use:   "import <file>...",
+		Short: "Import color schemes from other terminals",
+		Long: `Convert color scheme files of other terminals into TUIOS custom themes
+
+Supported formats, picked by file extension:
+  .toml         Alacritty config or theme
+  .itermcolors  iTerm2 color preset
+  .json         Windows Terminal scheme, or settings.json with all its schemes
+  .conf         kitty theme or kitty.conf
+
+Themes are named after the scheme or the file, and saved as JSON in the
+themes directory, where a running TUIOS picks them up.`,
+		Example: `  # Import a kitty theme and use it
+  tuios theme import ~/.config/kitty/current-theme.conf --name house
+  tuios --theme house
+
+  # Import every scheme of a Windows Terminal settings file
+  tuios theme import settings.json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return importThemes(args, themeImportName, themeImportForce)
+		},
+	}
+	themeImportCmd.Flags().StringVar(&themeImportName, "name", "", "Name of the imported theme")
+	themeImportCmd.Flags().BoolVarP(&themeImportForce, "force", "f", false, "Overwrite existing themes")
+
+	themeCmd.AddCommand(themeImportCmd)
+
+	rootCmd.AddCommand(sshCmd, configCmd, keybindsCmd, tapeCmd, layoutsCmd, themeCmd)
 	rootCmd.AddCommand(attachCmd, newCmd, lsCmd, killSessionCmd)
 	rootCmd.AddCommand(startDaemonCmd, daemonCmd, killDaemonCmd)
 	rootCmd.AddCommand(sendKeysCmd, runCommandCmd, setConfigCmd, logsCmd)
-	rootCmd.AddCommand(listWindowsCmd, getWindowCmd, sessionInfoCmd)
+	rootCmd.AddCommand(listWindowsCmd, getWindowCmd, sessionInfoCmd, exportWindowCmd)
 
 	if err := fang.Execute(
 		context.Background(),
@@ -6,9 +6,12 @@ import (
 	"maps"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Gaurav-Gosain/tuios/internal/scrollback"
 	"github.com/Gaurav-Gosain/tuios/internal/session"
 	"github.com/Gaurav-Gosain/tuios/internal/tape"
 	"github.com/google/uuid"
@@ -86,6 +89,86 @@ func runCommand(sessionName, command string, args []string, jsonOutput bool) err
 	return nil
 }
 
+// runExportWindow exports a window's history from a running TUIOS session,
+// writing it to outputPath or stdout.
+func runExportWindow(sessionName, window, formatName, outputPath, lines string, commands int) error {
+	if formatName == "" {
+		formatName = "txt"
+		if ext := filepath.Ext(outputPath); ext != "" {
+			formatName = ext
+		}
+	}
+	format, err := scrollback.ParseExportFormat(formatName)
+	if err != nil {
+		return err
+	}
+
+	if !session.IsDaemonRunning() {
+		return fmt.Errorf("TUIOS daemon is not running. Start a session first with 'tuios new'")
+	}
+
+	client := session.NewClient(&session.ClientConfig{
+		Version: version,
+	})
+
+	if err := client.Connect(); err != nil {
+		return fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	requestID := uuid.New().String()
+
+	commandCount := ""
+	if commands > 0 {
+		commandCount = strconv.Itoa(commands)
+	}
+	msg, err := session.NewMessage(session.MsgExecuteCommand, &session.ExecuteCommandPayload{
+		SessionName: sessionName,
+		CommandType: "ExportWindow",
+		Args:        []string{string(format), window, lines, commandCount},
+		RequestID:   requestID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create message: %w", err)
+	}
+
+	resp, err := client.SendControlMessage(msg)
+	if err != nil {
+		return fmt.Errorf("failed to send command: %w", err)
+	}
+
+	var content string
+	switch resp.Type {
+	case session.MsgCommandResult:
+		var result session.CommandResultPayload
+		if err := resp.ParsePayloadWithCodec(&result, client.GetCodec()); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+		if !result.Success {
+			return fmt.Errorf("export failed: %s", result.Message)
+		}
+		content, _ = result.Data["content"].(string)
+	case session.MsgError:
+		var errPayload session.ErrorPayload
+		if err := resp.ParsePayloadWithCodec(&errPayload, client.GetCodec()); err != nil {
+			return fmt.Errorf("export failed with unknown error")
+		}
+		return fmt.Errorf("export failed: %s", errPayload.Message)
+	default:
+		return fmt.Errorf("unexpected response from daemon")
+	}
+
+	if outputPath == "" {
+		_, err := os.Stdout.WriteString(content)
+		return err
+	}
+	if err := os.WriteFile(outputPath, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported to %s\n", outputPath)
+	return nil
+}
+
 // queryWindows queries window list directly from daemon (doesn't require TUI).
 func queryWindows(sessionName string, jsonOutput bool) error {
 	if !session.IsDaemonRunning() {
@@ -356,6 +439,15 @@ func listAvailableCommands() {
 		{"ToggleTiling", "Toggle tiling mode", "tuios run-command ToggleTiling"},
 		{"EnableTiling", "Enable tiling mode", "tuios run-command EnableTiling"},
 		{"DisableTiling", "Disable tiling mode", "tuios run-command DisableTiling"},
+		{"SetLayout name", "Set the workspace tiling layout", "tuios run-command SetLayout centered-master"},
+		{"ToggleFloating", "Float or tile the focused window", "tuios run-command ToggleFloating"},
+		{"ToggleZoom", "Zoom the focused window over the layout", "tuios run-command ToggleZoom"},
+		{"ToggleSticky", "Show the focused window on every workspace", "tuios run-command ToggleSticky"},
+		{"ToggleScratchpad name", "Show or hide a configured scratchpad", "tuios run-command ToggleScratchpad lazygit"},
+		{"UndoLayout", "Undo the last layout change", "tuios run-command UndoLayout"},
+		{"RedoLayout", "Redo the last undone layout change", "tuios run-command RedoLayout"},
+		{"SaveLayout name", "Save the workspace layout under a name", "tuios run-command SaveLayout review"},
+		{"LoadLayout name", "Apply a saved layout to the workspace", "tuios run-command LoadLayout review"},
 		{"SnapLeft", "Snap focused window to left", "tuios run-command SnapLeft"},
 		{"SnapRight", "Snap focused window to right", "tuios run-command SnapRight"},
 		{"SnapFullscreen", "Snap focused window to fullscreen", "tuios run-command SnapFullscreen"},
@@ -367,8 +459,9 @@ func listAvailableCommands() {
 		{"EqualizeSplits", "Equalize all split ratios", "tuios run-command EqualizeSplits"},
 
 		// Workspace
-		{"SwitchWorkspace 1-9", "Switch to workspace N", "tuios run-command SwitchWorkspace 2"},
-		{"MoveToWorkspace 1-9", "Move focused window to workspace N", "tuios run-command MoveToWorkspace 3"},
+		{"SwitchWorkspace <n|name>", "Switch to workspace by number or name", "tuios run-command SwitchWorkspace api"},
+		{"MoveToWorkspace <n|name>", "Move focused window to workspace by number or name", "tuios run-command MoveToWorkspace 3"},
+		{"RenameWorkspace <name>", "Name the current workspace", "tuios run-command RenameWorkspace api"},
 
 		// Animations
 		{"EnableAnimations", "Enable UI animations", "tuios run-command EnableAnimations"},
@@ -385,6 +478,7 @@ func listAvailableCommands() {
 		{"ListWindows", "List all windows (use --json)", "tuios list-windows --json"},
 		{"GetWindow [id-or-name]", "Get window info (use --json)", "tuios get-window --json"},
 		{"GetSessionInfo", "Get session info (use --json)", "tuios session-info --json"},
+		{"ExportWindow <format> [id-or-name]", "Export window history (use export-window)", "tuios export-window --format html -o history.html"},
 	}
 
 	fmt.Println("Available commands for 'tuios run-command':")
@@ -474,37 +568,9 @@ func getSendKeysCompletions(toComplete string) []string {
 
 // getRunCommandCompletions returns completions for run-command command names.
 func getRunCommandCompletions(toComplete string) []string {
-	commands := []string{
-		"NewWindow\tCreate a new terminal window",
-		"CloseWindow\tClose the focused window",
-		"NextWindow\tFocus the next window",
-		"PrevWindow\tFocus the previous window",
-		"RenameWindow\tRename the focused window",
-		"MinimizeWindow\tMinimize the focused window",
-		"RestoreWindow\tRestore the focused window",
-		"TerminalMode\tSwitch to terminal mode",
-		"WindowManagementMode\tSwitch to window management mode",
-		"ToggleTiling\tToggle tiling mode",
-		"EnableTiling\tEnable tiling mode",
-		"DisableTiling\tDisable tiling mode",
-		"SnapLeft\tSnap window to left",
-		"SnapRight\tSnap window to right",
-		"SnapFullscreen\tSnap window fullscreen",
-		"Split\tSplit window (horizontal/vertical)",
-		"RotateSplit\tRotate split direction",
-		"EqualizeSplits\tEqualize all splits",
-		"SwitchWorkspace\tSwitch to workspace N",
-		"MoveToWorkspace\tMove window to workspace N",
-		"MoveAndFollowWorkspace\tMove and follow to workspace N",
-		"EnableAnimations\tEnable animations",
-		"DisableAnimations\tDisable animations",
-		"ToggleAnimations\tToggle animations",
-		"SetConfig\tSet a config option",
-		"SetTheme\tChange theme",
-		"SetDockbarPosition\tChange dockbar position",
-		"SetBorderStyle\tChange border style",
-		"ShowNotification\tShow a notification",
-		"FocusDirection\tFocus window in direction",
+	commands := make([]string, 0, len(tape.Commands))
+	for _, c := range tape.Commands {
+		commands = append(commands, string(c.Type)+"\t"+c.Description)
 	}
 
 	var filtered []string
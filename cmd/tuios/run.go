@@ -132,6 +132,7 @@ func runLocal() error {
 		WindowTitlePosition: windowTitlePosition,
 		HideClock:           hideClock,
 		ScrollbackLines:     scrollbackLines,
+		ScrollbackPersist:   scrollbackPersist,
 		NoAnimations:        noAnimations,
 		ThemeName:           themeName,
 	}, userConfig)
@@ -169,7 +170,11 @@ func runLocal() error {
 		ShowKeys:                  showKeys,
 		IsDaemonSession:           isDaemonSession,
 		EnableGraphicsPassthrough: true,
+		WatchConfig:               true,
 	})
+	if err := initialOS.ApplyStartup(userConfig.Startup); err != nil {
+		log.Printf("Warning: %v", err)
+	}
 
 	p := tea.NewProgram(
 		initialOS,
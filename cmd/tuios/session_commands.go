@@ -93,6 +93,7 @@ func runDaemonSession(sessionName string, createNew bool) error {
 		WindowTitlePosition: windowTitlePosition,
 		HideClock:           hideClock,
 		ScrollbackLines:     scrollbackLines,
+		ScrollbackPersist:   scrollbackPersist,
 		NoAnimations:        noAnimations,
 		ThemeName:           themeName,
 	}, userConfig)
@@ -144,6 +145,7 @@ func runDaemonSession(sessionName string, createNew bool) error {
 		DaemonClient:              client,
 		SessionName:               client.SessionName(),
 		EnableGraphicsPassthrough: true,
+		WatchConfig:               true,
 	})
 
 	windowCount := 0
@@ -178,6 +180,13 @@ func runDaemonSession(sessionName string, createNew bool) error {
 		log.Printf("[CLIENT] No existing state to restore")
 	}
 
+	// Only a session this client created is set up by [startup]
+	if client.SessionCreated() {
+		if err := initialOS.ApplyStartup(userConfig.Startup); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
 	p := tea.NewProgram(
 		initialOS,
 		tea.WithFPS(config.NormalFPS),
@@ -56,28 +56,31 @@ func runTapeInteractive(tapeFile string) error {
 	converter := tape.NewScriptMessageConverter()
 
 	initialOS := &app.OS{
-		FocusedWindow:        -1,
-		WindowExitChan:       make(chan string, 10),
-		StateSyncChan:        make(chan *session.SessionState, 10),
-		ClientEventChan:      make(chan app.ClientEvent, 10),
-		MouseSnapping:        false,
-		MasterRatio:          0.5,
-		CurrentWorkspace:     1,
-		NumWorkspaces:        9,
-		WorkspaceFocus:       make(map[int]int),
-		WorkspaceLayouts:     make(map[int][]app.WindowLayout),
-		WorkspaceHasCustom:   make(map[int]bool),
-		WorkspaceMasterRatio: make(map[int]float64),
-		PendingResizes:       make(map[string][2]int),
-		KeybindRegistry:      keybindRegistry,
-		ShowKeys:             showKeys,
-		RecentKeys:           []app.KeyEvent{},
-		KeyHistoryMaxSize:    5,
-		ScriptMode:           true,
-		ScriptPlayer:         player,
-		ScriptPaused:         false,
-		ScriptConverter:      converter,
-		ScriptExecutor:       tape.NewCommandExecutor(nil),
+		FocusedWindow:         -1,
+		WindowExitChan:        make(chan string, 10),
+		StateSyncChan:         make(chan *session.SessionState, 10),
+		ClientEventChan:       make(chan app.ClientEvent, 10),
+		MouseSnapping:         false,
+		MasterRatio:           0.5,
+		MasterCount:           1,
+		CurrentWorkspace:      1,
+		NumWorkspaces:         9,
+		WorkspaceFocus:        make(map[int]int),
+		WorkspaceLayouts:      make(map[int][]app.WindowLayout),
+		WorkspaceHasCustom:    make(map[int]bool),
+		WorkspaceMasterRatio:  make(map[int]float64),
+		WorkspaceMasterCount:  make(map[int]int),
+		WorkspaceTilingLayout: make(map[int]string),
+		PendingResizes:        make(map[string][2]int),
+		KeybindRegistry:       keybindRegistry,
+		ShowKeys:              showKeys,
+		RecentKeys:            []app.KeyEvent{},
+		KeyHistoryMaxSize:     5,
+		ScriptMode:            true,
+		ScriptPlayer:          player,
+		ScriptPaused:          false,
+		ScriptConverter:       converter,
+		ScriptExecutor:        tape.NewCommandExecutor(nil),
 	}
 
 	initialOS.ScriptExecutor = tape.NewCommandExecutor(initialOS)
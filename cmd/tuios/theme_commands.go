@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"charm.land/lipgloss/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+)
+
+// importThemes converts color scheme files of other terminals into custom
+// themes in the themes directory.
+func importThemes(paths []string, name string, force bool) error {
+	dir, err := theme.GetThemesDir()
+	if err != nil {
+		return err
+	}
+
+	nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	pathStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	var imported []string
+	for _, path := range paths {
+		themes, err := theme.ImportColorSchemes(path)
+		if err != nil {
+			return err
+		}
+		if name != "" {
+			if len(paths) > 1 || len(themes) > 1 {
+				return fmt.Errorf("--name can only be used when importing a single color scheme")
+			}
+			themes[0].ID = theme.ThemeID(name)
+			themes[0].DisplayName = name
+		}
+
+		for _, t := range themes {
+			if t.ID == "" {
+				return fmt.Errorf("%s: could not derive a theme name, use --name", path)
+			}
+			target := filepath.Join(dir, t.ID+".json")
+			if _, err := os.Stat(target); err == nil && !force {
+				return fmt.Errorf("theme %s already exists at %s (use --force to overwrite)", t.ID, target)
+			}
+			if err := theme.SaveCustomThemeFile(target, t); err != nil {
+				return err
+			}
+			fmt.Printf("Imported %s %s\n", nameStyle.Render(t.ID), pathStyle.Render(target))
+			imported = append(imported, t.ID)
+		}
+	}
+
+	if len(imported) > 0 {
+		fmt.Printf("\nUse it with: tuios --theme %s\n", imported[0])
+	}
+	return nil
+}
@@ -155,7 +155,7 @@ func (m *OS) calculateDockPosition(windowIndex int) (int, int) {
 	dockWindows := []int{}
 
 	for i, window := range m.Windows {
-		if window.Workspace == m.CurrentWorkspace && (window.Minimized || window.Minimizing) {
+		if window.Workspace == m.CurrentWorkspace && (window.Minimized || window.Minimizing) && window.Scratchpad == "" {
 			dockWindows = append(dockWindows, i)
 			if len(dockWindows) >= 9 {
 				break
@@ -0,0 +1,51 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// With theme_light and theme_dark in the config, the theme follows the host
+// terminal's background. It is queried with OSC 11 at startup and whenever
+// the terminal regains focus, and terminals supporting mode 2031 report when
+// the system switches between light and dark. Every client attached to a
+// daemon session runs its own model, so each one follows its own terminal.
+
+// requestHostColorScheme asks the host terminal for its background color and
+// to report color scheme changes.
+func requestHostColorScheme() tea.Cmd {
+	if !config.AutoThemeEnabled() {
+		return nil
+	}
+	return tea.Batch(tea.RequestBackgroundColor, tea.Raw(ansi.SetModeLightDark))
+}
+
+// SetHostColorScheme records whether the host terminal has a dark background
+// and switches to the configured theme for it.
+func (m *OS) SetHostColorScheme(dark bool) {
+	m.hostDark = &dark
+	m.applyAutoTheme()
+}
+
+// applyAutoTheme switches to the theme configured for the host terminal's
+// background, once the background is known. The theme being edited in the
+// theme editor is left alone.
+func (m *OS) applyAutoTheme() {
+	if m.hostDark == nil || !config.AutoThemeEnabled() || m.ShowThemeEditor {
+		return
+	}
+	name := config.AutoThemeName(*m.hostDark)
+	if current := theme.Current(); current != nil && current.ID == name || current == nil && name == "" {
+		return
+	}
+
+	if err := theme.Initialize(name); err != nil {
+		m.LogError("Failed to switch theme to %s: %v", name, err)
+		return
+	}
+	m.applyThemeColors()
+	m.MarkAllDirty()
+	m.LogInfo("Host terminal is %s, switched theme to %q", map[bool]string{true: "dark", false: "light"}[*m.hostDark], name)
+}
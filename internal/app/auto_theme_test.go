@@ -0,0 +1,41 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+)
+
+// TestAutoTheme tests switching between the light and dark themes as the host
+// terminal reports its background
+func TestAutoTheme(t *testing.T) {
+	userCfg := config.DefaultConfig()
+	userCfg.Appearance.ThemeLight = "github"
+	userCfg.Appearance.ThemeDark = "dracula"
+	config.ApplyOverrides(config.Overrides{}, userCfg)
+	defer config.ApplyOverrides(config.Overrides{}, config.DefaultConfig())
+	defer func() { _ = theme.Initialize("") }()
+
+	if current := theme.Current(); current == nil || current.ID != "dracula" {
+		t.Fatalf("expected to start with the dark theme, got %+v", current)
+	}
+
+	m := &OS{Width: 120, Height: 40, WorkspaceFocus: make(map[int]int)}
+	m.SetHostColorScheme(false)
+	if current := theme.Current(); current == nil || current.ID != "github" {
+		t.Errorf("expected the light theme on a light terminal, got %+v", current)
+	}
+
+	m.ShowThemeEditor = true
+	m.SetHostColorScheme(true)
+	if theme.Current().ID != "github" {
+		t.Error("the theme being edited should not be switched")
+	}
+
+	m.ShowThemeEditor = false
+	m.SetHostColorScheme(true)
+	if theme.Current().ID != "dracula" {
+		t.Errorf("expected the dark theme on a dark terminal, got %s", theme.Current().ID)
+	}
+}
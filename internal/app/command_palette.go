@@ -0,0 +1,317 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/tape"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// The command palette lists everything TUIOS can do in one fuzzy-filtered
+// list: keybinding actions, tape commands, open windows, saved layouts,
+// themes and saved tape scripts. Typing a tape command with its arguments,
+// such as "SetLayout grid", runs that command line directly.
+
+// PaletteKind is the kind of entry in the command palette.
+type PaletteKind string
+
+// Kinds of command palette entries, in the order they are listed.
+const (
+	PaletteAction  PaletteKind = "action"
+	PaletteCommand PaletteKind = "command"
+	PaletteWindow  PaletteKind = "window"
+	PaletteLayout  PaletteKind = "layout"
+	PaletteTheme   PaletteKind = "theme"
+	PaletteTape    PaletteKind = "tape"
+)
+
+// PaletteItem is an entry in the command palette. Selecting it dispatches
+// Action, runs Commands, plays Tape, or, for commands that need arguments,
+// puts Complete into the query so the arguments can be typed.
+type PaletteItem struct {
+	Kind     PaletteKind
+	Title    string
+	Detail   string // Keys, argument synopsis or description shown dimmed
+	Action   string
+	Commands []tape.Command
+	Tape     *TapeFile
+	Complete string
+}
+
+// CommandPaletteState holds the state of the open command palette.
+type CommandPaletteState struct {
+	Query         string
+	SelectedIndex int
+	ScrollOffset  int
+	actions       []string   // Actions the input dispatcher can run
+	tapes         []TapeFile // Saved tape scripts, newest first
+	layouts       []string   // Names of the saved layouts
+}
+
+// OpenCommandPalette shows the command palette listing the given actions.
+func (m *OS) OpenCommandPalette(actions []string) {
+	tapes, _ := LoadTapeFiles()
+	layouts, _ := ListNamedLayouts(config.GetLayoutDirectory())
+	m.CommandPalette = &CommandPaletteState{actions: actions, tapes: tapes, layouts: layouts}
+	m.ShowCommandPalette = true
+}
+
+// CloseCommandPalette hides the command palette.
+func (m *OS) CloseCommandPalette() {
+	m.ShowCommandPalette = false
+	m.CommandPalette = nil
+}
+
+// CommandPaletteItems returns the palette entries matching the query. Entries
+// whose title contains the query come before other fuzzy matches.
+func (m *OS) CommandPaletteItems() []PaletteItem {
+	s := m.CommandPalette
+	if s == nil {
+		return nil
+	}
+	query := strings.TrimSpace(s.Query)
+
+	var items []PaletteItem
+	if item, ok := commandLineItem(query); ok {
+		items = append(items, item)
+	}
+	if query == "" {
+		return append(items, m.allPaletteItems()...)
+	}
+
+	var contains, fuzzy []PaletteItem
+	lowerQuery := strings.ToLower(query)
+	for _, item := range m.allPaletteItems() {
+		if strings.Contains(strings.ToLower(item.Title), lowerQuery) {
+			contains = append(contains, item)
+		} else if matched, _ := FuzzyMatch(query, string(item.Kind)+" "+item.Title+" "+item.Detail); matched {
+			fuzzy = append(fuzzy, item)
+		}
+	}
+	items = append(items, contains...)
+	return append(items, fuzzy...)
+}
+
+// commandLineItem turns a query of the form "<Command> <args...>" into an
+// entry that runs it.
+func commandLineItem(query string) (PaletteItem, bool) {
+	fields := splitCommandLine(query)
+	if len(fields) < 2 {
+		return PaletteItem{}, false
+	}
+	info, ok := tape.LookupCommand(fields[0])
+	if !ok || info.Args == "" {
+		return PaletteItem{}, false
+	}
+	return PaletteItem{
+		Kind:     PaletteCommand,
+		Title:    query,
+		Detail:   "run",
+		Commands: []tape.Command{{Type: info.Type, Args: fields[1:], Raw: query}},
+	}, true
+}
+
+// splitCommandLine splits a command line on spaces, keeping double-quoted
+// arguments together.
+func splitCommandLine(line string) []string {
+	var fields []string
+	var current strings.Builder
+	inQuotes, hasField := false, false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasField = true
+		case r == ' ' && !inQuotes:
+			if hasField {
+				fields = append(fields, current.String())
+				current.Reset()
+				hasField = false
+			}
+		default:
+			current.WriteRune(r)
+			hasField = true
+		}
+	}
+	if hasField {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
+
+// allPaletteItems lists every palette entry, grouped by kind.
+func (m *OS) allPaletteItems() []PaletteItem {
+	var items []PaletteItem
+
+	for _, action := range m.CommandPalette.actions {
+		description := config.ActionDescriptions[action]
+		if description == "" {
+			continue
+		}
+		item := PaletteItem{Kind: PaletteAction, Title: description, Action: action}
+		if m.KeybindRegistry != nil {
+			item.Detail = strings.Join(m.KeybindRegistry.GetKeys(action), ", ")
+		}
+		items = append(items, item)
+	}
+
+	for _, info := range tape.Commands {
+		item := PaletteItem{
+			Kind:   PaletteCommand,
+			Title:  string(info.Type),
+			Detail: strings.TrimSpace(info.Args + "  " + info.Description),
+		}
+		if info.NeedsArgs() {
+			item.Complete = string(info.Type) + " "
+		} else {
+			item.Commands = []tape.Command{{Type: info.Type}}
+		}
+		items = append(items, item)
+	}
+
+	windows := make([]*terminal.Window, len(m.Windows))
+	copy(windows, m.Windows)
+	sort.SliceStable(windows, func(i, j int) bool { return windows[i].Workspace < windows[j].Workspace })
+	for _, w := range windows {
+		commands := []tape.Command{}
+		if w.Workspace != m.CurrentWorkspace && !w.Sticky {
+			commands = append(commands, tape.Command{Type: tape.CommandTypeSwitchWS, Args: []string{strconv.Itoa(w.Workspace)}})
+		}
+		if w.Minimized {
+			commands = append(commands, tape.Command{Type: tape.CommandTypeRestoreWindow, Args: []string{w.ID}})
+		}
+		commands = append(commands, tape.Command{Type: tape.CommandTypeFocusWindow, Args: []string{w.ID}})
+		name := w.CustomName
+		if name == "" {
+			name = w.Title
+		}
+		items = append(items, PaletteItem{
+			Kind:     PaletteWindow,
+			Title:    name,
+			Detail:   "workspace " + m.WorkspaceLabel(w.Workspace),
+			Commands: commands,
+		})
+	}
+
+	for _, name := range m.CommandPalette.layouts {
+		items = append(items, PaletteItem{
+			Kind:     PaletteLayout,
+			Title:    name,
+			Commands: []tape.Command{{Type: tape.CommandTypeLoadLayout, Args: []string{name}}},
+		})
+	}
+
+	current := ""
+	if t := theme.Current(); t != nil {
+		current = t.ID
+	}
+	for _, id := range theme.IDs() {
+		item := PaletteItem{
+			Kind:     PaletteTheme,
+			Title:    id,
+			Commands: []tape.Command{{Type: tape.CommandTypeSetTheme, Args: []string{id}}},
+		}
+		if id == current {
+			item.Detail = "current"
+		}
+		items = append(items, item)
+	}
+
+	for i := range m.CommandPalette.tapes {
+		file := &m.CommandPalette.tapes[i]
+		items = append(items, PaletteItem{
+			Kind:   PaletteTape,
+			Title:  file.Name,
+			Detail: file.Modified.Format("2006-01-02 15:04"),
+			Tape:   file,
+		})
+	}
+
+	return items
+}
+
+// RunPaletteCommands executes tape commands picked in the command palette,
+// stopping at the first that fails.
+func (m *OS) RunPaletteCommands(commands []tape.Command) error {
+	executor := tape.NewCommandExecutor(m)
+	for i := range commands {
+		if err := executor.Execute(&commands[i]); err != nil {
+			return err
+		}
+	}
+	if m.AutoTiling {
+		m.TileAllWindows()
+	}
+	m.MarkAllDirty()
+	return nil
+}
+
+// renderCommandPalette renders the command palette.
+func (m *OS) renderCommandPalette() (string, int, int) {
+	s := m.CommandPalette
+	if s == nil {
+		return "", 0, 0
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpTabActive()).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("0")).
+		Background(theme.HelpTabActive()).
+		Bold(true)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpGray())
+
+	innerWidth := min(max(m.GetRenderWidth()-12, 30), 72)
+	input := lipgloss.NewStyle().
+		Width(innerWidth).
+		Render(": " + s.Query + "█")
+
+	lines := []string{titleStyle.Render("Command Palette"), "", input, ""}
+
+	items := m.CommandPaletteItems()
+	maxVisible := max(m.GetRenderHeight()-14, 3)
+	if s.SelectedIndex >= len(items) {
+		s.SelectedIndex = max(len(items)-1, 0)
+	}
+	s.ScrollOffset = scrollToSelection(s.SelectedIndex, s.ScrollOffset, maxVisible, len(items))
+	end := min(s.ScrollOffset+maxVisible, len(items))
+
+	if len(items) == 0 {
+		lines = append(lines, dimStyle.Render("no matches"))
+	}
+	const kindWidth = 8
+	for i, item := range items[s.ScrollOffset:end] {
+		title := ansi.Truncate(item.Title, innerWidth-kindWidth-2, "…")
+		detail := ""
+		if room := innerWidth - kindWidth - ansi.StringWidth(title) - 2; room > 4 && item.Detail != "" {
+			detail = ansi.Truncate(item.Detail, room, "…")
+		}
+		padding := strings.Repeat(" ", max(innerWidth-kindWidth-ansi.StringWidth(title)-ansi.StringWidth(detail), 0))
+		kind := fmt.Sprintf("%-*s", kindWidth, item.Kind)
+		if s.ScrollOffset+i == s.SelectedIndex {
+			lines = append(lines, selectedStyle.Render(kind+title+padding+detail))
+		} else {
+			lines = append(lines, dimStyle.Render(kind)+title+padding+dimStyle.Render(detail))
+		}
+	}
+	lines = append(lines, "", dimStyle.Render("↑/↓: select  enter: run  tab: complete  esc: cancel"))
+
+	dialogBox := lipgloss.NewStyle().
+		Border(getBorder()).
+		BorderForeground(theme.HelpBorder()).
+		Padding(1, 3).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return dialogBox, lipgloss.Width(dialogBox), lipgloss.Height(dialogBox)
+}
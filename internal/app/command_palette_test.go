@@ -0,0 +1,69 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/tape"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// TestCommandPalette tests filtering palette entries and running the
+// commands they stand for
+func TestCommandPalette(t *testing.T) {
+	m := newTestOS(
+		&terminal.Window{ID: "window-one", Title: "zsh", Workspace: 1},
+		&terminal.Window{ID: "window-two", CustomName: "logs", Workspace: 3},
+	)
+	m.AutoTiling = false
+	m.KeybindRegistry = config.NewKeybindRegistry(config.DefaultConfig())
+	m.CommandPalette = &CommandPaletteState{actions: []string{"new_window", "toggle_help"}}
+	m.ShowCommandPalette = true
+
+	find := func(kind PaletteKind, title string) *PaletteItem {
+		for _, item := range m.CommandPaletteItems() {
+			if item.Kind == kind && item.Title == title {
+				return &item
+			}
+		}
+		return nil
+	}
+
+	if item := find(PaletteAction, config.ActionDescriptions["new_window"]); item == nil || item.Detail != "n" {
+		t.Errorf("expected the new_window action with its key, got %+v", item)
+	}
+	if item := find(PaletteCommand, "SetLayout"); item == nil || item.Complete != "SetLayout " {
+		t.Errorf("commands that need arguments should complete instead of running, got %+v", item)
+	}
+	if find(PaletteCommand, "ToggleZoom") == nil {
+		t.Error("expected the ToggleZoom command")
+	}
+
+	m.CommandPalette.Query = "logs"
+	items := m.CommandPaletteItems()
+	if len(items) == 0 || items[0].Kind != PaletteWindow || items[0].Title != "logs" {
+		t.Fatalf("expected the logs window first, got %+v", items)
+	}
+	if err := m.RunPaletteCommands(items[0].Commands); err != nil {
+		t.Fatalf("RunPaletteCommands: %v", err)
+	}
+	if m.CurrentWorkspace != 3 || m.GetFocusedWindow() != m.Windows[1] {
+		t.Errorf("picking a window should switch to its workspace and focus it, on %d", m.CurrentWorkspace)
+	}
+
+	m.CommandPalette.Query = `RenameWindow "build output"`
+	items = m.CommandPaletteItems()
+	want := []tape.Command{{Type: tape.CommandTypeRenameWindow, Args: []string{"build output"}, Raw: m.CommandPalette.Query}}
+	if len(items) == 0 || !reflect.DeepEqual(items[0].Commands, want) {
+		t.Fatalf("a typed command line should run as typed, got %+v", items)
+	}
+	if err := m.RunPaletteCommands(items[0].Commands); err != nil || m.Windows[1].CustomName != "build output" {
+		t.Errorf("the command line should rename the window, got %q (%v)", m.Windows[1].CustomName, err)
+	}
+
+	m.CommandPalette.Query = "Bogus arg"
+	if items := m.CommandPaletteItems(); len(items) > 0 && items[0].Title == "Bogus arg" {
+		t.Error("unknown commands should not be offered as command lines")
+	}
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+)
+
+// The config file, its profile and project overlays and the custom themes
+// directory are polled for changes, so editing config.toml or a theme applies
+// it to the running TUIOS without a restart. Every client of a daemon session
+// runs its own watcher, so all of them pick up the change. A config that
+// fails validation is reported in a notification and the running config is
+// kept.
+
+// ConfigWatchMsg is sent periodically to check the config for changes.
+type ConfigWatchMsg struct{}
+
+// ConfigWatchCmd schedules the next config change check.
+func ConfigWatchCmd() tea.Cmd {
+	return tea.Tick(config.ConfigWatchInterval, func(time.Time) tea.Msg {
+		return ConfigWatchMsg{}
+	})
+}
+
+// configWatcher tracks the files that make up the config.
+type configWatcher struct {
+	configPath string
+	overlays   []config.ConfigLayer // Profile and project configs laid over configPath
+	themesDir  string
+	stamp      string // Modification times and sizes of the watched files
+}
+
+func newConfigWatcher() *configWatcher {
+	w := &configWatcher{}
+	if layers, _, err := config.ConfigLayers(); err == nil {
+		w.configPath = layers[0].Path
+		w.overlays = layers[1:]
+	} else if path, err := config.GetConfigPath(); err == nil {
+		w.configPath = path
+	}
+	if dir, err := theme.GetThemesDir(); err == nil {
+		w.themesDir = dir
+	}
+	w.stamp = w.currentStamp()
+	return w
+}
+
+// currentStamp fingerprints the config file and the theme files, so any
+// write, rename or removal changes it.
+func (w *configWatcher) currentStamp() string {
+	var sb strings.Builder
+	stampFile := func(path string) {
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(&sb, "%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+		}
+	}
+	if w.configPath != "" {
+		stampFile(w.configPath)
+	}
+	for _, layer := range w.overlays {
+		stampFile(layer.Path)
+	}
+	if w.themesDir != "" {
+		entries, _ := os.ReadDir(w.themesDir)
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(strings.ToLower(entry.Name()), ".json") {
+				stampFile(filepath.Join(w.themesDir, entry.Name()))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// changed reports whether the watched files changed since the last call.
+func (w *configWatcher) changed() bool {
+	stamp := w.currentStamp()
+	if stamp == w.stamp {
+		return false
+	}
+	w.stamp = stamp
+	return true
+}
+
+// checkConfigChanges reloads the config if it changed on disk.
+func (m *OS) checkConfigChanges() {
+	if m.configWatch == nil || !m.configWatch.changed() {
+		return
+	}
+	if err := m.ReloadConfig(); err != nil {
+		m.LogError("Config reload failed: %v", err)
+		m.ShowNotification("Config not reloaded: "+err.Error(), "error", 3*time.Second)
+		return
+	}
+	m.ShowNotification("Config reloaded", "info", config.NotificationDuration)
+}
+
+// ReloadConfig reads and validates the config files and applies their
+// appearance, keybindings and theme to the running session. Windows keep
+// running; only how they are drawn changes. On error nothing is applied.
+func (m *OS) ReloadConfig() error {
+	if m.configWatch == nil || m.configWatch.configPath == "" {
+		return fmt.Errorf("config file is not watched")
+	}
+	var cfg *config.UserConfig
+	var err error
+	if len(m.configWatch.overlays) == 0 {
+		cfg, err = config.LoadUserConfigFile(m.configWatch.configPath)
+	} else {
+		layers := []config.ConfigLayer{{Kind: config.LayerConfig, Path: m.configWatch.configPath}}
+		for _, layer := range m.configWatch.overlays {
+			// An edited project config has to be trusted again
+			if layer.Kind == config.LayerProject && !config.IsProjectConfigTrusted(layer.Path) {
+				return fmt.Errorf("%s changed, run 'tuios config trust' to use it", layer.Path)
+			}
+			layers = append(layers, layer)
+		}
+		cfg, _, err = config.LoadConfigLayers(layers)
+	}
+	if err != nil {
+		return err
+	}
+
+	dockbarPosition := config.DockbarPosition
+	config.ReapplyUserConfig(cfg)
+	if m.KeybindRegistry != nil {
+		m.KeybindRegistry.Reload(cfg)
+	}
+	m.NumWorkspaces = max(m.NumWorkspaces, len(cfg.Workspaces.Names))
+
+	m.applyAutoTheme()
+	m.applyThemeColors()
+	for _, w := range m.Windows {
+		w.InvalidateCache()
+	}
+	if config.DockbarPosition != dockbarPosition && m.AutoTiling {
+		m.TileAllWindows()
+	}
+	m.MarkAllDirty()
+	m.LogInfo("Reloaded config from %s", m.configWatch.configPath)
+	return nil
+}
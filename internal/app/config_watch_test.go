@@ -0,0 +1,54 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+)
+
+// TestReloadConfig tests that an edited config file is applied to the
+// running session and that an invalid one is reported and ignored
+func TestReloadConfig(t *testing.T) {
+	borderStyle, hideClock := config.BorderStyle, config.HideClock
+	defer func() { config.BorderStyle, config.HideClock = borderStyle, hideClock }()
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("[appearance]\nborder_style = \"rounded\"\n")
+
+	m := NewOS(OSOptions{KeybindRegistry: config.NewKeybindRegistry(config.DefaultConfig()), Width: 120, Height: 40})
+	m.configWatch = &configWatcher{configPath: path}
+	m.configWatch.stamp = m.configWatch.currentStamp()
+	if m.configWatch.changed() {
+		t.Fatal("an unchanged config should not be reported as changed")
+	}
+
+	write("[appearance]\nborder_style = \"double\"\nhide_clock = true\n" +
+		"[workspaces]\nnames = [\"a\", \"b\", \"c\", \"d\", \"e\", \"f\", \"g\", \"h\", \"i\", \"j\"]\n" +
+		"[keybindings.window_management]\nnew_window = [\"N\"]\n")
+	m.checkConfigChanges()
+	if config.BorderStyle != "double" || !config.HideClock {
+		t.Errorf("appearance not reloaded: border %q, hide clock %v", config.BorderStyle, config.HideClock)
+	}
+	if m.KeybindRegistry.GetAction("N") != "new_window" {
+		t.Error("keybindings not reloaded")
+	}
+	if m.NumWorkspaces != 10 || m.WorkspaceLabel(10) != "j" {
+		t.Errorf("workspaces not reloaded: %d, last named %q", m.NumWorkspaces, m.WorkspaceLabel(10))
+	}
+
+	write("[appearance]\nborder_style = \"rounded\"\n[workspaces]\nnames = [\"1\"]\n")
+	m.checkConfigChanges()
+	if config.BorderStyle != "double" || m.KeybindRegistry.GetAction("N") != "new_window" {
+		t.Error("an invalid config should leave the running config unchanged")
+	}
+	if len(m.Notifications) == 0 || m.Notifications[len(m.Notifications)-1].Type != "error" {
+		t.Error("an invalid config should be reported in an error notification")
+	}
+}
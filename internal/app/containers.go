@@ -0,0 +1,294 @@
+package app
+
+import (
+	"fmt"
+
+	"charm.land/lipgloss/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/layout"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// groupStrip describes the tab strip of a tabbed or stacked container on
+// screen. It sits directly above the container's active window.
+type groupStrip struct {
+	X, Y, Width int
+	Mode        layout.ContainerMode
+	Windows     []*terminal.Window
+	Active      *terminal.Window
+}
+
+// IsHiddenInGroup reports whether a window is hidden behind the active window
+// of a tabbed or stacked container.
+func (m *OS) IsHiddenInGroup(w *terminal.Window) bool {
+	return m.AutoTiling && w.GroupHidden
+}
+
+// usingContainers reports whether containers are in effect for the current
+// workspace. They belong to the BSP tree, so other layouts ignore them.
+func (m *OS) usingContainers() bool {
+	return m.AutoTiling && !m.UsingLayoutEngine()
+}
+
+// windowsByIntID maps BSP integer IDs back to windows.
+func (m *OS) windowsByIntID() map[int]*terminal.Window {
+	byID := make(map[int]*terminal.Window, len(m.Windows))
+	for _, w := range m.Windows {
+		if id, ok := m.WindowToBSPID[w.ID]; ok {
+			byID[id] = w
+		}
+	}
+	return byID
+}
+
+// syncGroupVisibility updates which windows are hidden behind the active
+// tab of a container. It must run whenever the tree's groups change.
+func (m *OS) syncGroupVisibility() {
+	hidden := make(map[*terminal.Window]bool)
+	byID := m.windowsByIntID()
+	for ws, tree := range m.WorkspaceTrees {
+		if tree == nil || !layout.IsBSP(m.workspaceLayoutName(ws)) {
+			continue
+		}
+		for _, node := range tree.Containers() {
+			for _, id := range node.Group {
+				if w := byID[id]; w != nil && id != node.WindowID {
+					hidden[w] = true
+				}
+			}
+		}
+	}
+
+	for _, w := range m.Windows {
+		if w.GroupHidden != hidden[w] {
+			w.GroupHidden = hidden[w]
+			w.InvalidateCache()
+		}
+	}
+}
+
+// activateGroupWindow brings a hidden window to the front of its container.
+func (m *OS) activateGroupWindow(w *terminal.Window) {
+	tree := m.WorkspaceTrees[w.Workspace]
+	if tree == nil {
+		return
+	}
+	if tree.ActivateWindow(m.getWindowIntID(w.ID)) {
+		m.syncGroupVisibility()
+	}
+}
+
+// focusedTreeWindow returns the focused window and its tree for container
+// operations, or an error describing why they are unavailable.
+func (m *OS) focusedTreeWindow() (*layout.BSPTree, *terminal.Window, error) {
+	if !m.usingContainers() {
+		return nil, nil, fmt.Errorf("tabbed and stacked containers need the bsp tiling layout")
+	}
+	focused := m.GetFocusedWindow()
+	if focused == nil {
+		return nil, nil, fmt.Errorf("no window is focused")
+	}
+	tree := m.WorkspaceTrees[m.CurrentWorkspace]
+	if tree == nil || !tree.HasWindow(m.getWindowIntID(focused.ID)) {
+		return nil, nil, fmt.Errorf("focused window is not tiled")
+	}
+	return tree, focused, nil
+}
+
+// afterGroupChange retiles and syncs state once the tree's groups changed.
+func (m *OS) afterGroupChange() {
+	m.ApplyBSPLayout()
+	m.SyncStateToDaemon()
+}
+
+// ToggleFocusedContainer switches the focused window's leaf between the given
+// container mode and a plain split, returning the resulting mode.
+func (m *OS) ToggleFocusedContainer(mode layout.ContainerMode) (layout.ContainerMode, error) {
+	tree, focused, err := m.focusedTreeWindow()
+	if err != nil {
+		return layout.ContainerSplit, err
+	}
+
+	id := m.getWindowIntID(focused.ID)
+	if tree.FindNode(id).Container == mode {
+		mode = layout.ContainerSplit
+	}
+	tree.SetContainerMode(id, mode, m.GetBSPBounds())
+	m.afterGroupChange()
+	return mode, nil
+}
+
+// CycleFocusedGroup focuses the window delta tabs away in the focused
+// window's container.
+func (m *OS) CycleFocusedGroup(delta int) error {
+	tree, focused, err := m.focusedTreeWindow()
+	if err != nil {
+		return err
+	}
+
+	id := m.getWindowIntID(focused.ID)
+	next := tree.CycleGroup(id, delta)
+	if next == id {
+		return fmt.Errorf("focused window is not in a group")
+	}
+	m.syncGroupVisibility()
+	for i, w := range m.Windows {
+		if w == m.getWindowByIntID(next) {
+			m.FocusWindow(i)
+			break
+		}
+	}
+	m.SyncStateToDaemon()
+	return nil
+}
+
+// JoinFocusedWindowGroup moves the focused window into the group of the
+// window next to it in the BSP tree.
+func (m *OS) JoinFocusedWindowGroup() error {
+	tree, focused, err := m.focusedTreeWindow()
+	if err != nil {
+		return err
+	}
+	if !tree.JoinSibling(m.getWindowIntID(focused.ID)) {
+		return fmt.Errorf("no neighbouring window to group with")
+	}
+	m.afterGroupChange()
+	return nil
+}
+
+// LeaveFocusedWindowGroup moves the focused window out of its group into a
+// leaf of its own.
+func (m *OS) LeaveFocusedWindowGroup() error {
+	tree, focused, err := m.focusedTreeWindow()
+	if err != nil {
+		return err
+	}
+	if !tree.LeaveGroup(m.getWindowIntID(focused.ID), m.GetBSPBounds()) {
+		return fmt.Errorf("focused window is not in a group")
+	}
+	m.afterGroupChange()
+	return nil
+}
+
+// groupStrips returns the tab strips of the current workspace's containers.
+func (m *OS) groupStrips() []groupStrip {
+	if !m.usingContainers() {
+		return nil
+	}
+	tree := m.WorkspaceTrees[m.CurrentWorkspace]
+	if tree == nil {
+		return nil
+	}
+
+	byID := m.windowsByIntID()
+	var strips []groupStrip
+	for _, node := range tree.Containers() {
+		active := byID[node.WindowID]
+		if active == nil || active.Minimized {
+			continue
+		}
+		strip := groupStrip{
+			X:      active.X,
+			Y:      active.Y - node.StripHeight(),
+			Width:  active.Width,
+			Mode:   node.Container,
+			Active: active,
+		}
+		for _, id := range node.Group {
+			if w := byID[id]; w != nil {
+				strip.Windows = append(strip.Windows, w)
+			}
+		}
+		strips = append(strips, strip)
+	}
+	return strips
+}
+
+// tabBounds returns the x offset and width of tab i in a tabbed strip.
+func (s groupStrip) tabBounds(i int) (int, int) {
+	n := len(s.Windows)
+	start := s.Width * i / n
+	return start, s.Width*(i+1)/n - start
+}
+
+// GroupTabAt returns the index of the window whose tab or title row is at
+// the given screen position, or -1.
+func (m *OS) GroupTabAt(x, y int) int {
+	for _, strip := range m.groupStrips() {
+		if x < strip.X || x >= strip.X+strip.Width {
+			continue
+		}
+		var hit *terminal.Window
+		switch strip.Mode {
+		case layout.ContainerStacked:
+			if row := y - strip.Y; row >= 0 && row < len(strip.Windows) {
+				hit = strip.Windows[row]
+			}
+		default:
+			if y != strip.Y {
+				continue
+			}
+			for i, w := range strip.Windows {
+				if start, width := strip.tabBounds(i); x-strip.X >= start && x-strip.X < start+width {
+					hit = w
+				}
+			}
+		}
+		for i, w := range m.Windows {
+			if hit != nil && w == hit {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// renderGroupStrips draws the tab strips of tabbed and stacked containers.
+func (m *OS) renderGroupStrips() []*lipgloss.Layer {
+	strips := m.groupStrips()
+	if len(strips) == 0 {
+		return nil
+	}
+
+	focused := m.GetFocusedWindow()
+	activeBg := theme.BorderFocusedWindow()
+	if m.Mode == TerminalMode {
+		activeBg = theme.BorderFocusedTerminal()
+	}
+
+	var layers []*lipgloss.Layer
+	for _, strip := range strips {
+		label := func(w *terminal.Window, width int) string {
+			style := lipgloss.NewStyle().Width(width).MaxWidth(width).
+				Foreground(theme.DockFg()).Background(theme.DockBg())
+			if w == strip.Active {
+				style = style.Bold(true).Background(theme.BorderUnfocused())
+				if w == focused {
+					style = style.Background(activeBg).Foreground(theme.DockBg())
+				}
+			}
+			return style.Render(ansi.Truncate(" "+m.getWindowDisplayName(w), width, "…"))
+		}
+
+		var content string
+		if strip.Mode == layout.ContainerStacked {
+			rows := make([]string, len(strip.Windows))
+			for i, w := range strip.Windows {
+				rows[i] = label(w, strip.Width)
+			}
+			content = lipgloss.JoinVertical(lipgloss.Left, rows...)
+		} else {
+			tabs := make([]string, len(strip.Windows))
+			for i, w := range strip.Windows {
+				_, width := strip.tabBounds(i)
+				tabs[i] = label(w, width)
+			}
+			content = lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+		}
+
+		layers = append(layers, lipgloss.NewLayer(content).
+			X(strip.X).Y(strip.Y).Z(m.StackZ(strip.Active)).ID("group-strip-"+strip.Active.ID))
+	}
+	return layers
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/layout"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// TestGroupVisibilityAndTabs tests that only a container's active window is
+// shown and that its tabs can be hit-tested
+func TestGroupVisibilityAndTabs(t *testing.T) {
+	tree := layout.NewBSPTree()
+	tree.InsertWindow(1, 0, layout.SplitNone, 0.5, layout.Rect{W: 100, H: 40})
+	if !tree.AddToGroup(2, 1, layout.ContainerTabbed) {
+		t.Fatal("AddToGroup failed")
+	}
+
+	m := &OS{
+		NumWorkspaces:    9,
+		CurrentWorkspace: 1,
+		AutoTiling:       true,
+		WorkspaceTrees:   map[int]*layout.BSPTree{1: tree},
+		WindowToBSPID:    map[string]int{"one": 1, "two": 2},
+		Windows: []*terminal.Window{
+			{ID: "one", Workspace: 1, X: 0, Y: 2, Width: 100, Height: 38},
+			{ID: "two", Workspace: 1, X: 0, Y: 2, Width: 100, Height: 38},
+		},
+	}
+
+	m.syncGroupVisibility()
+	if !m.IsHiddenInGroup(m.Windows[0]) || m.IsHiddenInGroup(m.Windows[1]) {
+		t.Fatalf("hidden = %v/%v, want window one hidden behind two",
+			m.Windows[0].GroupHidden, m.Windows[1].GroupHidden)
+	}
+
+	// The tab strip sits on the row above the active window, split evenly
+	if got := m.GroupTabAt(10, 1); got != 0 {
+		t.Errorf("GroupTabAt(left tab) = %d, want 0", got)
+	}
+	if got := m.GroupTabAt(60, 1); got != 1 {
+		t.Errorf("GroupTabAt(right tab) = %d, want 1", got)
+	}
+	if got := m.GroupTabAt(10, 5); got != -1 {
+		t.Errorf("GroupTabAt(window body) = %d, want -1", got)
+	}
+
+	m.activateGroupWindow(m.Windows[0])
+	if m.IsHiddenInGroup(m.Windows[0]) || !m.IsHiddenInGroup(m.Windows[1]) {
+		t.Error("activating window one should hide window two")
+	}
+
+	m.AutoTiling = false
+	if m.IsHiddenInGroup(m.Windows[1]) {
+		t.Error("windows should not be hidden while tiling is off")
+	}
+}
@@ -14,7 +14,7 @@ func (m *OS) getRealCursor() *tea.Cursor {
 		return nil
 	}
 
-	if m.ShowScrollbackBrowser {
+	if m.ShowScrollbackBrowser || m.ShowGlobalSearch || m.ShowHistoryBrowser || m.ShowWorkspacePicker || m.ShowCommandPalette || m.ShowWindowSwitcher || m.ShowThemeEditor {
 		return nil
 	}
 
@@ -2,14 +2,19 @@ package app
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 
 	"charm.land/lipgloss/v2"
 	"github.com/Gaurav-Gosain/tuios/internal/config"
 	"github.com/Gaurav-Gosain/tuios/internal/terminal"
 	"github.com/Gaurav-Gosain/tuios/internal/theme"
+	"github.com/charmbracelet/x/ansi"
 )
 
+// maxDockWorkspaceLabel is the widest a workspace name is shown in the dock.
+const maxDockWorkspaceLabel = 16
+
 // DockItem represents a single item in the dock
 type DockItem struct {
 	WindowIndex int
@@ -27,6 +32,10 @@ type DockLayout struct {
 	TruncatedCount int            // Number of items that don't fit
 	VisibleItems   []DockItem     // Items that fit and should be displayed
 	ModeInfo       ModeInfo       // Mode display information for styling
+	LeftInfo       string         // Rendered left status section
+	RightInfo      string         // Rendered right status section
+	CenterBefore   string         // Rendered center segments before the minimized windows
+	CenterAfter    string         // Rendered center segments after the minimized windows
 }
 
 // ItemPosition holds the position and size of a dock item
@@ -41,15 +50,28 @@ type ItemPosition struct {
 // to ensure consistent positioning.
 func (m *OS) CalculateDockLayout() DockLayout {
 	layout := DockLayout{}
+	left, center, right := m.statusBar().Sections()
 
-	// Build left side text (compact format)
-	layout.LeftText, layout.LeftWidth, layout.ModeInfo = m.buildDockLeftText()
-
-	// Calculate right side width
-	layout.RightWidth = m.calculateDockRightWidth()
+	// Build left side text (compact format) and the left status section
+	layout.LeftText, layout.ModeInfo = m.buildDockLeftText()
+	layout.LeftInfo = m.renderStatusSection(left, &layout)
+	layout.LeftWidth = lipgloss.Width(layout.LeftInfo) + 4 // +4 for margins/padding
 
-	// Get all dock items
-	allItems := m.getDockItems()
+	// Build the right status section and calculate its width
+	if section := m.renderStatusSection(right, &layout); section != "" {
+		layout.RightInfo = lipgloss.NewStyle().MarginRight(2).Render(section)
+	}
+	layout.RightWidth = m.calculateDockRightWidth(layout.RightInfo)
+
+	// Minimized windows are only shown when the center section has them
+	var allItems []DockItem
+	if i := slices.Index(center, "windows"); i != -1 {
+		layout.CenterBefore = m.renderStatusSection(center[:i], &layout)
+		layout.CenterAfter = m.renderStatusSection(center[i+1:], &layout)
+		allItems = m.getDockItems()
+	} else {
+		layout.CenterBefore = m.renderStatusSection(center, &layout)
+	}
 
 	// Calculate how many items fit and their positions
 	layout.calculateItemPositions(m.GetRenderWidth(), allItems)
@@ -67,8 +89,8 @@ type ModeInfo struct {
 }
 
 // buildDockLeftText builds the left side of the dock (mode + workspace info)
-// Returns the text, width, and mode info for styling
-func (m *OS) buildDockLeftText() (string, int, ModeInfo) {
+// Returns the text and mode info for styling
+func (m *OS) buildDockLeftText() (string, ModeInfo) {
 	focusedWindow := m.GetFocusedWindow()
 
 	// Build mode info (will be styled with colors in render.go)
@@ -117,32 +139,32 @@ func (m *OS) buildDockLeftText() (string, int, ModeInfo) {
 		}
 	}
 
+	// Show that the focused window is zoomed over the layout
+	if m.IsZoomed(focusedWindow) {
+		modeLabel += config.GetDockModeIconZoom()
+	}
+
 	// Build pill-style mode indicator with configurable semicircles
 	// This will be styled in render.go with the mode color
 	modeText = config.GetDockPillLeftChar() + modeLabel + config.GetDockPillRightChar()
 
-	// Count terminals (all windows across all workspaces)
+	// Count terminals and the workspaces being used (workspaces with at
+	// least 1 window) in one pass over the windows
+	counts := m.workspaceWindowCounts()
 	totalTerminals := 0
-	for i := 1; i <= m.NumWorkspaces; i++ {
-		totalTerminals += m.GetWorkspaceWindowCount(i)
-	}
-
-	// Count workspaces being used (workspaces with at least 1 window)
-	workspacesUsed := 0
-	for i := 1; i <= m.NumWorkspaces; i++ {
-		if m.GetWorkspaceWindowCount(i) > 0 {
-			workspacesUsed++
-		}
+	for _, count := range counts {
+		totalTerminals += count
 	}
+	workspacesUsed := len(counts)
 
 	// Build workspace text with stats using configurable icons
 	// Format: "2:3 • 5  3 " where:
-	// - 2:3 = workspace 2, 3 windows in current
+	// - 2:3 = workspace 2 (or its name), 3 windows in current
 	// - 5  = 5 terminals total (space before icon)
 	// - 3  = 3 workspaces in use (space before icon)
 	windowsInCurrent := m.GetWorkspaceWindowCount(m.CurrentWorkspace)
-	workspaceText := fmt.Sprintf(" %d:%d%s%d %s %d %s ",
-		m.CurrentWorkspace,
+	workspaceText := fmt.Sprintf(" %s:%d%s%d %s %d %s ",
+		ansi.Truncate(m.WorkspaceLabel(m.CurrentWorkspace), maxDockWorkspaceLabel, "…"),
 		windowsInCurrent,
 		config.GetDockSeparator(),
 		totalTerminals,
@@ -153,15 +175,12 @@ func (m *OS) buildDockLeftText() (string, int, ModeInfo) {
 	// Combine mode and workspace
 	leftText := modeText + workspaceText
 
-	// Calculate actual rendered width (handles Unicode, Nerd Fonts, etc.)
-	// Use lipgloss.Width instead of len() to get proper display width
-	width := lipgloss.Width(modeText) + lipgloss.Width(workspaceText) + 4 // +4 for margins/padding
-
-	return leftText, width, modeInfo
+	return leftText, modeInfo
 }
 
 // calculateDockRightWidth calculates the width of the right side of the dock
-func (m *OS) calculateDockRightWidth() int {
+// showing the given right status section
+func (m *OS) calculateDockRightWidth(rightInfo string) int {
 	focusedWindow := m.GetFocusedWindow()
 	inCopyMode := focusedWindow != nil && focusedWindow.CopyMode != nil && focusedWindow.CopyMode.Active
 
@@ -170,19 +189,22 @@ func (m *OS) calculateDockRightWidth() int {
 		// These widths match the actual help text lengths in render.go
 		switch focusedWindow.CopyMode.State {
 		case terminal.CopyModeNormal:
-			return 110 // Length of normal mode help text + padding
+			return 136 // Length of normal mode help text + padding
 		case terminal.CopyModeSearch:
 			return 60 // Length of search mode help text + padding
 		case terminal.CopyModeVisualChar:
 			return 90 // Length of visual char mode help text + padding
 		case terminal.CopyModeVisualLine:
-			return 35 // Length of visual line mode help text + padding
+			return 60 // Length of visual line mode help text + padding
+		case terminal.CopyModeVisualBlock:
+			return 50 // Length of visual block mode help text + padding
 		default:
 			return 32
 		}
 	}
 
-	return 32 // CPU graph (~19 chars) + space + RAM (~11 chars) = ~31 chars
+	// Use lipgloss.Width to get proper display width (handles Unicode, Nerd Fonts, etc.)
+	return lipgloss.Width(rightInfo)
 }
 
 // getDockItems returns all dock items (minimized windows in current workspace)
@@ -190,7 +212,7 @@ func (m *OS) getDockItems() []DockItem {
 	// Find all minimized/minimizing windows in current workspace
 	dockWindows := []int{}
 	for i, window := range m.Windows {
-		if window.Workspace == m.CurrentWorkspace && (window.Minimized || window.Minimizing) {
+		if window.Workspace == m.CurrentWorkspace && (window.Minimized || window.Minimizing) && window.Scratchpad == "" {
 			dockWindows = append(dockWindows, i)
 		}
 	}
@@ -243,8 +265,9 @@ func (m *OS) getDockItems() []DockItem {
 
 // calculateItemPositions determines which items fit and their X positions
 func (layout *DockLayout) calculateItemPositions(screenWidth int, allItems []DockItem) {
-	// Calculate total width of all items (including spaces between)
-	totalItemsWidth := 0
+	// Calculate total width of all items (including spaces between) and the
+	// center segments around them
+	totalItemsWidth := layout.centerSegmentsWidth()
 	for i, item := range allItems {
 		totalItemsWidth += item.Width
 		if i > 0 {
@@ -268,7 +291,7 @@ func (layout *DockLayout) calculateItemPositions(screenWidth int, allItems []Doc
 	layout.TruncatedCount = 0
 
 	// Calculate position of each item
-	currentX := layout.CenterStartX
+	currentX := layout.CenterStartX + layout.centerBeforeWidth()
 	layout.ItemPositions = make([]ItemPosition, 0, len(allItems))
 
 	for i, item := range allItems {
@@ -292,7 +315,7 @@ func (layout *DockLayout) truncateItems(screenWidth int, allItems []DockItem) {
 	const truncationIndicatorWidth = 4 // " ..." width
 
 	// Calculate max width available for items
-	maxItemsWidth := max(screenWidth-layout.LeftWidth-layout.RightWidth-truncationIndicatorWidth-4, 0)
+	maxItemsWidth := max(screenWidth-layout.LeftWidth-layout.RightWidth-layout.centerSegmentsWidth()-truncationIndicatorWidth-4, 0)
 
 	// Find how many complete items fit
 	currentWidth := 0
@@ -320,8 +343,8 @@ func (layout *DockLayout) truncateItems(screenWidth int, allItems []DockItem) {
 	}
 	layout.TruncatedCount = len(allItems) - visibleCount
 
-	// Recalculate total width including truncation indicator
-	totalWidth := currentWidth
+	// Recalculate total width including truncation indicator and center segments
+	totalWidth := currentWidth + layout.centerSegmentsWidth()
 	if layout.TruncatedCount > 0 {
 		totalWidth += 1 + truncationIndicatorWidth // space + "..."
 	}
@@ -333,7 +356,7 @@ func (layout *DockLayout) truncateItems(screenWidth int, allItems []DockItem) {
 	layout.CenterStartX = layout.LeftWidth + leftSpacer
 
 	// Calculate positions
-	currentX := layout.CenterStartX
+	currentX := layout.CenterStartX + layout.centerBeforeWidth()
 	layout.ItemPositions = make([]ItemPosition, 0, len(layout.VisibleItems))
 
 	for i, item := range layout.VisibleItems {
@@ -351,3 +374,22 @@ func (layout *DockLayout) truncateItems(screenWidth int, allItems []DockItem) {
 		currentX += item.Width
 	}
 }
+
+// centerBeforeWidth returns the width the center segments before the
+// minimized windows take, including the space after them
+func (layout *DockLayout) centerBeforeWidth() int {
+	if layout.CenterBefore == "" {
+		return 0
+	}
+	return lipgloss.Width(layout.CenterBefore) + 1
+}
+
+// centerSegmentsWidth returns the width the center segments take on both
+// sides of the minimized windows
+func (layout *DockLayout) centerSegmentsWidth() int {
+	width := layout.centerBeforeWidth()
+	if layout.CenterAfter != "" {
+		width += lipgloss.Width(layout.CenterAfter) + 1
+	}
+	return width
+}
@@ -0,0 +1,191 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Gaurav-Gosain/tuios/internal/scrollback"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+	"github.com/Gaurav-Gosain/tuios/internal/vt"
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// HistoryExportOptions returns export options using the current theme's
+// terminal colors and the terminal's size.
+func HistoryExportOptions(title string, term *vt.Emulator) scrollback.ExportOptions {
+	opts := scrollback.ExportOptions{
+		Title:      title,
+		Foreground: theme.TerminalFg(),
+		Background: theme.TerminalBg(),
+		Palette:    theme.GetANSIPalette(),
+	}
+	if term != nil {
+		opts.Width = term.Width()
+		opts.Height = term.Height()
+	}
+	return opts
+}
+
+// WriteHistoryExport renders lines in the given format to a new file in dir
+// named after the title, and returns the file's path.
+func WriteHistoryExport(dir string, lines []uv.Line, format scrollback.ExportFormat, opts scrollback.ExportOptions) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	name := exportFileName(opts.Title) + "-" + time.Now().Format("20060102-150405") + format.Extension()
+	path := filepath.Join(dir, name)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create export file: %w", err)
+	}
+	if err := scrollback.Export(f, lines, format, opts); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to write export file: %w", err)
+	}
+	return path, nil
+}
+
+// exportFileName turns a window title into a safe file name stem.
+func exportFileName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '-'
+	}, title)
+	name = strings.Trim(name, "-.")
+	if name == "" {
+		return "tuios-export"
+	}
+	return name
+}
+
+// ExportWindowData renders a window's history for the ExportWindow remote
+// command. Args are: format, window ID or name (empty for the focused
+// window), line range ("START:END", negative values count from the end) and
+// number of trailing command blocks. Range and command count are optional
+// and mutually exclusive; without them the whole history is exported.
+func (m *OS) ExportWindowData(args []string) (map[string]any, error) {
+	arg := func(i int) string {
+		if i < len(args) {
+			return args[i]
+		}
+		return ""
+	}
+
+	format, err := scrollback.ParseExportFormat(arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	var window *terminal.Window
+	if id := arg(1); id != "" {
+		window, err = m.findWindowByIDOrName(id)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		window = m.GetFocusedWindow()
+		if window == nil {
+			return nil, fmt.Errorf("no window is focused")
+		}
+	}
+	term := window.Terminal
+	if term == nil {
+		return nil, fmt.Errorf("window %s has no terminal", window.ID)
+	}
+
+	total := term.ScrollbackLen() + term.Height()
+	var lines []uv.Line
+	switch rangeSpec, commands := arg(2), arg(3); {
+	case rangeSpec != "" && commands != "":
+		return nil, fmt.Errorf("a line range and a command count cannot be combined")
+	case commands != "":
+		n, err := strconv.Atoi(commands)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid command count: %s", commands)
+		}
+		blocks := scrollback.ParseBlocks(term)
+		if len(blocks) == 0 {
+			return nil, fmt.Errorf("no commands found in scrollback")
+		}
+		lines = scrollback.BlockLines(term, blocks[max(len(blocks)-n, 0):])
+	case rangeSpec != "":
+		from, to, err := parseLineRange(rangeSpec, total)
+		if err != nil {
+			return nil, err
+		}
+		lines = scrollback.HistoryLines(term, from, to)
+	default:
+		lines = scrollback.HistoryLines(term, 0, total-1)
+	}
+
+	opts := HistoryExportOptions(m.getWindowDisplayName(window), term)
+	var sb strings.Builder
+	if err := scrollback.Export(&sb, lines, format, opts); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"window_id": window.ID,
+		"format":    string(format),
+		"lines":     len(lines),
+		"content":   sb.String(),
+	}, nil
+}
+
+// findWindowByIDOrName resolves a window by ID, then by display name.
+func (m *OS) findWindowByIDOrName(identifier string) (*terminal.Window, error) {
+	for _, w := range m.Windows {
+		if w.ID == identifier {
+			return w, nil
+		}
+	}
+	return m.findSingleWindowByName(identifier)
+}
+
+// parseLineRange parses "START:END" into inclusive absolute line indices.
+// Either side may be omitted, and negative values count back from total.
+func parseLineRange(spec string, total int) (int, int, error) {
+	startStr, endStr, ok := strings.Cut(spec, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid line range %q (expected START:END)", spec)
+	}
+
+	parse := func(s string, def int) (int, error) {
+		if s == "" {
+			return def, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid line range %q: %w", spec, err)
+		}
+		if n < 0 {
+			n += total
+		}
+		return n, nil
+	}
+
+	start, err := parse(startStr, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parse(endStr, total-1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if start > end {
+		return 0, 0, fmt.Errorf("invalid line range %q: start is after end", spec)
+	}
+	return start, end, nil
+}
@@ -0,0 +1,47 @@
+package app
+
+import "testing"
+
+func TestParseLineRange(t *testing.T) {
+	tests := []struct {
+		spec       string
+		start, end int
+		wantErr    bool
+	}{
+		{spec: "10:20", start: 10, end: 20},
+		{spec: ":20", start: 0, end: 20},
+		{spec: "10:", start: 10, end: 99},
+		{spec: "-50:", start: 50, end: 99},
+		{spec: "-10:-1", start: 90, end: 99},
+		{spec: "20:10", wantErr: true},
+		{spec: "10", wantErr: true},
+		{spec: "a:b", wantErr: true},
+	}
+	for _, tt := range tests {
+		start, end, err := parseLineRange(tt.spec, 100)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseLineRange(%q) should fail", tt.spec)
+			}
+			continue
+		}
+		if err != nil || start != tt.start || end != tt.end {
+			t.Errorf("parseLineRange(%q) = %d, %d, %v; want %d, %d", tt.spec, start, end, err, tt.start, tt.end)
+		}
+	}
+}
+
+func TestExportFileName(t *testing.T) {
+	for title, want := range map[string]string{
+		"zsh":            "zsh",
+		"vim main.go":    "vim-main.go",
+		"~/src/project":  "src-project",
+		"":               "tuios-export",
+		"../../etc":      "etc",
+		"Terminal 1 (2)": "Terminal-1--2",
+	} {
+		if got := exportFileName(title); got != want {
+			t.Errorf("exportFileName(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
@@ -14,7 +14,8 @@ type OSOptions struct {
 	// ShowKeys enables the key display overlay.
 	ShowKeys bool
 
-	// NumWorkspaces sets the number of workspaces (default: 9).
+	// NumWorkspaces sets the number of workspaces (default: 9, or more if the
+	// config names more).
 	NumWorkspaces int
 
 	// Width and Height set the initial terminal size.
@@ -39,6 +40,10 @@ type OSOptions struct {
 	// EnableGraphicsPassthrough enables Kitty/Sixel graphics passthrough.
 	// This should be true for terminal sessions, false for web.
 	EnableGraphicsPassthrough bool
+
+	// WatchConfig reloads config.toml and custom themes when they change on
+	// disk. Leave it off when the config is not read from the config file.
+	WatchConfig bool
 }
 
 // NewOS creates a new OS instance with the given options.
@@ -49,6 +54,9 @@ func NewOS(opts OSOptions) *OS {
 	if numWorkspaces <= 0 {
 		numWorkspaces = 9
 	}
+	if opts.KeybindRegistry != nil {
+		numWorkspaces = max(numWorkspaces, len(opts.KeybindRegistry.WorkspaceNames()))
+	}
 
 	os := &OS{
 		// Core state
@@ -57,14 +65,17 @@ func NewOS(opts OSOptions) *OS {
 		StateSyncChan:    make(chan *session.SessionState, 10),
 		ClientEventChan:  make(chan ClientEvent, 10),
 		MasterRatio:      0.5,
+		MasterCount:      1,
 		CurrentWorkspace: 1,
 		NumWorkspaces:    numWorkspaces,
 
 		// Workspace state maps
-		WorkspaceFocus:       make(map[int]int),
-		WorkspaceLayouts:     make(map[int][]WindowLayout),
-		WorkspaceHasCustom:   make(map[int]bool),
-		WorkspaceMasterRatio: make(map[int]float64),
+		WorkspaceFocus:        make(map[int]int),
+		WorkspaceLayouts:      make(map[int][]WindowLayout),
+		WorkspaceHasCustom:    make(map[int]bool),
+		WorkspaceMasterRatio:  make(map[int]float64),
+		WorkspaceMasterCount:  make(map[int]int),
+		WorkspaceTilingLayout: make(map[int]string),
 
 		// Resize tracking
 		PendingResizes: make(map[string][2]int),
@@ -96,6 +107,10 @@ func NewOS(opts OSOptions) *OS {
 		os.SixelPassthrough = NewSixelPassthrough()
 	}
 
+	if opts.WatchConfig {
+		os.configWatch = newConfigWatcher()
+	}
+
 	// Initialize PTY subscription tracking for daemon sessions
 	if opts.IsDaemonSession {
 		os.SubscribedPTYs = make(map[string]bool)
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/Gaurav-Gosain/tuios/internal/ui"
+)
+
+// IsTiledWindow reports whether w is positioned by the tiling layout: tiling
+// is enabled and the window does not float above it.
+func (m *OS) IsTiledWindow(w *terminal.Window) bool {
+	return w != nil && m.AutoTiling && !w.Floating
+}
+
+// isTileable reports whether w takes part in tiling the current workspace.
+func (m *OS) isTileable(w *terminal.Window) bool {
+	return w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing && !w.Floating
+}
+
+// sameLayer reports whether two windows are in the same stacking layer.
+// With tiling on, floating windows form a layer above the tiled ones.
+func (m *OS) sameLayer(a, b *terminal.Window) bool {
+	return !m.AutoTiling || a.Floating == b.Floating
+}
+
+// StackZ returns the z-index a window is drawn and hit-tested at. Floating
+// windows stay above every tiled window while tiling is on, and a zoomed
+// window above everything.
+func (m *OS) StackZ(w *terminal.Window) int {
+	if m.IsZoomed(w) {
+		return w.Z + 2*len(m.Windows)
+	}
+	if m.AutoTiling && w.Floating {
+		return w.Z + len(m.Windows)
+	}
+	return w.Z
+}
+
+// ToggleFloating switches the focused window between floating and tiled.
+func (m *OS) ToggleFloating() error {
+	focused := m.GetFocusedWindow()
+	if focused == nil {
+		return fmt.Errorf("no window is focused")
+	}
+	m.SetWindowFloating(focused, !focused.Floating)
+	return nil
+}
+
+// SetWindowFloating makes a window float above the tiling layout or returns
+// it to the layout. A window that starts floating is centered over the
+// workspace; one that stops floating is inserted into the BSP tree and is no
+// longer sticky.
+func (m *OS) SetWindowFloating(w *terminal.Window, floating bool) {
+	if w.Floating == floating {
+		return
+	}
+
+	if floating {
+		m.floatWindow(w)
+		if m.AutoTiling && w.Workspace == m.CurrentWorkspace && !w.Minimized {
+			m.centerFloatingWindow(w, 2.0/3, 2.0/3)
+		}
+	} else {
+		w.Floating = false
+		w.Sticky = false
+		w.InvalidateCache()
+		m.LogInfo("Window %s floating=false", w.ID[:min(8, len(w.ID))])
+		if m.AutoTiling && w.Workspace == m.CurrentWorkspace && !w.Minimized {
+			m.AddWindowToBSPTree(w)
+		}
+	}
+	m.SyncStateToDaemon()
+}
+
+// floatWindow marks a window floating and takes it out of its workspace's
+// BSP tree, retiling the remaining windows if they are on screen.
+func (m *OS) floatWindow(w *terminal.Window) {
+	w.Floating = true
+	w.InvalidateCache()
+	m.LogInfo("Window %s floating=true", w.ID[:min(8, len(w.ID))])
+
+	if tree := m.WorkspaceTrees[w.Workspace]; tree != nil {
+		tree.RemoveWindow(m.getWindowIntID(w.ID))
+	}
+	if m.AutoTiling && w.Workspace == m.CurrentWorkspace {
+		m.ApplyBSPLayout()
+	}
+}
+
+// centerFloatingWindow animates a floating window to the given fractions of
+// the usable area, centered.
+func (m *OS) centerFloatingWindow(w *terminal.Window, widthFrac, heightFrac float64) {
+	bounds := m.GetBSPBounds()
+	m.centerWindow(w, int(float64(bounds.W)*widthFrac), int(float64(bounds.H)*heightFrac))
+}
+
+// centerWindow animates a window to the given size, centered in the usable
+// area.
+func (m *OS) centerWindow(w *terminal.Window, width, height int) {
+	bounds := m.GetBSPBounds()
+	width = min(max(width, config.DefaultWindowWidth), max(bounds.W, config.DefaultWindowWidth))
+	height = min(max(height, config.DefaultWindowHeight), max(bounds.H, config.DefaultWindowHeight))
+	x := bounds.X + (bounds.W-width)/2
+	y := bounds.Y + (bounds.H-height)/2
+
+	anim := ui.NewSnapAnimation(w, x, y, width, height, config.GetAnimationDuration())
+	if anim != nil {
+		m.Animations = append(m.Animations, anim)
+	}
+}
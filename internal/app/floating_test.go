@@ -0,0 +1,48 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// TestSetWindowFloating tests that floating windows leave the tiling layout
+// and stay above tiled windows
+func TestSetWindowFloating(t *testing.T) {
+	m := newTestOS(
+		&terminal.Window{ID: "window-one", Workspace: 1, Z: 0},
+		&terminal.Window{ID: "window-two", Workspace: 1, Z: 1},
+		&terminal.Window{ID: "window-three", Workspace: 1, Z: 2},
+	)
+	m.TileAllWindows()
+	tree := m.WorkspaceTrees[1]
+	if tree.WindowCount() != 3 {
+		t.Fatalf("tree has %d windows, want 3", tree.WindowCount())
+	}
+
+	floating := m.Windows[0]
+	m.SetWindowFloating(floating, true)
+	if tree.HasWindow(m.getWindowIntID(floating.ID)) {
+		t.Error("floating window should be removed from the BSP tree")
+	}
+	if m.IsTiledWindow(floating) || !m.IsTiledWindow(m.Windows[1]) {
+		t.Error("IsTiledWindow should be false only for the floating window")
+	}
+	if m.StackZ(floating) <= m.StackZ(m.Windows[2]) {
+		t.Error("floating window should stack above tiled windows")
+	}
+
+	m.TileAllWindows()
+	if tree := m.WorkspaceTrees[1]; tree.HasWindow(m.getWindowIntID(floating.ID)) {
+		t.Error("retiling should not pull the floating window back into the tree")
+	}
+
+	if state := m.BuildSessionState(); !state.Windows[0].Floating {
+		t.Error("floating state should be saved in the session")
+	}
+
+	m.SetWindowFloating(floating, false)
+	if !m.WorkspaceTrees[1].HasWindow(m.getWindowIntID(floating.ID)) {
+		t.Error("tiling the window again should insert it into the tree")
+	}
+}
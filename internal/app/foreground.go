@@ -0,0 +1,99 @@
+package app
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+)
+
+// Local windows look up their foreground process on their own PTY. The PTYs
+// of daemon windows live in the daemon, so while rules match on commands the
+// client polls the daemon for the foreground process of every PTY and keeps
+// it on the windows.
+
+// ForegroundSyncMsg carries the foreground processes the daemon reported.
+// Polled is false when no rule needed them.
+type ForegroundSyncMsg struct {
+	Polled bool
+	PTYs   map[string]ForegroundProcess
+	Err    error
+}
+
+// ForegroundProcess is the foreground process of a PTY.
+type ForegroundProcess struct {
+	PID     int
+	Command string
+}
+
+// ForegroundSyncCmd schedules the next poll of the daemon's foreground
+// processes. It returns nil outside daemon sessions.
+func (m *OS) ForegroundSyncCmd() tea.Cmd {
+	client := m.DaemonClient
+	if client == nil {
+		return nil
+	}
+	poll := m.rulesMatchCommands()
+	return tea.Tick(config.ForegroundSyncInterval, func(time.Time) tea.Msg {
+		if !poll {
+			return ForegroundSyncMsg{}
+		}
+		ptys, err := client.ListPTYs()
+		if err != nil {
+			return ForegroundSyncMsg{Polled: true, Err: err}
+		}
+		procs := make(map[string]ForegroundProcess, len(ptys))
+		for _, p := range ptys {
+			procs[p.ID] = ForegroundProcess{PID: p.ForegroundPID, Command: p.ForegroundCmd}
+		}
+		return ForegroundSyncMsg{Polled: true, PTYs: procs}
+	})
+}
+
+// rulesMatchCommands reports whether a window or passthrough rule matches
+// the foreground command.
+func (m *OS) rulesMatchCommands() bool {
+	for _, rule := range m.windowRules() {
+		if rule.Command != "" {
+			return true
+		}
+	}
+	if m.KeybindRegistry != nil {
+		for _, rule := range m.KeybindRegistry.PassthroughRules() {
+			if rule.Command != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetForegroundProcesses stores the foreground processes of daemon windows
+// and applies the window rules of windows whose command changed. It reports
+// whether any window changed.
+func (m *OS) SetForegroundProcesses(msg ForegroundSyncMsg) bool {
+	if !msg.Polled {
+		return false
+	}
+	if msg.Err != nil {
+		m.LogWarn("Foreground process sync failed: %v", msg.Err)
+		return false
+	}
+	changed := false
+	for _, w := range m.Windows {
+		proc, ok := msg.PTYs[w.PTYID]
+		if w.PTYID == "" || !ok || (proc.PID == w.ForegroundPID && proc.Command == w.ForegroundCmd) {
+			continue
+		}
+		commandChanged := proc.Command != w.ForegroundCmd
+		w.ForegroundPID, w.ForegroundCmd = proc.PID, proc.Command
+		if commandChanged {
+			if m.foregroundCache.windowID == w.ID {
+				m.foregroundCache = foregroundCommandCache{}
+			}
+			m.ApplyWindowRules(w)
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,176 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// GlobalSearchHit is a single search match in some window's scrollback.
+type GlobalSearchHit struct {
+	WindowID    string               // ID of the window containing the match
+	WindowTitle string               // Display name of the window
+	Workspace   int                  // Workspace the window lives in
+	Match       terminal.SearchMatch // Match position (absolute line + columns)
+	LineText    string               // Full text of the matching line
+}
+
+// GlobalSearchState holds the state for the search-across-all-windows overlay.
+type GlobalSearchState struct {
+	Query         string            // Current search query (RE2, smartcase)
+	Hits          []GlobalSearchHit // Matches grouped by window, in window order
+	SelectedIndex int               // Index of the selected hit
+	ScrollOffset  int               // First visible row of the result list
+	Generation    int               // Bumped on every query change
+}
+
+// GlobalSearchMsg runs the global search once the query has stopped
+// changing. It is dropped if the query changed again after it was sent.
+type GlobalSearchMsg struct {
+	Generation int
+}
+
+// globalSearchRow is one rendered row of the result list:
+// either a window header or a hit belonging to the header above it.
+type globalSearchRow struct {
+	header string
+	hit    int // Index into Hits, -1 for header rows
+}
+
+// CloseGlobalSearch hides the global search overlay and drops its results.
+func (m *OS) CloseGlobalSearch() {
+	m.ShowGlobalSearch = false
+	m.GlobalSearch = nil
+}
+
+// globalSearchRows flattens hits into header + hit rows for rendering.
+func (s *GlobalSearchState) globalSearchRows() []globalSearchRow {
+	var rows []globalSearchRow
+	lastWindow := ""
+	for i, hit := range s.Hits {
+		if hit.WindowID != lastWindow {
+			count := 0
+			for _, h := range s.Hits[i:] {
+				if h.WindowID != hit.WindowID {
+					break
+				}
+				count++
+			}
+			rows = append(rows, globalSearchRow{
+				header: fmt.Sprintf("[%d] %s (%d)", hit.Workspace, hit.WindowTitle, count),
+				hit:    -1,
+			})
+			lastWindow = hit.WindowID
+		}
+		rows = append(rows, globalSearchRow{hit: i})
+	}
+	return rows
+}
+
+// RenderGlobalSearch renders the global search overlay.
+func (m *OS) RenderGlobalSearch(width, height int) string {
+	if m.GlobalSearch == nil {
+		return ""
+	}
+	s := m.GlobalSearch
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.WelcomeTitle()).
+		Bold(true)
+
+	headerStyle := lipgloss.NewStyle().
+		Foreground(theme.WelcomeSubtitle()).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("0")).
+		Background(theme.HelpTabActive()).
+		Bold(true)
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(theme.WelcomeText())
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpGray())
+
+	keyStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpKeyBadge()).
+		Bold(true)
+
+	boxWidth := max(min(width-8, 100), 30)
+	innerWidth := boxWidth - 6 // border(2) + padding(4)
+	maxVisible := max(height-14, 3)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Search All Windows"))
+	lines = append(lines, "")
+
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.HelpBorder()).
+		Padding(0, 1).
+		Width(innerWidth)
+	lines = append(lines, inputStyle.Render("/"+s.Query+"█"))
+	lines = append(lines, "")
+
+	rows := s.globalSearchRows()
+	switch {
+	case s.Query == "":
+		lines = append(lines, dimStyle.Render("Type a regex to search the scrollback of every window"))
+	case len(rows) == 0:
+		lines = append(lines, dimStyle.Render("No matches"))
+	default:
+		// Keep the selected hit visible
+		selectedRow := 0
+		for i, row := range rows {
+			if row.hit == s.SelectedIndex {
+				selectedRow = i
+				break
+			}
+		}
+		// The row above is kept in view too, so the window header above the
+		// first hit of a window shows when scrolling up
+		s.ScrollOffset = scrollToSelection(max(selectedRow-1, 0), s.ScrollOffset, maxVisible, len(rows))
+		s.ScrollOffset = scrollToSelection(selectedRow, s.ScrollOffset, maxVisible, len(rows))
+		endRow := min(s.ScrollOffset+maxVisible, len(rows))
+
+		for _, row := range rows[s.ScrollOffset:endRow] {
+			if row.hit < 0 {
+				lines = append(lines, headerStyle.Render(ansi.Truncate(row.header, innerWidth, "…")))
+				continue
+			}
+			hit := s.Hits[row.hit]
+			text := fmt.Sprintf("  %6d: %s", hit.Match.Line+1, strings.TrimSpace(hit.LineText))
+			text = ansi.Truncate(text, innerWidth, "…")
+			if row.hit == s.SelectedIndex {
+				lines = append(lines, selectedStyle.Render(text))
+			} else {
+				lines = append(lines, normalStyle.Render(text))
+			}
+		}
+
+		lines = append(lines, "")
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("%d matches", len(s.Hits))))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, dimStyle.Render(
+		keyStyle.Render("↑/↓")+" Select  "+
+			keyStyle.Render("Enter")+" Jump  "+
+			keyStyle.Render("Esc")+" Close"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.HelpBorder()).
+		Padding(1, 2).
+		Width(boxWidth).
+		Background(theme.LogViewerBg())
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(content))
+}
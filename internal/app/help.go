@@ -39,7 +39,7 @@ func GetHelpCategories(registry *config.KeybindRegistry) []HelpCategory {
 			Bindings: generateCategoryBindings(registry, "Window Management", []string{
 				"new_window", "close_window", "rename_window",
 				"minimize_window", "restore_all",
-				"next_window", "prev_window",
+				"next_window", "prev_window", "window_switcher",
 				"terminal_next_window", "terminal_prev_window",
 			}),
 		},
@@ -65,7 +65,22 @@ func GetHelpCategories(registry *config.KeybindRegistry) []HelpCategory {
 		{
 			Name: "BSP",
 			Bindings: generateCategoryBindings(registry, "BSP", []string{
-				"split_horizontal", "split_vertical", "rotate_split",
+				"split_horizontal", "split_vertical", "rotate_split", "undo_layout", "redo_layout",
+				"save_layout", "load_layout",
+			}),
+		},
+		{
+			Name: "Layouts",
+			Bindings: generateCategoryBindings(registry, "Layouts", []string{
+				"next_layout", "prev_layout", "increase_masters", "decrease_masters", "toggle_floating",
+				"toggle_zoom",
+				"toggle_sticky",
+			}),
+		},
+		{
+			Name: "Containers",
+			Bindings: generateCategoryBindings(registry, "Containers", []string{
+				"toggle_tabbed", "toggle_stacked", "next_tab", "prev_tab", "join_group", "leave_group",
 			}),
 		},
 		{
@@ -77,7 +92,7 @@ func GetHelpCategories(registry *config.KeybindRegistry) []HelpCategory {
 			Bindings: generateCategoryBindings(registry, "Modes", []string{
 				"enter_terminal_mode", "enter_window_mode",
 				"terminal_exit_mode",
-				"toggle_help", "quit",
+				"toggle_help", "command_palette", "theme_editor", "quit",
 			}),
 		},
 		{
@@ -161,6 +176,20 @@ func generateWorkspaceBindings(registry *config.KeybindRegistry) []HelpBinding {
 		}
 	}
 
+	if keys := registry.GetKeys("workspace_picker"); len(keys) > 0 {
+		bindings = append(bindings, HelpBinding{
+			Action:      "workspace_picker",
+			Keys:        keys,
+			Description: "Pick a workspace by number or name",
+			Category:    "Workspaces",
+		})
+	}
+	bindings = append(bindings, HelpBinding{
+		Keys:        []string{config.LeaderKey + ", w, r"},
+		Description: "Rename the current workspace",
+		Category:    "Workspaces",
+	})
+
 	return bindings
 }
 
@@ -173,9 +202,16 @@ func generateCopyModeBindings() []HelpBinding {
 		{Keys: []string{"0, ^, $"}, Description: "Line start/first/end", Category: "Copy Mode"},
 		{Keys: []string{"gg, G"}, Description: "Jump top/bottom", Category: "Copy Mode"},
 		{Keys: []string{"ctrl+u, ctrl+d"}, Description: "Half page up/down", Category: "Copy Mode"},
-		{Keys: []string{"/, ?, n, N"}, Description: "Search", Category: "Copy Mode"},
-		{Keys: []string{"v, V"}, Description: "Visual char/line", Category: "Copy Mode"},
+		{Keys: []string{"/, ?, n, N"}, Description: "Regex search (smartcase)", Category: "Copy Mode"},
+		{Keys: []string{config.LeaderKey + ", /"}, Description: "Search all windows", Category: "Copy Mode"},
+		{Keys: []string{config.LeaderKey + ", H"}, Description: "History of closed windows", Category: "Copy Mode"},
+		{Keys: []string{"[[, ]]"}, Description: "Previous/next shell prompt", Category: "Copy Mode"},
+		{Keys: []string{"ma, 'a, `a"}, Description: "Set/jump to mark", Category: "Copy Mode"},
+		{Keys: []string{"v, V, ctrl+v"}, Description: "Visual char/line/block", Category: "Copy Mode"},
+		{Keys: []string{"io, ao"}, Description: "Select command output (visual)", Category: "Copy Mode"},
 		{Keys: []string{"y, c"}, Description: "Yank to clipboard", Category: "Copy Mode"},
+		{Keys: []string{"\"a y, \"A y"}, Description: "Yank/append to register", Category: "Copy Mode"},
+		{Keys: []string{config.LeaderKey + ", ]"}, Description: "Paste from register", Category: "Copy Mode"},
 		{Keys: []string{"i, q, Esc"}, Description: "Exit copy mode", Category: "Copy Mode"},
 	}
 }
@@ -231,6 +267,7 @@ func generatePrefixBindings(registry *config.KeybindRegistry) []HelpBinding {
 		"prefix_toggle_tiling", "prefix_workspace", "prefix_minimize",
 		"prefix_window", "prefix_detach", "prefix_selection",
 		"prefix_help", "prefix_quit", "prefix_fullscreen",
+		"prefix_command_palette", "prefix_window_switcher",
 	}
 
 	// Add debug commands (Leader Key + D ...)
@@ -0,0 +1,22 @@
+package app
+
+import "github.com/Gaurav-Gosain/tuios/internal/terminal"
+
+// newTestOS returns a 120x40 OS on workspace 1 of 9 with tiling on, holding
+// windows and focusing the first one. Windows are not tiled yet.
+func newTestOS(windows ...*terminal.Window) *OS {
+	return &OS{
+		Width:                120,
+		Height:               40,
+		NumWorkspaces:        9,
+		CurrentWorkspace:     1,
+		AutoTiling:           true,
+		WorkspaceFocus:       make(map[int]int),
+		WorkspaceLayouts:     make(map[int][]WindowLayout),
+		WorkspaceHasCustom:   make(map[int]bool),
+		WorkspaceMasterRatio: make(map[int]float64),
+		WorkspaceMasterCount: make(map[int]int),
+		Windows:              windows,
+		FocusedWindow:        0,
+	}
+}
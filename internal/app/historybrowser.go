@@ -0,0 +1,125 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// HistoryBrowserState holds the state for the saved scrollback history picker.
+type HistoryBrowserState struct {
+	Entries       []terminal.HistoryInfo // Saved histories, newest first
+	SelectedIndex int                    // Index of the selected entry
+	ScrollOffset  int                    // First visible entry
+}
+
+// CloseHistoryBrowser hides the history picker.
+func (m *OS) CloseHistoryBrowser() {
+	m.ShowHistoryBrowser = false
+	m.HistoryBrowser = nil
+}
+
+// historyErrorPrefix starts the notification shown when a window could not
+// create its history file.
+const historyErrorPrefix = "Scrollback history not saved: "
+
+// reportHistoryError warns when a window could not create its history file.
+// Windows that fail while the warning is still shown add no new one.
+func (m *OS) reportHistoryError(w *terminal.Window) {
+	err := w.HistoryError()
+	if err == nil {
+		return
+	}
+	for _, notif := range m.Notifications {
+		if strings.HasPrefix(notif.Message, historyErrorPrefix) {
+			m.LogError("%s%v", historyErrorPrefix, err)
+			return
+		}
+	}
+	m.ShowNotification(historyErrorPrefix+err.Error(), "warning", config.NotificationDuration)
+}
+
+// RenderHistoryBrowser renders the saved scrollback history picker.
+func (m *OS) RenderHistoryBrowser(width, height int) string {
+	if m.HistoryBrowser == nil {
+		return ""
+	}
+	s := m.HistoryBrowser
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.WelcomeTitle()).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("0")).
+		Background(theme.HelpTabActive()).
+		Bold(true)
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(theme.WelcomeText())
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpGray())
+
+	keyStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpKeyBadge()).
+		Bold(true)
+
+	boxWidth := max(min(width-8, 100), 30)
+	innerWidth := boxWidth - 6 // border(2) + padding(4)
+	maxVisible := max(height-12, 3)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Window History"))
+	lines = append(lines, "")
+
+	if len(s.Entries) == 0 {
+		lines = append(lines, dimStyle.Render("No saved history"))
+		if !config.ScrollbackPersist {
+			lines = append(lines, dimStyle.Render("Set appearance.scrollback_persist = true to keep history on disk"))
+		}
+	} else {
+		s.ScrollOffset = scrollToSelection(s.SelectedIndex, s.ScrollOffset, maxVisible, len(s.Entries))
+		end := min(s.ScrollOffset+maxVisible, len(s.Entries))
+
+		for i, entry := range s.Entries[s.ScrollOffset:end] {
+			status := fmt.Sprintf("%d lines", entry.Lines)
+			if entry.Closed.IsZero() {
+				status = "not closed cleanly"
+			}
+			text := fmt.Sprintf("%s  %s  (%s)", entry.Started.Format("2006-01-02 15:04"), entry.Title, status)
+			text = ansi.Truncate(text, innerWidth, "…")
+			if s.ScrollOffset+i == s.SelectedIndex {
+				lines = append(lines, selectedStyle.Render(text))
+			} else {
+				lines = append(lines, normalStyle.Render(text))
+			}
+		}
+
+		lines = append(lines, "")
+		lines = append(lines, dimStyle.Render(ansi.Truncate(config.GetHistoryDirectory(), innerWidth, "…")))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, dimStyle.Render(
+		keyStyle.Render("↑/↓")+" Select  "+
+			keyStyle.Render("Enter")+" Open  "+
+			keyStyle.Render("d")+" Delete  "+
+			keyStyle.Render("Esc")+" Close"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.HelpBorder()).
+		Padding(1, 2).
+		Width(boxWidth).
+		Background(theme.LogViewerBg())
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, boxStyle.Render(content))
+}
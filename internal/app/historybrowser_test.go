@@ -0,0 +1,38 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// TestReportHistoryError tests that windows whose history file cannot be
+// created share one warning
+func TestReportHistoryError(t *testing.T) {
+	blocker := filepath.Join(t.TempDir(), "history")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	defer func(persist bool, dir string) {
+		config.ScrollbackPersist, config.ScrollbackHistoryDir = persist, dir
+	}(config.ScrollbackPersist, config.ScrollbackHistoryDir)
+	config.ScrollbackPersist = true
+	config.ScrollbackHistoryDir = filepath.Join(blocker, "sub")
+
+	m := &OS{}
+	for _, id := range []string{"window-one", "window-two"} {
+		w := terminal.NewDaemonWindow(id, id, 0, 0, 40, 10, 0, "pty-"+id)
+		defer w.Close()
+		if w.HistoryError() == nil {
+			t.Fatal("HistoryError should report the history directory failure")
+		}
+		m.reportHistoryError(w)
+	}
+	if len(m.Notifications) != 1 || !strings.HasPrefix(m.Notifications[0].Message, historyErrorPrefix) {
+		t.Errorf("notifications = %+v, want one history warning", m.Notifications)
+	}
+}
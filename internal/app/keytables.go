@@ -0,0 +1,46 @@
+package app
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// User-defined key tables come from [keybindings.tables.<name>] in the
+// config. While a table is active the prefix stays active too, so the
+// which-key popup shows the table's bindings.
+
+// EnterKeyTable makes a key table wait for the next key.
+func (m *OS) EnterKeyTable(name string) {
+	m.ActiveKeyTable = name
+	m.PrefixActive = true
+	m.LastPrefixTime = time.Now()
+}
+
+// LeaveKeyTable leaves the active key table and the prefix.
+func (m *OS) LeaveKeyTable() {
+	m.ActiveKeyTable = ""
+	m.PrefixActive = false
+}
+
+// checkKeyTableTimeout leaves the active key table when its timeout passed
+// without a key press.
+func (m *OS) checkKeyTableTimeout() {
+	if m.ActiveKeyTable == "" || m.KeybindRegistry == nil {
+		return
+	}
+	table, ok := m.KeybindRegistry.GetKeyTable(m.ActiveKeyTable)
+	if !ok {
+		m.LeaveKeyTable()
+		return
+	}
+	if timeout := table.TimeoutDuration(); timeout > 0 && time.Since(m.LastPrefixTime) > timeout {
+		m.LeaveKeyTable()
+	}
+}
+
+// RunTapeSnippet runs tape commands given inline, such as a key table
+// binding, one command at a time like a remote tape script.
+func (m *OS) RunTapeSnippet(script string) (tea.Cmd, error) {
+	return m.executeTapeScript(script, "")
+}
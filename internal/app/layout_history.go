@@ -0,0 +1,200 @@
+package app
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/layout"
+	"github.com/Gaurav-Gosain/tuios/internal/ui"
+)
+
+// layoutSnapshot is a workspace layout that undo can return to: the tiling
+// layout, the BSP tree when tiling with it, and every visible window's
+// geometry.
+type layoutSnapshot struct {
+	layout   string
+	tree     *layout.BSPTree
+	geometry map[string]layout.Rect
+	key      string // Canonical encoding used to detect changes
+	stamp    uint64 // layoutStamp when captured
+}
+
+// layoutHistory is the undo and redo stack of one workspace.
+type layoutHistory struct {
+	current  *layoutSnapshot // Last settled layout
+	undo     []*layoutSnapshot
+	redo     []*layoutSnapshot
+	settling bool // Set after undo/redo so the restored layout is not recorded as a change
+}
+
+// layoutStamp hashes the current workspace's tiling layout and window
+// geometry without allocating. BSP tree changes show up in the geometry
+// they produce, so an unchanged stamp means there is nothing to record.
+func (m *OS) layoutStamp() uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	writeInt := func(v int) {
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		_, _ = h.Write(buf[:])
+	}
+	_, _ = h.Write([]byte(m.CurrentLayoutName()))
+	if m.AutoTiling && !m.UsingLayoutEngine() {
+		writeInt(1)
+	} else {
+		writeInt(0)
+	}
+	for _, w := range m.Windows {
+		if w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing {
+			_, _ = h.Write([]byte(w.ID))
+			writeInt(w.X)
+			writeInt(w.Y)
+			writeInt(w.Width)
+			writeInt(w.Height)
+		}
+	}
+	return h.Sum64()
+}
+
+// captureLayout snapshots the current workspace's layout.
+func (m *OS) captureLayout() *layoutSnapshot {
+	snap := &layoutSnapshot{layout: m.CurrentLayoutName(), geometry: make(map[string]layout.Rect), stamp: m.layoutStamp()}
+	if m.AutoTiling && !m.UsingLayoutEngine() {
+		snap.tree = m.WorkspaceTrees[m.CurrentWorkspace].Clone()
+	}
+	for _, w := range m.Windows {
+		if w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing {
+			snap.geometry[w.ID] = layout.Rect{X: w.X, Y: w.Y, W: w.Width, H: w.Height}
+		}
+	}
+
+	key, _ := json.Marshal(struct {
+		Layout   string
+		Tree     *layout.SerializedBSPTree
+		Geometry map[string]layout.Rect
+	}{snap.layout, snap.tree.Serialize(), snap.geometry})
+	snap.key = string(key)
+	return snap
+}
+
+// workspaceHistory returns the layout history of the current workspace.
+func (m *OS) workspaceHistory() *layoutHistory {
+	if m.layoutHistories == nil {
+		m.layoutHistories = make(map[int]*layoutHistory)
+	}
+	h := m.layoutHistories[m.CurrentWorkspace]
+	if h == nil {
+		h = &layoutHistory{}
+		m.layoutHistories[m.CurrentWorkspace] = h
+	}
+	return h
+}
+
+// recordLayoutHistory pushes the previous layout onto the undo stack when the
+// current workspace's layout changed. It runs once the layout has settled, so
+// animations and mouse drags are recorded as a single change. It runs every
+// tick, so the layout is only captured when its stamp changed.
+func (m *OS) recordLayoutHistory() {
+	if m.HasActiveAnimations() || m.InteractionMode || m.Dragging || m.Resizing || m.ZoomedWindowID != "" {
+		return
+	}
+
+	h := m.workspaceHistory()
+	if h.current != nil && h.current.stamp == m.layoutStamp() {
+		h.settling = false
+		return
+	}
+	snap := m.captureLayout()
+	if h.current != nil && h.current.key == snap.key {
+		h.settling = false
+		return
+	}
+	if h.current != nil && !h.settling {
+		h.undo = append(h.undo, h.current)
+		if len(h.undo) > config.LayoutHistoryLimit {
+			h.undo = h.undo[len(h.undo)-config.LayoutHistoryLimit:]
+		}
+		h.redo = nil
+	}
+	h.current = snap
+	h.settling = false
+}
+
+// UndoLayout restores the current workspace's previous layout.
+func (m *OS) UndoLayout() error {
+	m.recordLayoutHistory()
+	h := m.workspaceHistory()
+	if len(h.undo) == 0 {
+		return fmt.Errorf("nothing to undo")
+	}
+	snap := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, h.current)
+	m.restoreLayout(h, snap)
+	return nil
+}
+
+// RedoLayout reapplies the layout change undone last.
+func (m *OS) RedoLayout() error {
+	m.recordLayoutHistory()
+	h := m.workspaceHistory()
+	if len(h.redo) == 0 {
+		return fmt.Errorf("nothing to redo")
+	}
+	snap := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, h.current)
+	m.restoreLayout(h, snap)
+	return nil
+}
+
+// restoreLayout applies a snapshot to the current workspace. Windows closed
+// since are dropped from the restored tree and windows opened since are
+// inserted into it, since their terminals cannot be brought back or removed.
+func (m *OS) restoreLayout(h *layoutHistory, snap *layoutSnapshot) {
+	h.current = snap
+	h.settling = true
+
+	if snap.layout != m.CurrentLayoutName() {
+		if m.WorkspaceTilingLayout == nil {
+			m.WorkspaceTilingLayout = make(map[int]string)
+		}
+		if layout.IsBSP(snap.layout) {
+			delete(m.WorkspaceTilingLayout, m.CurrentWorkspace)
+		} else {
+			m.WorkspaceTilingLayout[m.CurrentWorkspace] = snap.layout
+		}
+	}
+
+	if snap.tree != nil && m.AutoTiling && !m.UsingLayoutEngine() {
+		tree := snap.tree.Clone()
+		for _, id := range tree.GetAllWindowIDs() {
+			if w := m.getWindowByIntID(id); w == nil || !m.isTileable(w) {
+				tree.RemoveWindow(id)
+			}
+		}
+		m.WorkspaceTrees[m.CurrentWorkspace] = tree
+		for _, w := range m.Windows {
+			if m.isTileable(w) && !tree.HasWindow(m.getWindowIntID(w.ID)) {
+				m.AddWindowToBSPTree(w)
+			}
+		}
+	}
+
+	for _, w := range m.Windows {
+		rect, ok := snap.geometry[w.ID]
+		if !ok || w.Workspace != m.CurrentWorkspace || w.Minimized || m.IsTiledWindow(w) {
+			continue
+		}
+		if anim := ui.NewSnapAnimation(w, rect.X, rect.Y, rect.W, rect.H, config.GetAnimationDuration()); anim != nil {
+			m.Animations = append(m.Animations, anim)
+		}
+	}
+
+	if m.AutoTiling {
+		m.ApplyBSPLayout()
+	}
+	m.SyncStateToDaemon()
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// TestLayoutUndoRedo tests that layout changes can be undone and redone and
+// that windows closed in between are dropped from the restored tree
+func TestLayoutUndoRedo(t *testing.T) {
+	config.AnimationsEnabled = false
+	defer func() { config.AnimationsEnabled = true }()
+
+	m := newTestOS(
+		&terminal.Window{ID: "window-one", Workspace: 1, Z: 0},
+		&terminal.Window{ID: "window-two", Workspace: 1, Z: 1},
+		&terminal.Window{ID: "window-three", Workspace: 1, Z: 2},
+	)
+	m.TileAllWindows()
+	m.recordLayoutHistory()
+	first := m.Windows[0]
+	before := first.Width
+
+	if err := m.UndoLayout(); err == nil {
+		t.Error("expected nothing to undo before any change")
+	}
+
+	// A settled layout is not captured again
+	current := m.workspaceHistory().current
+	m.recordLayoutHistory()
+	if m.workspaceHistory().current != current {
+		t.Error("an unchanged layout should keep its snapshot")
+	}
+
+	m.SwapWindowsInBSPTree(m.Windows[0], m.Windows[2])
+	m.ApplyBSPLayout()
+	m.recordLayoutHistory()
+	swapped := first.X
+
+	if err := m.UndoLayout(); err != nil {
+		t.Fatalf("UndoLayout: %v", err)
+	}
+	m.recordLayoutHistory()
+	if first.X == swapped || first.Width != before {
+		t.Error("undo should restore the layout before the swap")
+	}
+
+	if err := m.RedoLayout(); err != nil {
+		t.Fatalf("RedoLayout: %v", err)
+	}
+	m.recordLayoutHistory()
+	if first.X != swapped {
+		t.Error("redo should reapply the swap")
+	}
+
+	// Close a window, then undo: the tree must not bring it back
+	closed := m.Windows[1]
+	m.WorkspaceTrees[1].RemoveWindow(m.getWindowIntID(closed.ID))
+	m.Windows = []*terminal.Window{m.Windows[0], m.Windows[2]}
+	m.ApplyBSPLayout()
+	m.recordLayoutHistory()
+
+	if err := m.UndoLayout(); err != nil {
+		t.Fatalf("UndoLayout: %v", err)
+	}
+	tree := m.WorkspaceTrees[1]
+	if tree.HasWindow(m.getWindowIntID(closed.ID)) || tree.WindowCount() != 2 {
+		t.Errorf("restored tree should hold only the open windows, has %d", tree.WindowCount())
+	}
+}
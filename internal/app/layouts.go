@@ -0,0 +1,112 @@
+package app
+
+import (
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/layout"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/Gaurav-Gosain/tuios/internal/ui"
+)
+
+// CurrentLayoutName returns the tiling layout selected for the current
+// workspace ("bsp" unless another layout was chosen).
+func (m *OS) CurrentLayoutName() string {
+	return m.workspaceLayoutName(m.CurrentWorkspace)
+}
+
+func (m *OS) workspaceLayoutName(workspace int) string {
+	if name := m.WorkspaceTilingLayout[workspace]; !layout.IsBSP(name) {
+		return name
+	}
+	return layout.LayoutBSP
+}
+
+// UsingLayoutEngine reports whether the current workspace is tiled by a
+// registered layout instead of the BSP tree.
+func (m *OS) UsingLayoutEngine() bool {
+	return !layout.IsBSP(m.CurrentLayoutName())
+}
+
+// layoutParams returns the parameters passed to registered layouts.
+func (m *OS) layoutParams() layout.Params {
+	return layout.Params{
+		MasterRatio: m.MasterRatio,
+		Masters:     max(m.MasterCount, 1),
+	}
+}
+
+// SetLayout selects the tiling layout for the current workspace and retiles
+// it if tiling is enabled.
+func (m *OS) SetLayout(name string) error {
+	if err := layout.Validate(name); err != nil {
+		return err
+	}
+	if m.WorkspaceTilingLayout == nil {
+		m.WorkspaceTilingLayout = make(map[int]string)
+	}
+	if layout.IsBSP(name) {
+		delete(m.WorkspaceTilingLayout, m.CurrentWorkspace)
+	} else {
+		m.WorkspaceTilingLayout[m.CurrentWorkspace] = name
+	}
+	m.LogInfo("Layout for workspace %d set to %s", m.CurrentWorkspace, m.CurrentLayoutName())
+
+	if m.AutoTiling {
+		m.TileAllWindows()
+	}
+	m.SyncStateToDaemon()
+	return nil
+}
+
+// CycleLayout moves the current workspace delta steps through the layout
+// list and returns the name of the new layout.
+func (m *OS) CycleLayout(delta int) string {
+	name := layout.NextName(m.CurrentLayoutName(), delta)
+	_ = m.SetLayout(name)
+	return name
+}
+
+// AdjustMasterCount changes the number of windows in the master area, never
+// going below one, and retiles.
+func (m *OS) AdjustMasterCount(delta int) int {
+	m.MasterCount = max(max(m.MasterCount, 1)+delta, 1)
+	if m.AutoTiling && m.UsingLayoutEngine() {
+		m.TileAllWindows()
+	}
+	m.SyncStateToDaemon()
+	return m.MasterCount
+}
+
+// tiledWindows returns the windows of the current workspace that take part in
+// tiling, in stacking order.
+func (m *OS) tiledWindows() []*terminal.Window {
+	var windows []*terminal.Window
+	for _, w := range m.Windows {
+		if m.isTileable(w) {
+			windows = append(windows, w)
+		}
+	}
+	return windows
+}
+
+// applyEngineLayout arranges the current workspace with its registered
+// layout. Window order in m.Windows decides which window is the master.
+func (m *OS) applyEngineLayout() {
+	l, ok := layout.Get(m.CurrentLayoutName())
+	if !ok {
+		return
+	}
+
+	windows := m.tiledWindows()
+	rects := layout.Apply(l, len(windows), m.GetBSPBounds(), m.layoutParams())
+	for i, win := range windows {
+		rect := m.zoomRect(win, rects[i])
+		anim := ui.NewSnapAnimation(
+			win,
+			rect.X, rect.Y, rect.W, rect.H,
+			config.GetAnimationDuration(),
+		)
+		if anim != nil {
+			m.Animations = append(m.Animations, anim)
+		}
+	}
+}
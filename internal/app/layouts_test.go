@@ -0,0 +1,44 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// TestMasterCountPerWorkspace tests that the number of master windows is kept
+// per workspace and saved with the session
+func TestMasterCountPerWorkspace(t *testing.T) {
+	config.AnimationsEnabled = false
+	defer func() { config.AnimationsEnabled = true }()
+
+	m := newTestOS(
+		&terminal.Window{ID: "window-one", Workspace: 1, Z: 0},
+		&terminal.Window{ID: "window-two", Workspace: 2, Z: 1},
+	)
+	m.MasterCount = 1
+	m.TileAllWindows()
+
+	if n := m.AdjustMasterCount(1); n != 2 {
+		t.Fatalf("AdjustMasterCount(1) = %d, want 2", n)
+	}
+	m.SwitchToWorkspace(2)
+	if m.MasterCount != 1 {
+		t.Errorf("workspace 2 has %d masters, want the default 1", m.MasterCount)
+	}
+	m.SwitchToWorkspace(1)
+	if m.MasterCount != 2 {
+		t.Errorf("workspace 1 has %d masters after switching back, want 2", m.MasterCount)
+	}
+
+	state := m.BuildSessionState()
+	if got := state.WorkspaceMasterCounts; len(got) != 1 || got[1] != 2 {
+		t.Errorf("session state master counts = %v, want only workspace 1 with 2", got)
+	}
+	restored := newTestOS()
+	restored.restoreTilingLayouts(state)
+	if restored.MasterCount != 2 {
+		t.Errorf("restored MasterCount = %d, want 2", restored.MasterCount)
+	}
+}
@@ -0,0 +1,285 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/layout"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// namedLayoutExt is the extension of layout files in the layout directory.
+const namedLayoutExt = ".json"
+
+// namedLayout is a workspace layout saved under a name. The BSP tree refers
+// to windows by slot (1..n) instead of window ID, so it can be reapplied to
+// whatever windows are open when it is loaded.
+type namedLayout struct {
+	Name    string                    `json:"name"`
+	Layout  string                    `json:"layout"`         // Tiling layout name
+	Tree    *layout.SerializedBSPTree `json:"tree,omitempty"` // Set when the layout is bsp
+	Windows []string                  `json:"windows"`        // Window names by slot, in tiling order
+}
+
+// namedLayoutPath returns the file a named layout is stored in.
+func namedLayoutPath(dir, name string) string {
+	return filepath.Join(dir, exportFileName(name)+namedLayoutExt)
+}
+
+// ListNamedLayouts returns the names of the layouts saved in dir, sorted.
+func ListNamedLayouts(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+namedLayoutExt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list layouts: %w", err)
+	}
+	names := make([]string, 0, len(paths))
+	for _, path := range paths {
+		var saved namedLayout
+		if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, &saved) == nil && saved.Name != "" {
+			names = append(names, saved.Name)
+		} else {
+			names = append(names, strings.TrimSuffix(filepath.Base(path), namedLayoutExt))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SaveNamedLayout stores the current workspace's tiling layout under name in
+// the layout directory, replacing any layout saved under that name before.
+func (m *OS) SaveNamedLayout(name string) error {
+	return m.saveNamedLayout(config.GetLayoutDirectory(), name)
+}
+
+// LoadNamedLayout reapplies a layout saved with SaveNamedLayout to the
+// windows on the current workspace, enabling tiling if needed.
+func (m *OS) LoadNamedLayout(name string) error {
+	return m.loadNamedLayout(config.GetLayoutDirectory(), name)
+}
+
+func (m *OS) saveNamedLayout(dir, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("layout name is required")
+	}
+	if !m.AutoTiling {
+		return fmt.Errorf("tiling is not enabled")
+	}
+
+	saved := namedLayout{Name: name, Layout: m.CurrentLayoutName()}
+	if m.UsingLayoutEngine() {
+		for _, w := range m.tiledWindows() {
+			saved.Windows = append(saved.Windows, m.getWindowDisplayName(w))
+		}
+	} else {
+		tree := m.WorkspaceTrees[m.CurrentWorkspace]
+		if tree == nil {
+			return fmt.Errorf("no tiled windows on workspace %d", m.CurrentWorkspace)
+		}
+		slots := make(map[int]int)
+		for _, id := range tree.GetAllWindowIDs() {
+			name := ""
+			if w := m.getWindowByIntID(id); w != nil {
+				name = m.getWindowDisplayName(w)
+			}
+			saved.Windows = append(saved.Windows, name)
+			slots[id] = len(saved.Windows)
+		}
+		saved.Tree = tree.Serialize().MapWindowIDs(func(id int) int { return slots[id] })
+	}
+	if len(saved.Windows) == 0 {
+		return fmt.Errorf("no tiled windows on workspace %d", m.CurrentWorkspace)
+	}
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode layout: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create layout directory: %w", err)
+	}
+	if err := os.WriteFile(namedLayoutPath(dir, name), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write layout: %w", err)
+	}
+	m.LogInfo("Saved layout %q with %d windows", name, len(saved.Windows))
+	return nil
+}
+
+func (m *OS) loadNamedLayout(dir, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("layout name is required")
+	}
+	data, err := os.ReadFile(namedLayoutPath(dir, name))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("unknown layout: %s", name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read layout: %w", err)
+	}
+	var saved namedLayout
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("invalid layout file %s: %w", name, err)
+	}
+	if err := layout.Validate(saved.Layout); err != nil {
+		return err
+	}
+
+	if m.WorkspaceTilingLayout == nil {
+		m.WorkspaceTilingLayout = make(map[int]string)
+	}
+	if layout.IsBSP(saved.Layout) {
+		delete(m.WorkspaceTilingLayout, m.CurrentWorkspace)
+	} else {
+		m.WorkspaceTilingLayout[m.CurrentWorkspace] = saved.Layout
+	}
+	if !m.AutoTiling {
+		m.ToggleAutoTiling()
+	}
+
+	slots := m.assignLayoutSlots(saved.Windows)
+	if m.UsingLayoutEngine() {
+		m.orderWindowsBySlot(slots)
+		m.TileAllWindows()
+	} else {
+		m.applyNamedTree(saved.Tree, slots)
+		m.ApplyBSPLayout()
+	}
+	m.LogInfo("Loaded layout %q on workspace %d", saved.Name, m.CurrentWorkspace)
+	m.SyncStateToDaemon()
+	return nil
+}
+
+// assignLayoutSlots matches the current workspace's windows to the slots of
+// a saved layout: first by name, then the remaining windows fill the
+// remaining slots in order. Unfilled slots are nil.
+func (m *OS) assignLayoutSlots(names []string) []*terminal.Window {
+	slots := make([]*terminal.Window, len(names))
+	windows := m.tiledWindows()
+	used := make(map[*terminal.Window]bool)
+
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+		for _, w := range windows {
+			if !used[w] && m.getWindowDisplayName(w) == name {
+				slots[i] = w
+				used[w] = true
+				break
+			}
+		}
+	}
+
+	next := 0
+	for i := range slots {
+		if slots[i] != nil {
+			continue
+		}
+		for next < len(windows) && used[windows[next]] {
+			next++
+		}
+		if next == len(windows) {
+			break
+		}
+		slots[i] = windows[next]
+		used[windows[next]] = true
+	}
+	return slots
+}
+
+// orderWindowsBySlot reorders the slotted windows among the positions they
+// already hold in the window list, so layout engines, which tile in list
+// order, place them by slot. Other windows keep their positions.
+func (m *OS) orderWindowsBySlot(slots []*terminal.Window) {
+	focused := m.GetFocusedWindow()
+	inSlot := make(map[*terminal.Window]bool)
+	var ordered []*terminal.Window
+	for _, w := range slots {
+		if w != nil {
+			inSlot[w] = true
+			ordered = append(ordered, w)
+		}
+	}
+	next := 0
+	for i, w := range m.Windows {
+		if inSlot[w] {
+			m.Windows[i] = ordered[next]
+			next++
+		}
+	}
+	for i, w := range m.Windows {
+		if w == focused {
+			m.FocusedWindow = i
+		}
+	}
+}
+
+// applyNamedTree rebuilds the current workspace's BSP tree from a saved tree,
+// placing each slotted window in its slot. Empty slots are collapsed and
+// windows without a slot are inserted as new tiles.
+func (m *OS) applyNamedTree(saved *layout.SerializedBSPTree, slots []*terminal.Window) {
+	if m.WorkspaceTrees == nil {
+		m.WorkspaceTrees = make(map[int]*layout.BSPTree)
+	}
+
+	// Empty slots get IDs no window can have so they can be removed after
+	// the tree is rebuilt.
+	const emptySlot = 1 << 30
+	tree := saved.MapWindowIDs(func(slot int) int {
+		if slot >= 1 && slot <= len(slots) && slots[slot-1] != nil {
+			return m.getWindowIntID(slots[slot-1].ID)
+		}
+		return emptySlot + slot
+	}).Deserialize()
+	for _, id := range tree.GetAllWindowIDs() {
+		if id >= emptySlot {
+			tree.RemoveWindow(id)
+		}
+	}
+	m.WorkspaceTrees[m.CurrentWorkspace] = tree
+
+	for _, w := range m.tiledWindows() {
+		if !tree.HasWindow(m.getWindowIntID(w.ID)) {
+			m.AddWindowToBSPTree(w)
+		}
+	}
+}
+
+// OpenLayoutPrompt asks for a layout name to save ("save") or load ("load").
+// The load prompt lists the saved layouts.
+func (m *OS) OpenLayoutPrompt(mode string) {
+	m.LayoutPrompt = mode
+	m.LayoutPromptBuffer = ""
+	m.LayoutPromptNames = nil
+	if mode == "load" {
+		names, err := ListNamedLayouts(config.GetLayoutDirectory())
+		if err != nil {
+			m.LogError("%v", err)
+		}
+		m.LayoutPromptNames = names
+	}
+}
+
+// CloseLayoutPrompt dismisses the layout name prompt.
+func (m *OS) CloseLayoutPrompt() {
+	m.LayoutPrompt = ""
+	m.LayoutPromptBuffer = ""
+	m.LayoutPromptNames = nil
+}
+
+// CompleteLayoutPrompt completes the typed name to the first saved layout
+// starting with it.
+func (m *OS) CompleteLayoutPrompt() {
+	for _, name := range m.LayoutPromptNames {
+		if strings.HasPrefix(name, m.LayoutPromptBuffer) {
+			m.LayoutPromptBuffer = name
+			return
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/layout"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+func namedLayoutTestOS(names ...string) *OS {
+	m := newTestOS()
+	for i, name := range names {
+		m.Windows = append(m.Windows, &terminal.Window{ID: "window-" + name, CustomName: name, Workspace: 1, Z: i})
+	}
+	m.TileAllWindows()
+	return m
+}
+
+// TestNamedLayouts tests that a saved layout keeps its tree shape and ratios
+// and is reapplied by window name first, then in order
+func TestNamedLayouts(t *testing.T) {
+	config.AnimationsEnabled = false
+	defer func() { config.AnimationsEnabled = true }()
+	dir := t.TempDir()
+
+	m := namedLayoutTestOS("editor", "logs", "shell")
+	m.WorkspaceTrees[1].Root.SplitRatio = 0.7
+	m.ApplyBSPLayout()
+	want := make(map[string]layout.Rect)
+	for _, w := range m.Windows {
+		want[w.CustomName] = layout.Rect{X: w.X, Y: w.Y, W: w.Width, H: w.Height}
+	}
+	if err := m.saveNamedLayout(dir, "review"); err != nil {
+		t.Fatalf("saveNamedLayout: %v", err)
+	}
+
+	names, err := ListNamedLayouts(dir)
+	if err != nil || len(names) != 1 || names[0] != "review" {
+		t.Fatalf("ListNamedLayouts = %v, %v", names, err)
+	}
+
+	// Different windows in a different order: editor and shell match by
+	// name, build fills the remaining logs slot
+	m = namedLayoutTestOS("shell", "build", "editor")
+	if err := m.loadNamedLayout(dir, "review"); err != nil {
+		t.Fatalf("loadNamedLayout: %v", err)
+	}
+	for _, w := range m.Windows {
+		slot := w.CustomName
+		if slot == "build" {
+			slot = "logs"
+		}
+		if got := (layout.Rect{X: w.X, Y: w.Y, W: w.Width, H: w.Height}); got != want[slot] {
+			t.Errorf("%s placed at %+v, want %+v", w.CustomName, got, want[slot])
+		}
+	}
+
+	// Fewer windows collapse the empty slots, extra windows are tiled too
+	m = namedLayoutTestOS("logs")
+	if err := m.loadNamedLayout(dir, "review"); err != nil {
+		t.Fatalf("loadNamedLayout: %v", err)
+	}
+	if n := m.WorkspaceTrees[1].WindowCount(); n != 1 {
+		t.Errorf("tree should hold the one open window, has %d", n)
+	}
+	m = namedLayoutTestOS("a", "b", "c", "d")
+	if err := m.loadNamedLayout(dir, "review"); err != nil {
+		t.Fatalf("loadNamedLayout: %v", err)
+	}
+	if n := m.WorkspaceTrees[1].WindowCount(); n != 4 {
+		t.Errorf("tree should hold all four windows, has %d", n)
+	}
+
+	if err := m.loadNamedLayout(dir, "missing"); err == nil {
+		t.Error("expected an error for an unknown layout")
+	}
+}
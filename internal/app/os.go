@@ -120,6 +120,7 @@ type OS struct {
 	LastRAMUpdate      time.Time                  // Last time RAM was updated
 	AutoTiling         bool                       // Automatic tiling mode enabled
 	MasterRatio        float64                    // Master window width ratio for tiling (0.3-0.7)
+	MasterCount        int                        // Number of master windows for master layouts on the current workspace
 	// BSP tiling state
 	WorkspaceTrees        map[int]*layout.BSPTree // BSP tree per workspace
 	PreselectionDir       layout.PreselectionDir  // Pending preselection direction (0 = none)
@@ -129,28 +130,37 @@ type OS struct {
 	NextBSPWindowID       int                     // Next BSP window ID to assign (starts at 1)
 	RenamingWindow        bool                    // True when renaming a window
 	RenameBuffer          string                  // Buffer for new window name
+	LayoutPrompt          string                  // "save" or "load" while asking for a layout name, else empty
+	LayoutPromptBuffer    string                  // Layout name typed so far
+	LayoutPromptNames     []string                // Saved layout names offered by the load prompt
 	PrefixActive          bool                    // True when prefix key was pressed (tmux-style)
 	WorkspacePrefixActive bool                    // True when Ctrl+B, w was pressed (workspace sub-prefix)
 	MinimizePrefixActive  bool                    // True when Ctrl+B, m was pressed (minimize sub-prefix)
 	TilingPrefixActive    bool                    // True when Ctrl+B, t was pressed (tiling/window sub-prefix)
 	DebugPrefixActive     bool                    // True when Ctrl+B, D was pressed (debug sub-prefix)
+	ActiveKeyTable        string                  // User-defined key table waiting for a key ("" if none)
 	LastPrefixTime        time.Time               // Time when prefix was activated
 	HelpScrollOffset      int                     // Scroll offset for help menu
 	HelpCategory          int                     // Current help category index (for left/right navigation)
 	HelpSearchMode        bool                    // True when help search is active
 	HelpSearchQuery       string                  // Current search query in help menu
-	CurrentWorkspace      int                     // Current active workspace (1-9)
+	CurrentWorkspace      int                     // Current active workspace (1-NumWorkspaces)
 	NumWorkspaces         int                     // Total number of workspaces
+	WorkspaceNames        map[int]string          // Workspace names set at runtime, overriding the config ("" = unnamed)
 	WorkspaceFocus        map[int]int             // Remembers focused window per workspace
 	WorkspaceLayouts      map[int][]WindowLayout  // Stores custom layouts per workspace
 	WorkspaceHasCustom    map[int]bool            // Tracks if workspace has custom layout
 	WorkspaceMasterRatio  map[int]float64         // Stores master ratio per workspace
+	WorkspaceMasterCount  map[int]int             // Stores master window count per workspace
+	WorkspaceTilingLayout map[int]string          // Registered tiling layout per workspace (absent = bsp)
 	ShowLogs              bool                    // True when showing log overlay
 	LogMessages           []LogMessage            // Store log messages
 	LogScrollOffset       int                     // Scroll offset for log viewer
 	Notifications         []Notification          // Active notifications
 	SelectionMode         bool                    // True when in text selection mode
 	ClipboardContent      string                  // Store clipboard content from tea.ClipboardMsg
+	Registers             map[rune]string         // Vim-style yank registers shared across windows
+	PendingRegisterPaste  bool                    // True when waiting for a register name to paste from
 	ShowCacheStats        bool                    // True when showing style cache statistics overlay
 	ShowQuitConfirm       bool                    // True when showing quit confirmation dialog
 	QuitConfirmSelection  int                     // 0 = Yes (left), 1 = No (right)
@@ -178,6 +188,21 @@ type OS struct {
 	KeyboardEnhancementsEnabled bool // True when terminal supports keyboard enhancements
 	// Keybind registry for user-configurable keybindings
 	KeybindRegistry *config.KeybindRegistry
+	// Config hot-reload: watches config.toml and the custom themes directory
+	configWatch *configWatcher
+	// Window rules: which rules each window matched when last evaluated
+	windowRuleMatches map[string][]bool
+	// Key passthrough: the focused window's last looked up foreground command
+	foregroundCache foregroundCommandCache
+	// Light/dark themes: whether the host terminal's background is dark, once known
+	hostDark *bool
+	// Status bar: cached segment values and custom segment runs
+	status statusCache
+	// Layout undo/redo stacks per workspace
+	layoutHistories map[int]*layoutHistory
+	// Zoom: the window shown over its whole workspace, and where it goes back to
+	ZoomedWindowID string
+	zoomRestore    layout.Rect
 	// Showkeys feature
 	ShowKeys          bool       // True when showkeys overlay is enabled
 	RecentKeys        []KeyEvent // Ring buffer of recently pressed keys
@@ -214,6 +239,24 @@ type OS struct {
 	// Scrollback browser overlay
 	ShowScrollbackBrowser bool
 	ScrollbackBrowser     any // *scrollback.Browser — typed as any to avoid import cycle
+	// Search across all windows overlay
+	ShowGlobalSearch bool
+	GlobalSearch     *GlobalSearchState
+	// Saved scrollback history picker overlay
+	ShowHistoryBrowser bool
+	HistoryBrowser     *HistoryBrowserState
+	// Workspace picker and rename prompt overlay
+	ShowWorkspacePicker bool
+	WorkspacePicker     *WorkspacePickerState
+	// Command palette overlay
+	ShowCommandPalette bool
+	CommandPalette     *CommandPaletteState
+	// Window switcher overlay with thumbnails of every window
+	ShowWindowSwitcher bool
+	WindowSwitcher     *WindowSwitcherState
+	// Theme editor panel
+	ShowThemeEditor bool
+	ThemeEditor     *ThemeEditorState
 }
 
 // Notification represents a temporary notification message.
@@ -363,7 +406,7 @@ func (m *OS) CycleToNextVisibleWindow() {
 	// Find next visible (non-minimized and non-minimizing) window in current workspace
 	visibleWindows := []int{}
 	for i, w := range m.Windows {
-		if w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing {
+		if w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing && !m.IsHiddenInGroup(w) {
 			visibleWindows = append(visibleWindows, i)
 		}
 	}
@@ -396,7 +439,7 @@ func (m *OS) CycleToPreviousVisibleWindow() {
 	// Find previous visible (non-minimized and non-minimizing) window in current workspace
 	visibleWindows := []int{}
 	for i, w := range m.Windows {
-		if w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing {
+		if w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing && !m.IsHiddenInGroup(w) {
 			visibleWindows = append(visibleWindows, i)
 		}
 	}
@@ -435,6 +478,11 @@ func (m *OS) FocusWindow(i int) *OS {
 
 	oldFocused := m.FocusedWindow
 
+	// Focusing a window behind a container's active tab brings it to the front
+	if m.IsHiddenInGroup(m.Windows[i]) {
+		m.activateGroupWindow(m.Windows[i])
+	}
+
 	// ATOMIC: Set focus and Z-index in one operation
 	m.FocusedWindow = i
 
@@ -465,15 +513,25 @@ func (m *OS) FocusWindow(i int) *OS {
 	// Invalidate cache for new focused window (border color change)
 	m.Windows[i].MarkPositionDirty() // Use lighter invalidation
 
+	// Focusing another window ends a zoom
+	m.checkZoom()
+
 	return m
 }
 
 // AddWindow adds a new window to the current workspace.
 // In daemon mode, this creates a daemon-managed PTY and window.
 func (m *OS) AddWindow(title string) *OS {
+	return m.AddCommandWindow(title, "")
+}
+
+// AddCommandWindow adds a new window to the current workspace whose process
+// is command instead of the user's shell. The window closes when the command
+// exits. An empty command starts the shell, like AddWindow.
+func (m *OS) AddCommandWindow(title, command string) *OS {
 	// In daemon mode, use daemon PTY management
 	if m.IsDaemonSession && m.DaemonClient != nil {
-		return m.AddDaemonWindow(title)
+		return m.addDaemonWindow(title, command)
 	}
 
 	newID := createID()
@@ -524,11 +582,12 @@ func (m *OS) AddWindow(title string) *OS {
 		y = screenHeight / 4
 	}
 
-	window := terminal.NewWindow(newID, title, x, y, width, height, len(m.Windows), m.WindowExitChan)
+	window := terminal.NewCommandWindow(newID, title, x, y, width, height, len(m.Windows), m.WindowExitChan, command)
 	if window == nil {
 		m.LogError("Failed to create window %s (PTY creation failed)", title)
 		return m // Failed to create window
 	}
+	m.reportHistoryError(window)
 
 	caps := GetHostCapabilities()
 	if caps.CellWidth > 0 && caps.CellHeight > 0 {
@@ -558,6 +617,8 @@ func (m *OS) AddWindow(title string) *OS {
 		}
 	}
 
+	m.ApplyWindowRules(window)
+
 	return m
 }
 
@@ -594,6 +655,8 @@ func (m *OS) DeleteWindow(i int) *OS {
 	// Get the window int ID BEFORE deleting (for BSP tree removal)
 	windowIntID := m.getWindowIntID(deletedWindow.ID)
 
+	delete(m.windowRuleMatches, deletedWindow.ID)
+
 	// Clean up the BSP ID mapping
 	if m.WindowToBSPID != nil {
 		delete(m.WindowToBSPID, deletedWindow.ID)
@@ -1001,7 +1064,7 @@ func (m *OS) RestoreMinimizedByIndex(index int) {
 	// Find the nth minimized window in current workspace
 	minimizedCount := 0
 	for i, window := range m.Windows {
-		if window.Workspace == m.CurrentWorkspace && window.Minimized {
+		if window.Workspace == m.CurrentWorkspace && window.Minimized && window.Scratchpad == "" {
 			if minimizedCount == index {
 				m.RestoreWindow(i)
 				return
@@ -1031,7 +1094,7 @@ func (m *OS) FocusNextVisibleWindow() {
 // HasMinimizedWindows returns true if there are any minimized windows.
 func (m *OS) HasMinimizedWindows() bool {
 	for _, w := range m.Windows {
-		if w.Workspace == m.CurrentWorkspace && w.Minimized {
+		if w.Workspace == m.CurrentWorkspace && w.Minimized && w.Scratchpad == "" {
 			return true
 		}
 	}
@@ -1369,5 +1432,10 @@ func (m *OS) extractSelectedText(window *terminal.Window) string {
 
 // Cleanup performs cleanup operations when the application exits.
 func (m *OS) Cleanup() {
-	// Reserved for future cleanup operations
+	// Flush on-disk scrollback history so the transcript is complete
+	for _, w := range m.Windows {
+		if err := w.SaveHistory(); err != nil {
+			m.LogError("Failed to save history for window %s: %v", w.ID, err)
+		}
+	}
 }
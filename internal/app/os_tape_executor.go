@@ -117,8 +117,13 @@ func (m *OS) getWindowInfo(w *terminal.Window, isFocused bool) map[string]any {
 		"title":          w.Title,
 		"display_name":   m.getWindowDisplayName(w),
 		"workspace":      w.Workspace,
+		"workspace_name": m.WorkspaceName(w.Workspace),
 		"focused":        isFocused,
 		"minimized":      w.Minimized,
+		"floating":       w.Floating,
+		"sticky":         w.Sticky,
+		"scratchpad":     w.Scratchpad,
+		"zoomed":         m.IsZoomed(w),
 		"fullscreen":     w.Width == m.Width && w.Height == m.GetUsableHeight(),
 		"x":              w.X,
 		"y":              w.Y,
@@ -190,9 +195,20 @@ func (m *OS) GetWindowListData() map[string]any {
 		"focused_window_id": focusedWindowID,
 		"current_workspace": m.CurrentWorkspace,
 		"workspace_windows": workspaceWindows,
+		"workspace_names":   m.workspaceNameList(),
 	}
 }
 
+// workspaceNameList returns the workspace names by number, "" for unnamed
+// workspaces.
+func (m *OS) workspaceNameList() []string {
+	names := make([]string, m.NumWorkspaces)
+	for i := range names {
+		names[i] = m.WorkspaceName(i + 1)
+	}
+	return names
+}
+
 // GetSessionInfoData returns data about the current session.
 func (m *OS) GetSessionInfoData() map[string]any {
 	// Determine mode
@@ -204,7 +220,7 @@ func (m *OS) GetSessionInfoData() map[string]any {
 	// Get tiling mode string
 	tilingMode := "floating"
 	if m.AutoTiling {
-		tilingMode = "bsp"
+		tilingMode = m.CurrentLayoutName()
 	}
 
 	// Get dockbar position - it's stored as a string in config
@@ -248,6 +264,7 @@ func (m *OS) GetSessionInfoData() map[string]any {
 		"height":             m.Height,
 		"workspace_windows":  workspaceWindows,
 		"num_workspaces":     m.NumWorkspaces,
+		"workspace_names":    m.workspaceNameList(),
 	}
 
 	// Script playback info
@@ -339,7 +356,7 @@ func (m *OS) CloseWindowByName(name string) error {
 
 // SwitchWorkspace switches to a workspace.
 func (m *OS) SwitchWorkspace(workspace int) error {
-	if workspace >= 1 && workspace <= m.NumWorkspaces {
+	if m.ensureWorkspace(workspace) {
 		recorder := m.TapeRecorder
 		m.TapeRecorder = nil
 		m.SwitchToWorkspace(workspace)
@@ -529,7 +546,7 @@ func (m *OS) SnapByDirection(direction string) error {
 
 // MoveWindowToWorkspaceByID moves a window to a workspace.
 func (m *OS) MoveWindowToWorkspaceByID(windowID string, workspace int) error {
-	if workspace < 1 || workspace > m.NumWorkspaces {
+	if !m.ensureWorkspace(workspace) {
 		return fmt.Errorf("workspace %d out of range (1-%d)", workspace, m.NumWorkspaces)
 	}
 
@@ -545,7 +562,7 @@ func (m *OS) MoveWindowToWorkspaceByID(windowID string, workspace int) error {
 
 // MoveAndFollowWorkspaceByID moves a window to a workspace and switches to it.
 func (m *OS) MoveAndFollowWorkspaceByID(windowID string, workspace int) error {
-	if workspace < 1 || workspace > m.NumWorkspaces {
+	if !m.ensureWorkspace(workspace) {
 		return fmt.Errorf("workspace %d out of range (1-%d)", workspace, m.NumWorkspaces)
 	}
 
@@ -559,6 +576,11 @@ func (m *OS) MoveAndFollowWorkspaceByID(windowID string, workspace int) error {
 	return fmt.Errorf("window not found: %s", windowID)
 }
 
+// RenameCurrentWorkspace names the current workspace, or clears its name.
+func (m *OS) RenameCurrentWorkspace(name string) error {
+	return m.RenameWorkspace(m.CurrentWorkspace, name)
+}
+
 // SplitHorizontal splits the focused window horizontally.
 func (m *OS) SplitHorizontal() error {
 	if !m.AutoTiling {
@@ -683,7 +705,15 @@ func (m *OS) SetTheme(themeName string) error {
 		return fmt.Errorf("failed to set theme: %w", err)
 	}
 
-	// Update terminal colors for all windows
+	m.applyThemeColors()
+	m.ShowNotification(fmt.Sprintf("Theme: %s", themeName), "info", config.NotificationDuration)
+	m.MarkAllDirty()
+	return nil
+}
+
+// applyThemeColors updates the terminal colors of all windows to the
+// current theme.
+func (m *OS) applyThemeColors() {
 	for _, w := range m.Windows {
 		if w != nil && w.Terminal != nil {
 			if theme.IsEnabled() {
@@ -700,10 +730,6 @@ func (m *OS) SetTheme(themeName string) error {
 			w.InvalidateCache()
 		}
 	}
-
-	m.ShowNotification(fmt.Sprintf("Theme: %s", themeName), "info", config.NotificationDuration)
-	m.MarkAllDirty()
-	return nil
 }
 
 // SetDockbarPosition changes the dockbar position.
@@ -744,20 +770,9 @@ func (m *OS) FocusDirection(direction string) error {
 		return nil
 	}
 
-	focusedWindow := m.Windows[m.FocusedWindow]
-
-	var targetIndex int
-	switch direction {
-	case "left":
-		targetIndex = m.findWindowInDirection(focusedWindow, -1, 0)
-	case "right":
-		targetIndex = m.findWindowInDirection(focusedWindow, 1, 0)
-	case "up":
-		targetIndex = m.findWindowInDirection(focusedWindow, 0, -1)
-	case "down":
-		targetIndex = m.findWindowInDirection(focusedWindow, 0, 1)
-	default:
-		return fmt.Errorf("invalid direction: %s (use: left, right, up, down)", direction)
+	targetIndex, err := m.windowInDirection(m.Windows[m.FocusedWindow], direction)
+	if err != nil {
+		return err
 	}
 
 	if targetIndex >= 0 {
@@ -768,6 +783,23 @@ func (m *OS) FocusDirection(direction string) error {
 	return nil
 }
 
+// windowInDirection returns the index of the nearest window in a named
+// direction, or -1 if there is none.
+func (m *OS) windowInDirection(from *terminal.Window, direction string) (int, error) {
+	switch direction {
+	case "left":
+		return m.findWindowInDirection(from, -1, 0), nil
+	case "right":
+		return m.findWindowInDirection(from, 1, 0), nil
+	case "up":
+		return m.findWindowInDirection(from, 0, -1), nil
+	case "down":
+		return m.findWindowInDirection(from, 0, 1), nil
+	default:
+		return -1, fmt.Errorf("invalid direction: %s (use: left, right, up, down)", direction)
+	}
+}
+
 // handleRemoteSendKeys processes key sequences for TUIOS.
 // When literal=true, keys are sent directly to the focused terminal PTY.
 // When raw=true, each character is treated as a separate key (no splitting on space/comma).
@@ -1040,7 +1072,7 @@ func (m *OS) findWindowInDirection(from *terminal.Window, dx, dy int) int {
 	minDistance := m.Width + m.Height // Start with max possible distance
 
 	for i, win := range m.Windows {
-		if win == from || win.Workspace != m.CurrentWorkspace || win.Minimized || win.Minimizing {
+		if win == from || win.Workspace != m.CurrentWorkspace || win.Minimized || win.Minimizing || m.IsHiddenInGroup(win) || !m.sameLayer(win, from) {
 			continue
 		}
 
@@ -272,6 +272,70 @@ func TestMoveWindowToWorkspaceByID(t *testing.T) {
 	}
 }
 
+// TestSetLayout tests per-workspace layout selection
+func TestSetLayout(t *testing.T) {
+	m := &OS{
+		NumWorkspaces:    9,
+		CurrentWorkspace: 1,
+	}
+
+	if err := m.SetLayout("centered-master"); err != nil {
+		t.Fatalf("SetLayout failed: %v", err)
+	}
+	if got := m.CurrentLayoutName(); got != "centered-master" {
+		t.Errorf("CurrentLayoutName() = %q, want centered-master", got)
+	}
+	if err := m.SetLayout("spiral"); err == nil {
+		t.Error("SetLayout should reject unknown layouts")
+	}
+
+	m.CurrentWorkspace = 2
+	if got := m.CurrentLayoutName(); got != "bsp" {
+		t.Errorf("workspace 2 layout = %q, want bsp", got)
+	}
+
+	m.CurrentWorkspace = 1
+	if err := m.SetLayout("bsp"); err != nil {
+		t.Fatalf("SetLayout(bsp) failed: %v", err)
+	}
+	if _, ok := m.WorkspaceTilingLayout[1]; ok {
+		t.Error("selecting bsp should clear the workspace entry")
+	}
+
+	state := m.BuildSessionState()
+	if got := state.TilingLayout(1); got != "bsp" {
+		t.Errorf("state layout = %q, want bsp", got)
+	}
+}
+
+// TestApplyStateSyncTilingLayouts tests that layout selection is synced
+func TestApplyStateSyncTilingLayouts(t *testing.T) {
+	m := &OS{
+		NumWorkspaces:    9,
+		CurrentWorkspace: 1,
+		WorkspaceFocus:   make(map[int]int),
+	}
+
+	state := &session.SessionState{
+		CurrentWorkspace:       2,
+		WorkspaceTilingLayouts: map[int]string{2: "monocle", 3: "no-such-layout"},
+		WorkspaceMasterCounts:  map[int]int{2: 3, 3: 2},
+	}
+	if err := m.ApplyStateSync(state); err != nil {
+		t.Fatalf("ApplyStateSync failed: %v", err)
+	}
+
+	if got := m.CurrentLayoutName(); got != "monocle" {
+		t.Errorf("CurrentLayoutName() = %q, want monocle", got)
+	}
+	if _, ok := m.WorkspaceTilingLayout[3]; ok {
+		t.Error("unknown layouts should be dropped")
+	}
+	if m.MasterCount != 3 || m.WorkspaceMasterCount[3] != 2 {
+		t.Errorf("MasterCount = %d on workspace 2 and %d on 3, want 3 and 2", m.MasterCount, m.WorkspaceMasterCount[3])
+	}
+}
+
 // TestApplyStateSyncGlobalState tests that global state (workspace, tiling) is synced
 func TestApplyStateSyncGlobalState(t *testing.T) {
 	m := &OS{
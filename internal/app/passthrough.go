@@ -0,0 +1,106 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// Passthrough rules let programs such as nvim keep keys TUIOS would otherwise
+// take in terminal mode. Navigate keys move focus between windows like
+// vim-tmux-navigator: a program matched by a rule that passes the key gets
+// it and moves between its own splits, and calls "tuios run-command
+// FocusDirection <dir>" when it is at its edge. Without a window in that
+// direction the key always goes to the program.
+
+// foregroundCommandCache remembers the focused window's foreground command,
+// which is looked up on every key press in terminal mode.
+type foregroundCommandCache struct {
+	windowID string
+	command  string
+	at       time.Time
+}
+
+// foregroundCommand returns the foreground command of a window, looking it up
+// at most once per config.ForegroundCommandCacheTTL.
+func (m *OS) foregroundCommand(w *terminal.Window) string {
+	cache := &m.foregroundCache
+	if cache.windowID == w.ID && time.Since(cache.at) < config.ForegroundCommandCacheTTL {
+		return cache.command
+	}
+	*cache = foregroundCommandCache{windowID: w.ID, command: w.ForegroundCommand(), at: time.Now()}
+	return cache.command
+}
+
+// FocusedPassthroughRule returns the first passthrough rule matching the
+// focused window, or nil.
+func (m *OS) FocusedPassthroughRule() *config.PassthroughRule {
+	if m.KeybindRegistry == nil {
+		return nil
+	}
+	rules := m.KeybindRegistry.PassthroughRules()
+	w := m.GetFocusedWindow()
+	if len(rules) == 0 || w == nil {
+		return nil
+	}
+
+	command := ""
+	for i := range rules {
+		if rules[i].Command != "" {
+			command = m.foregroundCommand(w)
+			break
+		}
+	}
+	for i := range rules {
+		if rules[i].Matches(command, w.IsAltScreen) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// IsLeaderKey reports whether a key starts a prefix in terminal mode. A
+// passthrough rule for the focused window can disable the leader or swap
+// it for another key.
+func (m *OS) IsLeaderKey(key string) bool {
+	if rule := m.FocusedPassthroughRule(); rule != nil {
+		if rule.DisableLeader {
+			return false
+		}
+		if rule.Leader != "" {
+			return m.KeybindRegistry.IsKey(key, rule.Leader)
+		}
+	}
+	return strings.ToLower(key) == strings.ToLower(config.LeaderKey)
+}
+
+// PassesKeyThrough reports whether a passthrough rule for the focused window
+// sends the key to the program instead of TUIOS.
+func (m *OS) PassesKeyThrough(key string) bool {
+	rule := m.FocusedPassthroughRule()
+	return rule != nil && m.KeybindRegistry.PassesKey(rule, key)
+}
+
+// NavigateKey moves focus to the neighbouring window if the key is a
+// navigate key and neither a passthrough rule nor the screen edge hands it
+// to the program. It reports whether the key was used.
+func (m *OS) NavigateKey(key string) bool {
+	if m.KeybindRegistry == nil {
+		return false
+	}
+	direction := m.KeybindRegistry.GetNavigateDirection(key)
+	w := m.GetFocusedWindow()
+	if direction == "" || w == nil || m.PassesKeyThrough(key) {
+		return false
+	}
+	if target, err := m.windowInDirection(w, direction); err != nil || target < 0 {
+		return false
+	}
+	_ = m.FocusDirection(direction)
+	if focused := m.GetFocusedWindow(); focused != nil {
+		focused.InvalidateCache()
+	}
+	return true
+}
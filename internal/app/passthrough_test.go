@@ -0,0 +1,96 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// TestKeyPassthrough tests navigate keys and the leader and key overrides of
+// passthrough rules
+func TestKeyPassthrough(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Passthrough = config.PassthroughConfig{
+		Navigate: map[string]string{"ctrl+h": "left", "ctrl+l": "right"},
+		Rules: []config.PassthroughRule{
+			{AltScreen: true, DisableLeader: true, Keys: []string{"ctrl+h", "ctrl+l"}},
+		},
+	}
+	m := newTestOS(
+		&terminal.Window{ID: "left", Workspace: 1, X: 0, Y: 0, Width: 60, Height: 40},
+		&terminal.Window{ID: "right", Workspace: 1, X: 60, Y: 0, Width: 60, Height: 40, Z: 1},
+	)
+	m.AutoTiling = false
+	m.KeybindRegistry = config.NewKeybindRegistry(cfg)
+
+	if !m.IsLeaderKey(config.LeaderKey) {
+		t.Error("the leader key should start a prefix without a matching rule")
+	}
+	if !m.NavigateKey("ctrl+l") || m.FocusedWindow != 1 {
+		t.Fatalf("ctrl+l should focus the window on the right, focused %d", m.FocusedWindow)
+	}
+	if m.NavigateKey("ctrl+l") {
+		t.Error("at the screen edge the key should go to the program")
+	}
+	if m.NavigateKey("ctrl+x") {
+		t.Error("keys that are not navigate keys should not move focus")
+	}
+	if !m.NavigateKey("ctrl+h") || m.FocusedWindow != 0 {
+		t.Fatalf("ctrl+h should focus the window on the left, focused %d", m.FocusedWindow)
+	}
+
+	m.Windows[0].IsAltScreen = true
+	if m.IsLeaderKey(config.LeaderKey) {
+		t.Error("the rule should disable the leader for full-screen programs")
+	}
+	if !m.PassesKeyThrough("ctrl+l") || m.NavigateKey("ctrl+l") {
+		t.Error("the rule should pass ctrl+l to the program instead of moving focus")
+	}
+
+	cfg.Passthrough.Rules[0] = config.PassthroughRule{AltScreen: true, Leader: "ctrl+a"}
+	m.KeybindRegistry = config.NewKeybindRegistry(cfg)
+	if !m.IsLeaderKey("ctrl+a") || m.IsLeaderKey(config.LeaderKey) {
+		t.Error("the rule should replace the leader with ctrl+a")
+	}
+	if !m.NavigateKey("ctrl+l") {
+		t.Error("keys the rule does not pass should still navigate")
+	}
+}
+
+// TestForegroundSync tests that rules match daemon windows on the foreground
+// command the daemon reports
+func TestForegroundSync(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Passthrough = config.PassthroughConfig{
+		Rules: []config.PassthroughRule{{Command: "nvim", DisableLeader: true}},
+	}
+	cfg.WindowRules = []config.WindowRule{{Command: "nvim", Name: "editor"}}
+	m := newTestOS(&terminal.Window{ID: "daemon-window", PTYID: "pty-1", DaemonMode: true, Workspace: 1, Width: 120, Height: 40})
+	m.AutoTiling = false
+	m.KeybindRegistry = config.NewKeybindRegistry(cfg)
+	if !m.rulesMatchCommands() {
+		t.Fatal("command rules should make the client poll the daemon")
+	}
+	if m.FocusedPassthroughRule() != nil {
+		t.Fatal("no rule should match before the daemon reports a command")
+	}
+
+	changed := m.SetForegroundProcesses(ForegroundSyncMsg{
+		Polled: true,
+		PTYs:   map[string]ForegroundProcess{"pty-1": {PID: 42, Command: "nvim"}},
+	})
+	w := m.Windows[0]
+	if !changed || w.ForegroundPID != 42 || w.ForegroundCommand() != "nvim" {
+		t.Fatalf("the reported foreground process should be kept, got %d %q", w.ForegroundPID, w.ForegroundCommand())
+	}
+	if m.FocusedPassthroughRule() == nil {
+		t.Error("the passthrough rule should match the reported command")
+	}
+	if w.CustomName != "editor" {
+		t.Errorf("the window rule should apply when the command changes, name %q", w.CustomName)
+	}
+	if m.SetForegroundProcesses(ForegroundSyncMsg{Polled: true, PTYs: map[string]ForegroundProcess{"pty-1": {PID: 42, Command: "nvim"}}}) {
+		t.Error("an unchanged foreground process should not change the window")
+	}
+}
@@ -0,0 +1,51 @@
+package app
+
+import "unicode"
+
+// UnnamedRegister is the register used when no register is specified.
+const UnnamedRegister = '"'
+
+// IsValidRegister reports whether r names a yank register.
+// Valid registers are the unnamed register ("), a-z, A-Z and 0-9.
+func IsValidRegister(r rune) bool {
+	return r == UnnamedRegister ||
+		(r >= 'a' && r <= 'z') ||
+		(r >= 'A' && r <= 'Z') ||
+		(r >= '0' && r <= '9')
+}
+
+// SetRegister stores text in a yank register.
+// Uppercase register names append to the lowercase register (vim-style "A),
+// and every yank also updates the unnamed register.
+// Registers are shared by all windows so text can be pasted anywhere.
+func (m *OS) SetRegister(name rune, text string) {
+	if m.Registers == nil {
+		m.Registers = make(map[rune]string)
+	}
+	if name == 0 {
+		name = UnnamedRegister
+	}
+
+	if unicode.IsUpper(name) {
+		lower := unicode.ToLower(name)
+		if existing := m.Registers[lower]; existing != "" {
+			text = existing + "\n" + text
+		}
+		name = lower
+	}
+
+	m.Registers[name] = text
+	if name != UnnamedRegister {
+		m.Registers[UnnamedRegister] = text
+	}
+}
+
+// GetRegister returns the contents of a yank register.
+// Uppercase names read the corresponding lowercase register.
+func (m *OS) GetRegister(name rune) (string, bool) {
+	if name == 0 {
+		name = UnnamedRegister
+	}
+	text, ok := m.Registers[unicode.ToLower(name)]
+	return text, ok
+}
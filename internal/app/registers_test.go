@@ -0,0 +1,59 @@
+package app
+
+import "testing"
+
+func TestSetRegisterUnnamed(t *testing.T) {
+	m := &OS{}
+	m.SetRegister(0, "hello")
+
+	got, ok := m.GetRegister(UnnamedRegister)
+	if !ok || got != "hello" {
+		t.Errorf("GetRegister(unnamed) = %q, %v; want %q, true", got, ok, "hello")
+	}
+}
+
+func TestSetRegisterNamedUpdatesUnnamed(t *testing.T) {
+	m := &OS{}
+	m.SetRegister('a', "pod-a")
+
+	if got, _ := m.GetRegister('a'); got != "pod-a" {
+		t.Errorf("GetRegister('a') = %q, want %q", got, "pod-a")
+	}
+	if got, _ := m.GetRegister(UnnamedRegister); got != "pod-a" {
+		t.Errorf("GetRegister(unnamed) = %q, want %q", got, "pod-a")
+	}
+}
+
+func TestSetRegisterUppercaseAppends(t *testing.T) {
+	m := &OS{}
+	m.SetRegister('A', "first")
+	m.SetRegister('A', "second")
+
+	want := "first\nsecond"
+	if got, _ := m.GetRegister('a'); got != want {
+		t.Errorf("GetRegister('a') = %q, want %q", got, want)
+	}
+	if got, _ := m.GetRegister('A'); got != want {
+		t.Errorf("GetRegister('A') = %q, want %q", got, want)
+	}
+}
+
+func TestGetRegisterMissing(t *testing.T) {
+	m := &OS{}
+	if _, ok := m.GetRegister('z'); ok {
+		t.Error("GetRegister('z') on empty registers should report not found")
+	}
+}
+
+func TestIsValidRegister(t *testing.T) {
+	for _, r := range []rune{'"', 'a', 'z', 'A', 'Z', '0', '9'} {
+		if !IsValidRegister(r) {
+			t.Errorf("IsValidRegister(%q) = false, want true", r)
+		}
+	}
+	for _, r := range []rune{' ', '-', '/', 'é'} {
+		if IsValidRegister(r) {
+			t.Errorf("IsValidRegister(%q) = true, want false", r)
+		}
+	}
+}
@@ -50,6 +50,11 @@ func (m *OS) GetCanvas(render bool) *lipgloss.Canvas {
 			continue
 		}
 
+		// Windows behind the active tab of a container are not drawn
+		if m.IsHiddenInGroup(window) {
+			continue
+		}
+
 		margin := 5
 		if isAnimating {
 			margin = 20
@@ -79,6 +84,9 @@ func (m *OS) GetCanvas(render bool) *lipgloss.Canvas {
 		} else {
 			borderColorObj = theme.BorderUnfocused()
 		}
+		if window.BorderColor != "" {
+			borderColorObj = lipgloss.Color(window.BorderColor)
+		}
 
 		if window.CachedLayer != nil && !window.Dirty && !window.ContentDirty && !window.PositionDirty {
 			layers = append(layers, window.CachedLayer)
@@ -89,7 +97,7 @@ func (m *OS) GetCanvas(render bool) *lipgloss.Canvas {
 			window.Dirty || window.ContentDirty || window.PositionDirty ||
 			window.CachedLayer.GetX() != window.X ||
 			window.CachedLayer.GetY() != window.Y ||
-			window.CachedLayer.GetZ() != window.Z
+			window.CachedLayer.GetZ() != m.StackZ(window)
 
 		if !needsRedraw || (!isFocused && !isFullyVisible && !window.ContentDirty && !window.IsBeingManipulated && window.CachedLayer != nil) {
 			layers = append(layers, window.CachedLayer)
@@ -109,10 +117,10 @@ func (m *OS) GetCanvas(render bool) *lipgloss.Canvas {
 			window,
 			isRenaming,
 			m.RenameBuffer,
-			m.AutoTiling,
+			m.IsTiledWindow(window),
 		)
 
-		zIndex := window.Z
+		zIndex := m.StackZ(window)
 		if isAnimating {
 			zIndex = config.ZIndexAnimating
 		}
@@ -130,6 +138,8 @@ func (m *OS) GetCanvas(render bool) *lipgloss.Canvas {
 	}
 
 	if render {
+		layers = append(layers, m.renderGroupStrips()...)
+
 		overlays := m.renderOverlays()
 		layers = append(layers, overlays...)
 
@@ -195,7 +205,7 @@ func (m *OS) GetKittyGraphicsCmd() tea.Cmd {
 		m.KittyPassthrough.RefreshAllPlacements(func() map[string]*WindowPositionInfo {
 			result := make(map[string]*WindowPositionInfo)
 			for _, w := range m.Windows {
-				if w.Workspace == m.CurrentWorkspace && !w.Minimized {
+				if w.Workspace == m.CurrentWorkspace && !w.Minimized && !m.IsHiddenInGroup(w) {
 					scrollbackLen := 0
 					if w.Terminal != nil {
 						scrollbackLen = w.Terminal.ScrollbackLen()
@@ -244,7 +254,7 @@ func (m *OS) GetSixelGraphicsCmd() tea.Cmd {
 	if m.SixelPassthrough.PlacementCount() > 0 {
 		m.SixelPassthrough.RefreshAllPlacements(func(windowID string) *WindowPositionInfo {
 			for _, w := range m.Windows {
-				if w.ID == windowID && w.Workspace == m.CurrentWorkspace && !w.Minimized {
+				if w.ID == windowID && w.Workspace == m.CurrentWorkspace && !w.Minimized && !m.IsHiddenInGroup(w) {
 					scrollbackLen := 0
 					if w.Terminal != nil {
 						scrollbackLen = w.Terminal.ScrollbackLen()
@@ -12,94 +12,6 @@ import (
 func (m *OS) renderDock() *lipgloss.Layer {
 	layout := m.CalculateDockLayout()
 
-	sysInfoStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#808090")).
-		MarginRight(2)
-
-	modeStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#a0a0b0")).
-		Bold(true).
-		MarginRight(2)
-
-	if m.workspaceActiveStyle == nil {
-		activeStyle := lipgloss.NewStyle().
-			Background(lipgloss.Color("#4865f2")).
-			Foreground(lipgloss.Color("#ffffff")).
-			Bold(true)
-		m.workspaceActiveStyle = &activeStyle
-	}
-
-	leftText := layout.LeftText
-
-	leftCircle := config.GetDockPillLeftChar()
-	rightCircle := config.GetDockPillRightChar()
-
-	var styledModeText, styledWorkspaceText string
-
-	if leftCircle != "" && rightCircle != "" {
-		startIdx := strings.Index(leftText, leftCircle)
-		endIdx := strings.Index(leftText, rightCircle)
-
-		if startIdx != -1 && endIdx > startIdx {
-			workspacePart := leftText[endIdx+len(rightCircle):]
-
-			modeColor := layout.ModeInfo.Color
-			modeLabel := leftText[startIdx+len(leftCircle) : endIdx]
-
-			styledLeftCircle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color(modeColor)).
-				Render(leftCircle)
-
-			styledLabel := lipgloss.NewStyle().
-				Background(lipgloss.Color(modeColor)).
-				Foreground(lipgloss.Color("#ffffff")).
-				Bold(true).
-				Render(modeLabel)
-
-			styledRightCircle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color(modeColor)).
-				Render(rightCircle)
-
-			styledModeText = styledLeftCircle + styledLabel + styledRightCircle
-
-			styledWorkspaceText = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#b0b0c0")).
-				Bold(true).
-				Render(workspacePart)
-		} else {
-			styledModeText = modeStyle.Render(leftText)
-			styledWorkspaceText = ""
-		}
-	} else {
-		modeColor := layout.ModeInfo.Color
-
-		var modeLabel, workspacePart string
-		if strings.Contains(leftText, " ") {
-			for i := 1; i < len(leftText); i++ {
-				if leftText[i] >= '0' && leftText[i] <= '9' {
-					modeLabel = leftText[:i]
-					workspacePart = leftText[i:]
-					break
-				}
-			}
-		}
-
-		if modeLabel == "" {
-			modeLabel = leftText
-		}
-
-		styledModeText = lipgloss.NewStyle().
-			Background(lipgloss.Color(modeColor)).
-			Foreground(lipgloss.Color("#ffffff")).
-			Bold(true).
-			Render(modeLabel)
-
-		styledWorkspaceText = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#b0b0c0")).
-			Bold(true).
-			Render(workspacePart)
-	}
-
 	var dockItemsStr string
 	itemNumber := 1
 
@@ -150,12 +62,18 @@ func (m *OS) renderDock() *lipgloss.Layer {
 		dockItemsStr += truncStyle.Render(" ...")
 	}
 
-	leftInfo := lipgloss.JoinHorizontal(lipgloss.Top,
-		styledModeText,
-		styledWorkspaceText,
-	)
+	// Center segments go on either side of the minimized windows
+	var centerParts []string
+	for _, part := range []string{layout.CenterBefore, dockItemsStr, layout.CenterAfter} {
+		if part != "" {
+			centerParts = append(centerParts, part)
+		}
+	}
+	dockItemsStr = strings.Join(centerParts, " ")
 
-	var rightInfo string
+	leftInfo := layout.LeftInfo
+
+	rightInfo := layout.RightInfo
 	focusedWindow := m.GetFocusedWindow()
 
 	inCopyMode := focusedWindow != nil && focusedWindow.CopyMode != nil && focusedWindow.CopyMode.Active
@@ -163,13 +81,15 @@ func (m *OS) renderDock() *lipgloss.Layer {
 		var helpText string
 		switch focusedWindow.CopyMode.State {
 		case terminal.CopyModeNormal:
-			helpText = "hjkl:move w/b/e:word f/F/t/T:char /:search n/N:next/prev C-l:clear ;,:repeat v:visual y:yank i:term q:quit"
+			helpText = "hjkl:move w/b/e:word f/F/t/T:char /:search n/N:next/prev C-l:clear ;,:repeat [[/]]:prompt m/':mark v/V/C-v:visual \"x:reg i:term q:quit"
 		case terminal.CopyModeSearch:
 			helpText = "Type to search  n/N:next/prev  Enter:done  Esc:cancel"
 		case terminal.CopyModeVisualChar:
 			helpText = "hjkl:extend w/b/e:word f/F/t/T:char ;,:repeat {/}:para %:bracket y:yank Esc:cancel"
 		case terminal.CopyModeVisualLine:
-			helpText = "jk:extend  y:yank  Esc:cancel"
+			helpText = "jk:extend  [[/]]:prompt  io/ao:output  y:yank  Esc:cancel"
+		case terminal.CopyModeVisualBlock:
+			helpText = "hjkl:extend  \"x:register  y:yank  Esc:cancel"
 		}
 
 		helpStyle := lipgloss.NewStyle().
@@ -177,10 +97,6 @@ func (m *OS) renderDock() *lipgloss.Layer {
 			Background(lipgloss.Color("#1a1a2e")).
 			Padding(0, 1)
 		rightInfo = helpStyle.Render(helpText)
-	} else {
-		cpuGraph := m.GetCPUGraph()
-		ramUsage := m.GetRAMUsage()
-		rightInfo = sysInfoStyle.Render(cpuGraph + " " + ramUsage)
 	}
 
 	actualLeftWidth := lipgloss.Width(leftInfo)
@@ -231,3 +147,94 @@ func (m *OS) renderDock() *lipgloss.Layer {
 	fullDock := lipgloss.JoinVertical(lipgloss.Left, dockbarParts...)
 	return lipgloss.NewLayer(fullDock).X(0).Y(dockbarYPos).Z(config.ZIndexDock).ID("dock")
 }
+
+// renderDockModeParts renders the mode pill and the workspace text of the
+// dock's left text, colored for the current mode.
+func (m *OS) renderDockModeParts(layout *DockLayout) (string, string) {
+	modeStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#a0a0b0")).
+		Bold(true).
+		MarginRight(2)
+
+	if m.workspaceActiveStyle == nil {
+		activeStyle := lipgloss.NewStyle().
+			Background(lipgloss.Color("#4865f2")).
+			Foreground(lipgloss.Color("#ffffff")).
+			Bold(true)
+		m.workspaceActiveStyle = &activeStyle
+	}
+
+	leftText := layout.LeftText
+
+	leftCircle := config.GetDockPillLeftChar()
+	rightCircle := config.GetDockPillRightChar()
+
+	var styledModeText, styledWorkspaceText string
+
+	if leftCircle != "" && rightCircle != "" {
+		startIdx := strings.Index(leftText, leftCircle)
+		endIdx := strings.Index(leftText, rightCircle)
+
+		if startIdx != -1 && endIdx > startIdx {
+			// Segments are joined with a space, so drop the one the text starts with
+			workspacePart := strings.TrimPrefix(leftText[endIdx+len(rightCircle):], " ")
+
+			modeColor := layout.ModeInfo.Color
+			modeLabel := leftText[startIdx+len(leftCircle) : endIdx]
+
+			styledLeftCircle := lipgloss.NewStyle().
+				Foreground(lipgloss.Color(modeColor)).
+				Render(leftCircle)
+
+			styledLabel := lipgloss.NewStyle().
+				Background(lipgloss.Color(modeColor)).
+				Foreground(lipgloss.Color("#ffffff")).
+				Bold(true).
+				Render(modeLabel)
+
+			styledRightCircle := lipgloss.NewStyle().
+				Foreground(lipgloss.Color(modeColor)).
+				Render(rightCircle)
+
+			styledModeText = styledLeftCircle + styledLabel + styledRightCircle
+
+			styledWorkspaceText = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#b0b0c0")).
+				Bold(true).
+				Render(workspacePart)
+		} else {
+			styledModeText = modeStyle.Render(leftText)
+			styledWorkspaceText = ""
+		}
+	} else {
+		modeColor := layout.ModeInfo.Color
+
+		var modeLabel, workspacePart string
+		if strings.Contains(leftText, " ") {
+			for i := 1; i < len(leftText); i++ {
+				if leftText[i] >= '0' && leftText[i] <= '9' {
+					modeLabel = strings.TrimRight(leftText[:i], " ")
+					workspacePart = leftText[i:]
+					break
+				}
+			}
+		}
+
+		if modeLabel == "" {
+			modeLabel = leftText
+		}
+
+		styledModeText = lipgloss.NewStyle().
+			Background(lipgloss.Color(modeColor)).
+			Foreground(lipgloss.Color("#ffffff")).
+			Bold(true).
+			Render(modeLabel)
+
+		styledWorkspaceText = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#b0b0c0")).
+			Bold(true).
+			Render(workspacePart)
+	}
+
+	return styledModeText, styledWorkspaceText
+}
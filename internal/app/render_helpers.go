@@ -553,3 +553,16 @@ func (m *OS) isPositionInSelection(window *terminal.Window, x, y int) bool {
 		return true
 	}
 }
+
+// scrollToSelection returns the first visible row of a list of count rows
+// showing visible of them at a time, moved from offset as little as
+// needed to keep the selected row in view.
+func scrollToSelection(selected, offset, visible, count int) int {
+	if selected < offset {
+		offset = selected
+	}
+	if selected >= offset+visible {
+		offset = selected - visible + 1
+	}
+	return max(min(offset, count-visible), 0)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Gaurav-Gosain/tuios/internal/tape"
 	"github.com/Gaurav-Gosain/tuios/internal/terminal"
 	"github.com/Gaurav-Gosain/tuios/internal/theme"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func (m *OS) renderOverlays() []*lipgloss.Layer {
@@ -115,6 +116,24 @@ func (m *OS) renderOverlays() []*lipgloss.Layer {
 		}
 	}
 
+	if m.ShowGlobalSearch {
+		searchContent := m.RenderGlobalSearch(m.GetRenderWidth(), m.GetRenderHeight())
+		if searchContent != "" {
+			searchLayer := lipgloss.NewLayer(searchContent).
+				X(0).Y(0).Z(config.ZIndexScrollbackBrowser).ID("global-search")
+			layers = append(layers, searchLayer)
+		}
+	}
+
+	if m.ShowHistoryBrowser {
+		historyContent := m.RenderHistoryBrowser(m.GetRenderWidth(), m.GetRenderHeight())
+		if historyContent != "" {
+			historyLayer := lipgloss.NewLayer(historyContent).
+				X(0).Y(0).Z(config.ZIndexScrollbackBrowser).ID("history-browser")
+			layers = append(layers, historyLayer)
+		}
+	}
+
 	if m.ShowQuitConfirm {
 		quitContent, width, height := m.renderQuitConfirmDialog()
 		x := (m.GetRenderWidth() - width) / 2
@@ -124,6 +143,52 @@ func (m *OS) renderOverlays() []*lipgloss.Layer {
 		layers = append(layers, quitLayer)
 	}
 
+	if m.LayoutPrompt != "" {
+		promptContent, width, height := m.renderLayoutPrompt()
+		x := (m.GetRenderWidth() - width) / 2
+		y := (m.GetRenderHeight() - height) / 2
+		promptLayer := lipgloss.NewLayer(promptContent).
+			X(x).Y(y).Z(config.ZIndexHelp + 1).ID("layout-prompt")
+		layers = append(layers, promptLayer)
+	}
+
+	if m.ShowWorkspacePicker {
+		pickerContent, width, height := m.renderWorkspacePicker()
+		x := (m.GetRenderWidth() - width) / 2
+		y := (m.GetRenderHeight() - height) / 2
+		pickerLayer := lipgloss.NewLayer(pickerContent).
+			X(x).Y(y).Z(config.ZIndexHelp + 1).ID("workspace-picker")
+		layers = append(layers, pickerLayer)
+	}
+
+	if m.ShowWindowSwitcher {
+		switcherContent, width, height := m.renderWindowSwitcher()
+		x := max((m.GetRenderWidth()-width)/2, 0)
+		y := max((m.GetRenderHeight()-height)/2, 0)
+		switcherLayer := lipgloss.NewLayer(switcherContent).
+			X(x).Y(y).Z(config.ZIndexHelp + 1).ID("window-switcher")
+		layers = append(layers, switcherLayer)
+	}
+
+	if m.ShowThemeEditor {
+		// Keep to the side so the windows show the colors being edited
+		editorContent, width, height := m.renderThemeEditor()
+		x := max(m.GetRenderWidth()-width-1, 0)
+		y := max((m.GetRenderHeight()-height)/2, 0)
+		editorLayer := lipgloss.NewLayer(editorContent).
+			X(x).Y(y).Z(config.ZIndexHelp + 1).ID("theme-editor")
+		layers = append(layers, editorLayer)
+	}
+
+	if m.ShowCommandPalette {
+		paletteContent, width, height := m.renderCommandPalette()
+		x := (m.GetRenderWidth() - width) / 2
+		y := (m.GetRenderHeight() - height) / 2
+		paletteLayer := lipgloss.NewLayer(paletteContent).
+			X(x).Y(y).Z(config.ZIndexHelp + 1).ID("command-palette")
+		layers = append(layers, paletteLayer)
+	}
+
 	if m.ShowHelp {
 		helpContent := m.RenderHelpMenu(m.GetRenderWidth(), m.GetRenderHeight())
 
@@ -377,7 +442,10 @@ func (m *OS) renderOverlays() []*lipgloss.Layer {
 		var title string
 		var bindings []config.Keybinding
 
-		if m.WorkspacePrefixActive {
+		if m.ActiveKeyTable != "" && m.KeybindRegistry != nil {
+			title = m.KeybindRegistry.KeyTableTitle(m.ActiveKeyTable)
+			bindings = m.KeybindRegistry.KeyTableKeybindings(m.ActiveKeyTable)
+		} else if m.WorkspacePrefixActive {
 			title = "Workspace"
 			bindings = config.GetPrefixKeybindings("workspace")
 		} else if m.MinimizePrefixActive {
@@ -385,7 +453,7 @@ func (m *OS) renderOverlays() []*lipgloss.Layer {
 			bindings = config.GetPrefixKeybindings("minimize")
 			minimizedCount := 0
 			for _, win := range m.Windows {
-				if win.Minimized && win.Workspace == m.CurrentWorkspace {
+				if win.Minimized && win.Workspace == m.CurrentWorkspace && win.Scratchpad == "" {
 					minimizedCount++
 				}
 			}
@@ -407,6 +475,9 @@ func (m *OS) renderOverlays() []*lipgloss.Layer {
 		} else {
 			title = "Prefix"
 			bindings = config.GetPrefixKeybindings("", m.IsDaemonSession)
+			if m.KeybindRegistry != nil {
+				bindings = append(bindings, m.KeybindRegistry.KeyTableEntries()...)
+			}
 		}
 
 		maxKeyLen := 0
@@ -721,3 +792,46 @@ func (m *OS) renderQuitConfirmDialog() (string, int, int) {
 
 	return dialogBox, width, height
 }
+
+// renderLayoutPrompt renders the dialog asking for a layout name to save or
+// load, listing the saved layouts when loading.
+func (m *OS) renderLayoutPrompt() (string, int, int) {
+	borderColor := theme.HelpBorder()
+	activeColor := theme.HelpTabActive()
+	grayColor := theme.HelpGray()
+
+	heading := "Save layout"
+	if m.LayoutPrompt == "load" {
+		heading = "Load layout"
+	}
+	title := lipgloss.NewStyle().
+		Foreground(activeColor).
+		Bold(true).
+		Render(heading)
+
+	input := lipgloss.NewStyle().
+		Width(30).
+		Render("> " + m.LayoutPromptBuffer + "█")
+
+	lines := []string{title, "", input}
+	if m.LayoutPrompt == "load" {
+		saved := "no saved layouts"
+		if len(m.LayoutPromptNames) > 0 {
+			saved = ansi.Truncate(strings.Join(m.LayoutPromptNames, ", "), 30, "…")
+		}
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(grayColor).Render(saved))
+	}
+	hint := "enter: save  esc: cancel"
+	if m.LayoutPrompt == "load" {
+		hint = "enter: load  tab: complete  esc: cancel"
+	}
+	lines = append(lines, "", lipgloss.NewStyle().Foreground(grayColor).Render(hint))
+
+	dialogBox := lipgloss.NewStyle().
+		Border(getBorder()).
+		BorderForeground(borderColor).
+		Padding(1, 3).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return dialogBox, lipgloss.Width(dialogBox), lipgloss.Height(dialogBox)
+}
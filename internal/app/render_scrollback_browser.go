@@ -149,7 +149,16 @@ func (m *OS) renderScrollbackBrowser() string {
 	lines = append(lines, styled(dimFg, false, strings.Repeat("─", innerW)))
 
 	var hints []browserHint
-	if browser.OutputMode {
+	if browser.ExportPending {
+		hints = []browserHint{
+			{"h", "html"},
+			{"s", "svg"},
+			{"a", "ansi"},
+			{"t", "txt"},
+			{"c", "asciicast"},
+			{"esc", "cancel"},
+		}
+	} else if browser.OutputMode {
 		hints = []browserHint{
 			{"hjkl", "move"},
 			{"w/b/e", "word"},
@@ -168,6 +177,7 @@ func (m *OS) renderScrollbackBrowser() string {
 				{"J/K", "scroll"},
 				{"y", "copy"},
 				{"c", "cmd"},
+				{"e", "export"},
 				{"Enter", "paste"},
 				{"/", "search"},
 				{"Tab", "mode"},
@@ -710,6 +720,7 @@ func overlayBrowserHelp(w, h int, outputMode bool, accent, dimFg color.Color) st
 			"c            Copy command",
 			"Enter        Paste command to terminal",
 			"Space        Multi-select",
+			"e            Export selected (h/s/a/t/c)",
 			"/            Search",
 			"",
 			"Tab  1 2 3   Switch mode",
@@ -112,9 +112,8 @@ func (m *OS) renderTerminal(window *terminal.Window, isFocused bool, inTerminalM
 		}
 	}
 
-	inVisualMode := inCopyMode &&
-		(window.CopyMode.State == terminal.CopyModeVisualChar ||
-			window.CopyMode.State == terminal.CopyModeVisualLine)
+	inVisualMode := inCopyMode && window.CopyMode.InVisualMode()
+	inBlockMode := inVisualMode && window.CopyMode.State == terminal.CopyModeVisualBlock
 
 	if inVisualMode {
 		visualSelection = pool.GetHighlightGrid()
@@ -127,6 +126,8 @@ func (m *OS) renderTerminal(window *terminal.Window, isFocused bool, inTerminalM
 		if start.Y > end.Y || (start.Y == end.Y && start.X > end.X) {
 			start, end = end, start
 		}
+		// Block selections cover the same columns on every row
+		blockStartX, blockEndX := min(start.X, end.X), max(start.X, end.X)
 
 		for absY := start.Y; absY <= end.Y; absY++ {
 			var viewportY int
@@ -151,11 +152,15 @@ func (m *OS) renderTerminal(window *terminal.Window, isFocused bool, inTerminalM
 
 			if viewportY >= 0 && viewportY < maxY {
 				startX, endX := 0, maxX-1
-				if absY == start.Y {
-					startX = start.X
-				}
-				if absY == end.Y {
-					endX = end.X
+				if inBlockMode {
+					startX, endX = blockStartX, blockEndX
+				} else {
+					if absY == start.Y {
+						startX = start.X
+					}
+					if absY == end.Y {
+						endX = end.X
+					}
 				}
 
 				for x := startX; x <= endX && x < maxX; x++ {
@@ -359,7 +364,7 @@ func (m *OS) renderTerminal(window *terminal.Window, isFocused bool, inTerminalM
 				char = string(cell.Content)
 			}
 
-			if inVisualMode && visualSelection != nil && visualSelection.Get(y, x) && x <= lineEndX {
+			if inVisualMode && visualSelection != nil && visualSelection.Get(y, x) && (x <= lineEndX || inBlockMode) {
 				selStyle := lipgloss.NewStyle().
 					Background(lipgloss.Color("#5F5FAF")).
 					Foreground(lipgloss.Color("#FFFFFF")).
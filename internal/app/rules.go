@@ -0,0 +1,127 @@
+package app
+
+import (
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// windowRules returns the configured window rules.
+func (m *OS) windowRules() []config.WindowRule {
+	if m.KeybindRegistry == nil {
+		return nil
+	}
+	return m.KeybindRegistry.WindowRules()
+}
+
+// ApplyWindowRules evaluates the window rules for a window and applies those
+// it starts matching. Rules it already matched are not applied again, so a
+// window the user moves away stays where they put it.
+func (m *OS) ApplyWindowRules(w *terminal.Window) {
+	rules := m.windowRules()
+	if len(rules) == 0 {
+		return
+	}
+	if m.windowRuleMatches == nil {
+		m.windowRuleMatches = make(map[string][]bool)
+	}
+
+	previous := m.windowRuleMatches[w.ID]
+	matches := m.matchWindowRules(w, rules)
+	m.windowRuleMatches[w.ID] = matches
+
+	applied := false
+	for i := range rules {
+		if matches[i] && (i >= len(previous) || !previous[i]) {
+			m.applyWindowRule(w, &rules[i])
+			applied = true
+		}
+	}
+	if applied {
+		m.SyncStateToDaemon()
+	}
+}
+
+// checkWindowRules re-evaluates the rules for windows whose title changed.
+// Windows seen for the first time, such as those restored from a session,
+// only have their matches recorded so restoring does not undo user changes.
+func (m *OS) checkWindowRules() {
+	rules := m.windowRules()
+	if len(rules) == 0 {
+		return
+	}
+
+	for _, w := range m.Windows {
+		changed := w.TakeTitleChange()
+		if _, seen := m.windowRuleMatches[w.ID]; !seen {
+			if m.windowRuleMatches == nil {
+				m.windowRuleMatches = make(map[string][]bool)
+			}
+			m.windowRuleMatches[w.ID] = m.matchWindowRules(w, rules)
+			continue
+		}
+		if changed {
+			m.ApplyWindowRules(w)
+		}
+	}
+}
+
+// matchWindowRules reports which rules match a window.
+func (m *OS) matchWindowRules(w *terminal.Window, rules []config.WindowRule) []bool {
+	command := ""
+	for i := range rules {
+		if rules[i].Command != "" {
+			command = w.ForegroundCommand()
+			break
+		}
+	}
+
+	matches := make([]bool, len(rules))
+	for i := range rules {
+		matches[i] = rules[i].Matches(w.Title, command)
+	}
+	return matches
+}
+
+// applyWindowRule applies a rule's actions to a window.
+func (m *OS) applyWindowRule(w *terminal.Window, rule *config.WindowRule) {
+	m.LogInfo("Window %s matched rule (title=%q command=%q)", w.ID[:min(8, len(w.ID))], rule.Title, rule.Command)
+
+	if rule.Name != "" {
+		w.CustomName = rule.Name
+	}
+	if rule.BorderColor != "" {
+		w.BorderColor = rule.BorderColor
+	}
+	w.InvalidateCache()
+
+	if rule.Floating != nil {
+		m.SetWindowFloating(w, *rule.Floating)
+	}
+	if (rule.Width > 0 || rule.Height > 0) && !m.IsTiledWindow(w) {
+		width, height := w.Width, w.Height
+		if rule.Width > 0 {
+			width = rule.Width
+		}
+		if rule.Height > 0 {
+			height = rule.Height
+		}
+		m.centerWindow(w, width, height)
+	}
+
+	if target := rule.WorkspaceTarget(); target != "" {
+		workspace, err := m.ResolveWorkspace(target)
+		if err != nil {
+			m.LogWarn("Window rule: %v", err)
+			return
+		}
+		if workspace == w.Workspace {
+			return
+		}
+		for i, win := range m.Windows {
+			if win == w {
+				m.MoveWindowToWorkspace(i, workspace)
+				break
+			}
+		}
+	}
+}
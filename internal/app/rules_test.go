@@ -0,0 +1,64 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// TestApplyWindowRules tests that rules apply once when a window starts
+// matching and that restored windows are left alone
+func TestApplyWindowRules(t *testing.T) {
+	floating := true
+	cfg := config.DefaultConfig()
+	cfg.WindowRules = []config.WindowRule{
+		{Title: "/^ssh prod/", Workspace: 3, Name: "prod", BorderColor: "#ff0000"},
+		{Title: "notes", Floating: &floating, Width: 40, Height: 10},
+	}
+	m := newTestOS(
+		&terminal.Window{ID: "restored", Title: "ssh prod-old", Workspace: 1, Z: 0},
+		&terminal.Window{ID: "window-ssh", Title: "ssh prod-db", Workspace: 1, Z: 1},
+		&terminal.Window{ID: "window-notes", Title: "notes", Workspace: 1, Z: 2},
+	)
+	m.KeybindRegistry = config.NewKeybindRegistry(cfg)
+	m.TileAllWindows()
+
+	ssh, notes := m.Windows[1], m.Windows[2]
+	m.ApplyWindowRules(ssh)
+	m.ApplyWindowRules(notes)
+	m.checkWindowRules()
+	if restored := m.Windows[0]; restored.Workspace != 1 || restored.CustomName != "" {
+		t.Error("rules should not be applied to windows restored from a session")
+	}
+	if ssh.Workspace != 3 || ssh.CustomName != "prod" || ssh.BorderColor != "#ff0000" {
+		t.Errorf("ssh window: workspace=%d name=%q border=%q", ssh.Workspace, ssh.CustomName, ssh.BorderColor)
+	}
+
+	ssh.Workspace = 1
+	ssh.Title = "ssh prod-db: ~"
+	m.ApplyWindowRules(ssh)
+	if ssh.Workspace != 1 {
+		t.Error("a rule the window already matched should not be applied again")
+	}
+
+	if !notes.Floating || m.WorkspaceTrees[1].HasWindow(m.getWindowIntID(notes.ID)) {
+		t.Error("notes window should float above the layout")
+	}
+}
+
+// TestWindowRuleNamedWorkspace tests that a rule moves windows to a workspace
+// given by name
+func TestWindowRuleNamedWorkspace(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Workspaces.Names = []string{"", "", "db"}
+	cfg.WindowRules = []config.WindowRule{{Title: "psql", Workspace: "db"}}
+	m := newTestOS(&terminal.Window{ID: "window-psql", Title: "psql", Workspace: 1})
+	m.KeybindRegistry = config.NewKeybindRegistry(cfg)
+	m.TileAllWindows()
+
+	m.ApplyWindowRules(m.Windows[0])
+	if got := m.Windows[0].Workspace; got != 3 {
+		t.Errorf("window moved to workspace %d, want 3 (db)", got)
+	}
+}
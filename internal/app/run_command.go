@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+)
+
+// RunCommand starts a shell command in a new window on the current
+// workspace. With popup the window floats centered over the workspace, like
+// a scratchpad. The command is the window's process, so the window closes
+// when the command exits.
+func (m *OS) RunCommand(command string, popup bool) error {
+	if command == "" {
+		return fmt.Errorf("no command to run")
+	}
+	count := len(m.Windows)
+	m.AddCommandWindow(command, command)
+	if len(m.Windows) == count {
+		return fmt.Errorf("failed to create a window for %s", command)
+	}
+
+	w := m.Windows[len(m.Windows)-1]
+	if popup {
+		m.floatWindow(w)
+		m.centerFloatingWindow(w, defaultScratchpadSize, defaultScratchpadSize)
+	}
+	m.SyncStateToDaemon()
+	return nil
+}
+
+// SendToFocusedWindow types text into the focused window as if it was typed
+// on the keyboard.
+func (m *OS) SendToFocusedWindow(text string) error {
+	focused := m.GetFocusedWindow()
+	if focused == nil {
+		return fmt.Errorf("no window is focused")
+	}
+	if err := focused.SendInput([]byte(text)); err != nil {
+		return fmt.Errorf("failed to send input: %w", err)
+	}
+	return nil
+}
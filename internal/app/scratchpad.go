@@ -0,0 +1,101 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// defaultScratchpadSize is the fraction of the usable area a scratchpad
+// covers when its config leaves width or height unset.
+const defaultScratchpadSize = 0.8
+
+// scratchpadWindow returns the index of the window backing a scratchpad, or
+// -1 if it has not been opened yet or its shell has exited.
+func (m *OS) scratchpadWindow(name string) int {
+	for i, w := range m.Windows {
+		if w.Scratchpad == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// ToggleScratchpad shows the named scratchpad floating over the current
+// workspace, or hides it if it is already shown there and focused. The
+// window is created from the scratchpad's config the first time; after that
+// hiding only minimizes it, so its shell and scrollback stay alive.
+func (m *OS) ToggleScratchpad(name string) error {
+	if m.KeybindRegistry == nil {
+		return fmt.Errorf("no scratchpads configured")
+	}
+	pad, ok := m.KeybindRegistry.GetScratchpad(name)
+	if !ok {
+		return fmt.Errorf("unknown scratchpad: %s", name)
+	}
+
+	i := m.scratchpadWindow(name)
+	if i < 0 {
+		return m.openScratchpad(pad)
+	}
+
+	w := m.Windows[i]
+	if w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing && i == m.FocusedWindow {
+		m.MinimizeWindow(i)
+		m.SyncStateToDaemon()
+		return nil
+	}
+	m.showScratchpad(i, pad)
+	return nil
+}
+
+// openScratchpad creates the window for a scratchpad running its command,
+// or a shell when it has none.
+func (m *OS) openScratchpad(pad config.ScratchpadConfig) error {
+	count := len(m.Windows)
+	m.AddCommandWindow(pad.Name, pad.Command)
+	if len(m.Windows) == count {
+		return fmt.Errorf("failed to create scratchpad %s", pad.Name)
+	}
+
+	w := m.Windows[len(m.Windows)-1]
+	w.Scratchpad = pad.Name
+	w.CustomName = pad.Name
+	m.floatWindow(w)
+	m.placeScratchpad(w, pad)
+	m.SyncStateToDaemon()
+	return nil
+}
+
+// showScratchpad brings a scratchpad's window to the current workspace,
+// floating and focused.
+func (m *OS) showScratchpad(i int, pad config.ScratchpadConfig) {
+	w := m.Windows[i]
+	if !w.Floating {
+		m.floatWindow(w)
+	}
+	if w.Workspace != m.CurrentWorkspace {
+		w.Workspace = m.CurrentWorkspace
+		w.MarkPositionDirty()
+		m.SubscribeWorkspaceWindows(m.CurrentWorkspace)
+	}
+	w.Minimized = false
+	w.Minimizing = false
+
+	m.FocusWindow(i)
+	m.placeScratchpad(w, pad)
+	m.SyncStateToDaemon()
+}
+
+// placeScratchpad centers a scratchpad at its configured size.
+func (m *OS) placeScratchpad(w *terminal.Window, pad config.ScratchpadConfig) {
+	width, height := pad.Width, pad.Height
+	if width <= 0 {
+		width = defaultScratchpadSize
+	}
+	if height <= 0 {
+		height = defaultScratchpadSize
+	}
+	m.centerFloatingWindow(w, width, height)
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// TestToggleScratchpad tests that a scratchpad follows the current workspace,
+// floats over it and hides without showing up in the dock
+func TestToggleScratchpad(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Scratchpads = []config.ScratchpadConfig{{Name: "notes", Key: "alt+n"}}
+	m := newTestOS(
+		&terminal.Window{ID: "window-one", Workspace: 2, Z: 0},
+		&terminal.Window{ID: "scratchpad", Workspace: 1, Z: 1, Scratchpad: "notes", Floating: true, Minimized: true},
+	)
+	m.CurrentWorkspace = 2
+	m.KeybindRegistry = config.NewKeybindRegistry(cfg)
+	m.TileAllWindows()
+	pad := m.Windows[1]
+
+	if m.HasMinimizedWindows() || len(m.getDockItems()) != 0 {
+		t.Error("hidden scratchpad should not be in the dock")
+	}
+
+	if err := m.ToggleScratchpad("notes"); err != nil {
+		t.Fatalf("ToggleScratchpad: %v", err)
+	}
+	if pad.Minimized || pad.Workspace != 2 || m.FocusedWindow != 1 {
+		t.Errorf("scratchpad should be shown and focused on workspace 2, got minimized=%v workspace=%d focus=%d",
+			pad.Minimized, pad.Workspace, m.FocusedWindow)
+	}
+	if m.WorkspaceTrees[2].HasWindow(m.getWindowIntID(pad.ID)) {
+		t.Error("scratchpad should float above the layout")
+	}
+
+	if err := m.ToggleScratchpad("notes"); err != nil {
+		t.Fatalf("ToggleScratchpad: %v", err)
+	}
+	if !pad.Minimized {
+		t.Error("toggling a focused scratchpad should hide it")
+	}
+
+	if err := m.ToggleScratchpad("missing"); err == nil {
+		t.Error("expected an error for an unknown scratchpad")
+	}
+}
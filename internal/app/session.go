@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/Gaurav-Gosain/tuios/internal/config"
 	"github.com/Gaurav-Gosain/tuios/internal/layout"
 	"github.com/Gaurav-Gosain/tuios/internal/session"
 	"github.com/Gaurav-Gosain/tuios/internal/terminal"
@@ -54,6 +55,40 @@ func passThroughCursorStyle(data []byte) {
 	}
 }
 
+// restoreTilingLayouts copies the registered layout selection and master
+// window counts from state.
+func (m *OS) restoreTilingLayouts(state *session.SessionState) {
+	m.WorkspaceTilingLayout = make(map[int]string)
+	for ws, name := range state.WorkspaceTilingLayouts {
+		if layout.Validate(name) == nil && !layout.IsBSP(name) {
+			m.WorkspaceTilingLayout[ws] = name
+		}
+	}
+	m.WorkspaceMasterCount = make(map[int]int)
+	maps.Copy(m.WorkspaceMasterCount, state.WorkspaceMasterCounts)
+	m.MasterCount = max(state.WorkspaceMasterCounts[state.CurrentWorkspace], 1)
+}
+
+// restoreZoom takes over the zoomed window and the geometry it returns to
+// from a session state.
+func (m *OS) restoreZoom(state *session.SessionState) {
+	m.ZoomedWindowID = ""
+	for _, ws := range state.Windows {
+		if ws.Zoomed {
+			m.ZoomedWindowID = ws.ID
+			m.zoomRestore = layout.Rect{X: ws.ZoomRestoreX, Y: ws.ZoomRestoreY, W: ws.ZoomRestoreW, H: ws.ZoomRestoreH}
+		}
+	}
+}
+
+// restoreWorkspaces takes over the workspace count and names from a session
+// state.
+func (m *OS) restoreWorkspaces(state *session.SessionState) {
+	m.NumWorkspaces = max(m.NumWorkspaces, min(max(state.NumWorkspaces, state.CurrentWorkspace), config.MaxDynamicWorkspaces))
+	m.WorkspaceNames = make(map[int]string)
+	maps.Copy(m.WorkspaceNames, state.WorkspaceNames)
+}
+
 // BuildSessionState creates a serializable SessionState from the current OS state.
 // This is called progressively during Update() to sync state to the daemon.
 // For windows with active animations, it uses the final (target) positions
@@ -104,6 +139,11 @@ func (m *OS) BuildSessionState() *session.SessionState {
 			Z:            w.Z,
 			Workspace:    w.Workspace,
 			Minimized:    w.Minimized,
+			Floating:     w.Floating,
+			Sticky:       w.Sticky,
+			Scratchpad:   w.Scratchpad,
+			Zoomed:       m.IsZoomed(w),
+			BorderColor:  w.BorderColor,
 			PreMinimizeX: w.PreMinimizeX,
 			PreMinimizeY: w.PreMinimizeY,
 			PreMinimizeW: w.PreMinimizeWidth,
@@ -111,6 +151,11 @@ func (m *OS) BuildSessionState() *session.SessionState {
 			PTYID:        w.PTYID,
 			IsAltScreen:  w.IsAltScreen, // Save alt screen state for mouse forwarding on restore
 		}
+		if m.IsZoomed(w) {
+			ws := &state.Windows[i]
+			ws.ZoomRestoreX, ws.ZoomRestoreY = m.zoomRestore.X, m.zoomRestore.Y
+			ws.ZoomRestoreW, ws.ZoomRestoreH = m.zoomRestore.W, m.zoomRestore.H
+		}
 	}
 
 	// Set focused window ID
@@ -150,6 +195,30 @@ func (m *OS) BuildSessionState() *session.SessionState {
 	state.NextBSPWindowID = m.NextBSPWindowID
 	state.TilingScheme = int(m.TilingScheme)
 
+	// Save registered layout selection
+	if len(m.WorkspaceTilingLayout) > 0 {
+		state.WorkspaceTilingLayouts = make(map[int]string)
+		maps.Copy(state.WorkspaceTilingLayouts, m.WorkspaceTilingLayout)
+	}
+	state.WorkspaceMasterCounts = make(map[int]int)
+	for ws, count := range m.WorkspaceMasterCount {
+		if count > 1 {
+			state.WorkspaceMasterCounts[ws] = count
+		}
+	}
+	if m.MasterCount > 1 {
+		state.WorkspaceMasterCounts[m.CurrentWorkspace] = m.MasterCount
+	} else {
+		delete(state.WorkspaceMasterCounts, m.CurrentWorkspace)
+	}
+
+	// Save workspaces created and named at runtime
+	state.NumWorkspaces = m.NumWorkspaces
+	if len(m.WorkspaceNames) > 0 {
+		state.WorkspaceNames = make(map[int]string)
+		maps.Copy(state.WorkspaceNames, m.WorkspaceNames)
+	}
+
 	return state
 }
 
@@ -162,6 +231,8 @@ func convertBSPNode(node *layout.SerializedNode) *session.SerializedBSPNode {
 		WindowID:   node.WindowID,
 		SplitType:  node.SplitType,
 		SplitRatio: node.SplitRatio,
+		Group:      node.Group,
+		Container:  node.Container,
 		Left:       convertBSPNode(node.Left),
 		Right:      convertBSPNode(node.Right),
 	}
@@ -219,6 +290,7 @@ func (m *OS) RestoreFromState(state *session.SessionState) error {
 			m.LogError("Failed to create daemon window for %s", ws.ID[:8])
 			continue
 		}
+		m.reportHistoryError(window)
 
 		caps := GetHostCapabilities()
 		if caps.CellWidth > 0 && caps.CellHeight > 0 {
@@ -228,6 +300,10 @@ func (m *OS) RestoreFromState(state *session.SessionState) error {
 		window.CustomName = ws.CustomName
 		window.Workspace = ws.Workspace
 		window.Minimized = ws.Minimized
+		window.Floating = ws.Floating
+		window.Sticky = ws.Sticky
+		window.Scratchpad = ws.Scratchpad
+		window.BorderColor = ws.BorderColor
 		window.PreMinimizeX = ws.PreMinimizeX
 		window.PreMinimizeY = ws.PreMinimizeY
 		window.PreMinimizeWidth = ws.PreMinimizeW
@@ -257,6 +333,9 @@ func (m *OS) RestoreFromState(state *session.SessionState) error {
 		}
 	}
 
+	m.restoreZoom(state)
+	m.restoreWorkspaces(state)
+
 	// Restore workspace focus (window ID -> window index)
 	m.WorkspaceFocus = make(map[int]int)
 	for workspace, windowID := range state.WorkspaceFocus {
@@ -280,6 +359,7 @@ func (m *OS) RestoreFromState(state *session.SessionState) error {
 	m.NextBSPWindowID = state.NextBSPWindowID
 	m.TilingScheme = layout.AutoScheme(state.TilingScheme)
 	m.LogInfo("[RESTORE] NextBSPWindowID=%d, TilingScheme=%d", m.NextBSPWindowID, m.TilingScheme)
+	m.restoreTilingLayouts(state)
 
 	// Restore BSP trees
 	if state.WorkspaceTrees != nil && state.AutoTiling {
@@ -302,6 +382,7 @@ func (m *OS) RestoreFromState(state *session.SessionState) error {
 		}
 	}
 
+	m.syncGroupVisibility()
 	m.MarkAllDirty()
 	m.LogInfo("[RESTORE] Restored session state: %d windows, FocusedWindow=%d, AutoTiling=%v", len(m.Windows), m.FocusedWindow, m.AutoTiling)
 
@@ -381,6 +462,9 @@ func (m *OS) ApplyStateSync(state *session.SessionState) error {
 		}
 	}
 
+	m.restoreZoom(state)
+	m.restoreWorkspaces(state)
+
 	// Update workspace focus map
 	m.WorkspaceFocus = make(map[int]int)
 	for workspace, windowID := range state.WorkspaceFocus {
@@ -399,6 +483,7 @@ func (m *OS) ApplyStateSync(state *session.SessionState) error {
 	}
 	m.NextBSPWindowID = state.NextBSPWindowID
 	m.TilingScheme = layout.AutoScheme(state.TilingScheme)
+	m.restoreTilingLayouts(state)
 
 	// Update BSP trees
 	if state.WorkspaceTrees != nil && state.AutoTiling {
@@ -439,6 +524,7 @@ func (m *OS) ApplyStateSync(state *session.SessionState) error {
 		}
 	}
 
+	m.syncGroupVisibility()
 	m.MarkAllDirty()
 	return nil
 }
@@ -458,6 +544,10 @@ func (m *OS) updateWindowFromState(w *terminal.Window, ws *session.WindowState)
 	w.Z = ws.Z
 	w.Workspace = ws.Workspace
 	w.Minimized = ws.Minimized
+	w.Floating = ws.Floating
+	w.Sticky = ws.Sticky
+	w.Scratchpad = ws.Scratchpad
+	w.BorderColor = ws.BorderColor
 	w.PreMinimizeX = ws.PreMinimizeX
 	w.PreMinimizeY = ws.PreMinimizeY
 	w.PreMinimizeWidth = ws.PreMinimizeW
@@ -502,6 +592,7 @@ func (m *OS) createWindowFromSync(ws *session.WindowState) *terminal.Window {
 	if window == nil {
 		return nil
 	}
+	m.reportHistoryError(window)
 
 	caps := GetHostCapabilities()
 	if caps.CellWidth > 0 && caps.CellHeight > 0 {
@@ -511,6 +602,10 @@ func (m *OS) createWindowFromSync(ws *session.WindowState) *terminal.Window {
 	window.CustomName = ws.CustomName
 	window.Workspace = ws.Workspace
 	window.Minimized = ws.Minimized
+	window.Floating = ws.Floating
+	window.Sticky = ws.Sticky
+	window.Scratchpad = ws.Scratchpad
+	window.BorderColor = ws.BorderColor
 	window.PreMinimizeX = ws.PreMinimizeX
 	window.PreMinimizeY = ws.PreMinimizeY
 	window.PreMinimizeWidth = ws.PreMinimizeW
@@ -577,6 +672,8 @@ func convertSessionBSPNode(node *session.SerializedBSPNode) *layout.SerializedNo
 		WindowID:   node.WindowID,
 		SplitType:  node.SplitType,
 		SplitRatio: node.SplitRatio,
+		Group:      node.Group,
+		Container:  node.Container,
 		Left:       convertSessionBSPNode(node.Left),
 		Right:      convertSessionBSPNode(node.Right),
 	}
@@ -860,6 +957,12 @@ func (m *OS) UnsubscribeWorkspaceWindows(workspace int) {
 // AddDaemonWindow creates a new window using a daemon-managed PTY.
 // This is the daemon-mode equivalent of AddWindow.
 func (m *OS) AddDaemonWindow(title string) *OS {
+	return m.addDaemonWindow(title, "")
+}
+
+// addDaemonWindow creates a new window using a daemon-managed PTY that runs
+// command, or the shell when command is empty.
+func (m *OS) addDaemonWindow(title, command string) *OS {
 	m.LogInfo("[DAEMON] AddDaemonWindow called, DaemonClient=%v", m.DaemonClient != nil)
 
 	if m.DaemonClient == nil {
@@ -915,7 +1018,7 @@ func (m *OS) AddDaemonWindow(title string) *OS {
 
 	// Create PTY in daemon
 	m.LogInfo("[DAEMON] Calling CreatePTY(%s, %d, %d)", title, termWidth, termHeight)
-	ptyID, err := m.DaemonClient.CreatePTY(title, termWidth, termHeight)
+	ptyID, err := m.DaemonClient.CreateCommandPTY(title, termWidth, termHeight, command)
 	if err != nil {
 		m.LogError("[DAEMON] Failed to create PTY in daemon: %v", err)
 		return m
@@ -928,6 +1031,7 @@ func (m *OS) AddDaemonWindow(title string) *OS {
 		_ = m.DaemonClient.ClosePTY(ptyID)
 		return m
 	}
+	m.reportHistoryError(window)
 
 	caps := GetHostCapabilities()
 	if caps.CellWidth > 0 && caps.CellHeight > 0 {
@@ -982,6 +1086,8 @@ func (m *OS) AddDaemonWindow(title string) *OS {
 		}
 	}
 
+	m.ApplyWindowRules(window)
+
 	// Sync state to daemon
 	m.SyncStateToDaemon()
 
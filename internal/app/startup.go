@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/layout"
+)
+
+// ApplyStartup applies the [startup] section of the config to a new session:
+// it selects the tiling layout of every workspace and turns tiling on, then
+// starts playing the startup tape.
+func (m *OS) ApplyStartup(startup config.StartupConfig) error {
+	if startup.Layout != "" {
+		if err := layout.Validate(startup.Layout); err != nil {
+			return fmt.Errorf("startup layout: %w", err)
+		}
+		if m.WorkspaceTilingLayout == nil {
+			m.WorkspaceTilingLayout = make(map[int]string)
+		}
+		for ws := 1; ws <= m.NumWorkspaces; ws++ {
+			if layout.IsBSP(startup.Layout) {
+				delete(m.WorkspaceTilingLayout, ws)
+			} else {
+				m.WorkspaceTilingLayout[ws] = startup.Layout
+			}
+		}
+		// Windows are tiled as they open
+		m.AutoTiling = true
+	}
+
+	if startup.Tape != "" {
+		name := strings.TrimSuffix(filepath.Base(startup.Tape), filepath.Ext(startup.Tape))
+		if err := m.PlayTapeFile(TapeFile{Name: name, Path: startup.Tape}); err != nil {
+			return fmt.Errorf("startup tape: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+)
+
+// TestApplyStartup tests the startup layout and tape of a new session
+func TestApplyStartup(t *testing.T) {
+	m := NewOS(OSOptions{KeybindRegistry: config.NewKeybindRegistry(config.DefaultConfig()), Width: 120, Height: 40})
+	if err := m.ApplyStartup(config.StartupConfig{Layout: "nope"}); err == nil {
+		t.Error("an unknown startup layout should be an error")
+	}
+	if m.AutoTiling {
+		t.Error("an unknown startup layout should leave tiling off")
+	}
+
+	tape := filepath.Join(t.TempDir(), "setup.tape")
+	if err := os.WriteFile(tape, []byte("NewWindow\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ApplyStartup(config.StartupConfig{Layout: "columns", Tape: tape}); err != nil {
+		t.Fatalf("ApplyStartup: %v", err)
+	}
+	if !m.AutoTiling || m.WorkspaceTilingLayout[1] != "columns" || m.WorkspaceTilingLayout[m.NumWorkspaces] != "columns" {
+		t.Errorf("every workspace should tile with the startup layout, got %v tiling %v", m.WorkspaceTilingLayout, m.AutoTiling)
+	}
+	if !m.ScriptMode || m.ScriptPlayer == nil {
+		t.Error("the startup tape should be playing")
+	}
+}
@@ -0,0 +1,275 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/system"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// The dock is a status line of segments in a left, center and right section.
+// Built-in segments are read when the dock is drawn, or every
+// StatusPollInterval when reading them costs more. Custom segments run their
+// command in the background on their own interval and show the last line it
+// printed, so a slow script never holds up drawing.
+
+// maxStatusSegmentWidth is the widest a custom segment is shown.
+const maxStatusSegmentWidth = 40
+
+// StatusTickMsg is sent periodically to update the status bar.
+type StatusTickMsg struct{}
+
+// StatusTickCmd schedules the next status bar update.
+func StatusTickCmd() tea.Cmd {
+	return tea.Tick(config.StatusRefreshInterval, func(time.Time) tea.Msg {
+		return StatusTickMsg{}
+	})
+}
+
+// StatusSegmentMsg carries the output of a custom segment's command.
+type StatusSegmentMsg struct {
+	Name   string
+	Output string
+	Err    error
+}
+
+// statusCache holds the values of status segments between updates.
+type statusCache struct {
+	values  map[string]string    // Last value of each polled or custom segment
+	lastRun map[string]time.Time // When each custom segment's command last started
+	running map[string]bool      // Custom segments whose command is running
+	polled  time.Time            // When the built-in segments were last read
+	gitDir  string               // Directory the git branch was looked up for
+	branch  string               // Git branch of gitDir
+}
+
+func (c *statusCache) init() {
+	if c.values == nil {
+		c.values = make(map[string]string)
+		c.lastRun = make(map[string]time.Time)
+		c.running = make(map[string]bool)
+	}
+}
+
+// statusBar returns the status bar configuration.
+func (m *OS) statusBar() config.StatusBarConfig {
+	if m.KeybindRegistry == nil {
+		return config.StatusBarConfig{}
+	}
+	return m.KeybindRegistry.StatusBar()
+}
+
+// statusSegmentNames returns every segment shown in the status bar.
+func (m *OS) statusSegmentNames() []string {
+	left, center, right := m.statusBar().Sections()
+	return slices.Concat(left, center, right)
+}
+
+// UpdateStatus reads the built-in segments that are due and returns the
+// commands of custom segments that are due.
+func (m *OS) UpdateStatus() tea.Cmd {
+	if config.DockbarPosition == "hidden" {
+		return nil
+	}
+	m.status.init()
+
+	now := time.Now()
+	poll := now.Sub(m.status.polled) >= config.StatusPollInterval
+	if poll {
+		m.status.polled = now
+	}
+
+	bar := m.statusBar()
+	var cmds []tea.Cmd
+	for _, name := range m.statusSegmentNames() {
+		switch name {
+		case "battery":
+			if poll {
+				value := ""
+				if battery, ok := system.ReadBattery(); ok {
+					value = fmt.Sprintf("BAT:%d%%", battery.Percent)
+					if battery.Charging {
+						value += "+"
+					}
+				}
+				m.status.values[name] = value
+			}
+		case "load":
+			if poll {
+				value := ""
+				if load, err := system.LoadAverage(); err == nil {
+					value = fmt.Sprintf("LOAD:%.2f", load)
+				}
+				m.status.values[name] = value
+			}
+		case "git_branch":
+			if poll {
+				// Look the branch up again when the dock is drawn
+				m.status.gitDir = ""
+			}
+		default:
+			seg, ok := bar.Segment(name)
+			if !ok || m.status.running[name] || now.Sub(m.status.lastRun[name]) < seg.IntervalDuration() {
+				continue
+			}
+			m.status.running[name] = true
+			m.status.lastRun[name] = now
+			cmds = append(cmds, runStatusSegment(seg, m.focusedWorkingDir()))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// runStatusSegment runs a custom segment's command, killing it when it takes
+// longer than the segment's timeout. The command gets the focused window's
+// working directory in TUIOS_CWD.
+func runStatusSegment(seg config.StatusSegment, cwd string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), seg.TimeoutDuration())
+		defer cancel()
+
+		name, args := system.ShellCommand(seg.Command)
+		// #nosec G204 - running the user's configured status command is intentional
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Env = append(os.Environ(), "TUIOS_CWD="+cwd)
+		// Children of the shell can keep the output open after it is killed
+		cmd.WaitDelay = config.ProcessWaitDelay
+		out, err := cmd.Output()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", seg.TimeoutDuration())
+		}
+		if err != nil {
+			return StatusSegmentMsg{Name: seg.Name, Err: err}
+		}
+		line, _, _ := strings.Cut(string(out), "\n")
+		return StatusSegmentMsg{Name: seg.Name, Output: strings.TrimSpace(line)}
+	}
+}
+
+// SetStatusSegment stores the output of a custom segment's command. A failed
+// run keeps the previous output.
+func (m *OS) SetStatusSegment(msg StatusSegmentMsg) {
+	m.status.init()
+	m.status.running[msg.Name] = false
+	if msg.Err != nil {
+		m.LogWarn("Status segment %s: %v", msg.Name, msg.Err)
+		return
+	}
+	m.status.values[msg.Name] = ansi.Truncate(ansi.Strip(msg.Output), maxStatusSegmentWidth, "…")
+}
+
+// focusedWorkingDir returns the working directory the focused window's shell
+// reported, or "".
+func (m *OS) focusedWorkingDir() string {
+	if w := m.GetFocusedWindow(); w != nil {
+		return w.WorkingDir()
+	}
+	return ""
+}
+
+// statusSegmentText returns the text of a built-in or custom segment, or ""
+// when it has nothing to show.
+func (m *OS) statusSegmentText(name string) string {
+	switch name {
+	case "cpu":
+		return m.GetCPUGraph()
+	case "ram":
+		return m.GetRAMUsage()
+	case "clock":
+		return time.Now().Format("15:04:05")
+	case "session":
+		return m.SessionName
+	case "hostname":
+		m.status.init()
+		if m.status.values["hostname"] == "" {
+			if host, err := os.Hostname(); err == nil {
+				m.status.values["hostname"] = host
+			}
+		}
+		return m.status.values["hostname"]
+	case "git_branch":
+		return m.gitBranch()
+	case "prefix":
+		if m.PrefixActive {
+			return "PREFIX"
+		}
+		return ""
+	case "recording":
+		if m.TapeRecorder != nil && m.TapeRecorder.IsRecording() {
+			return config.TapeRecordingIndicator
+		}
+		return ""
+	}
+	return m.status.values[name]
+}
+
+// gitBranch returns the git branch of the focused window's working
+// directory. The branch is looked up again when the directory changes or
+// every StatusPollInterval.
+func (m *OS) gitBranch() string {
+	dir := m.focusedWorkingDir()
+	if dir == "" {
+		return ""
+	}
+	if m.status.gitDir != dir {
+		m.status.gitDir = dir
+		m.status.branch = system.GitBranch(dir)
+	}
+	return m.status.branch
+}
+
+// renderStatusSegment renders a segment other than mode, workspace and
+// windows, or returns "" when it has nothing to show.
+func (m *OS) renderStatusSegment(name string) string {
+	text := m.statusSegmentText(name)
+	if text == "" {
+		return ""
+	}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#808090"))
+	switch name {
+	case "prefix":
+		style = lipgloss.NewStyle().
+			Background(lipgloss.Color("#ff6b6b")).
+			Foreground(lipgloss.Color("#ffffff")).
+			Bold(true).
+			Padding(0, 1)
+	case "recording":
+		style = lipgloss.NewStyle().
+			Background(lipgloss.Color("#cc0000")).
+			Foreground(lipgloss.Color("#ffffff")).
+			Bold(true).
+			Padding(0, 1)
+	}
+	return style.Render(text)
+}
+
+// renderStatusSection renders segments separated by spaces, skipping those
+// with nothing to show. Mode and workspace are rendered by the dock.
+func (m *OS) renderStatusSection(names []string, layout *DockLayout) string {
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		var part string
+		switch name {
+		case "mode":
+			part, _ = m.renderDockModeParts(layout)
+		case "workspace":
+			_, part = m.renderDockModeParts(layout)
+		default:
+			part = m.renderStatusSegment(name)
+		}
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
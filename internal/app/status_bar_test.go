@@ -0,0 +1,81 @@
+package app
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TestStatusBar tests the default dock layout, custom segments and segments
+// around the minimized windows
+func TestStatusBar(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("status commands use sh")
+	}
+	newOS := func(cfg *config.UserConfig) *OS {
+		m := newTestOS(
+			&terminal.Window{ID: "a", Workspace: 1, Width: 80, Height: 20},
+			&terminal.Window{ID: "b", Workspace: 1, Width: 80, Height: 20, Minimized: true},
+		)
+		m.Width = 160
+		m.AutoTiling = false
+		m.KeybindRegistry = config.NewKeybindRegistry(cfg)
+		return m
+	}
+
+	m := newOS(config.DefaultConfig())
+	layout := m.CalculateDockLayout()
+	if layout.RightWidth != 32 {
+		t.Errorf("the default right section should be the CPU graph and RAM usage, got width %d", layout.RightWidth)
+	}
+	if len(layout.VisibleItems) != 1 {
+		t.Errorf("the minimized window should be in the dock, got %d items", len(layout.VisibleItems))
+	}
+	defaultStart := layout.ItemPositions[0].StartX
+
+	cfg := config.DefaultConfig()
+	cfg.StatusBar = config.StatusBarConfig{
+		Center: []string{"greeting", "windows"},
+		Right:  []string{"slow", "prefix"},
+		Segments: []config.StatusSegment{
+			{Name: "greeting", Command: `printf 'hello %s\nsecond line\n' "$TUIOS_CWD"`},
+			{Name: "slow", Command: "sleep 5", Timeout: 50},
+		},
+	}
+	m = newOS(cfg)
+	m.FocusedWindow = 1
+	m.Windows[1].SetWorkingDir("/")
+
+	cmds := map[string]StatusSegmentMsg{}
+	for _, seg := range cfg.StatusBar.Segments {
+		msg := runStatusSegment(seg, m.focusedWorkingDir())().(StatusSegmentMsg)
+		cmds[seg.Name] = msg
+		m.SetStatusSegment(msg)
+	}
+	if cmds["greeting"].Output != "hello /" {
+		t.Errorf("a segment should show the first line its command prints, got %q", cmds["greeting"].Output)
+	}
+	if cmds["slow"].Err == nil || !strings.Contains(cmds["slow"].Err.Error(), "timed out") {
+		t.Errorf("a command running past its timeout should fail, got %v", cmds["slow"].Err)
+	}
+
+	layout = m.CalculateDockLayout()
+	if got := ansi.Strip(layout.CenterBefore); got != "hello /" {
+		t.Errorf("center segments before the windows should be rendered, got %q", got)
+	}
+	if layout.RightInfo != "" || layout.RightWidth != 0 {
+		t.Errorf("segments with nothing to show should be skipped, got %q", layout.RightInfo)
+	}
+	if layout.ItemPositions[0].StartX == defaultStart {
+		t.Error("the minimized windows should move to make room for the center segments")
+	}
+
+	m.PrefixActive = true
+	if got := ansi.Strip(m.CalculateDockLayout().RightInfo); !strings.Contains(got, "PREFIX") {
+		t.Errorf("the prefix segment should show while the leader key is pending, got %q", got)
+	}
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// Sticky windows are shown on every workspace at the same position. They
+// float, so no workspace tiles them, and their Workspace follows the current
+// workspace on every switch.
+
+// ToggleSticky makes the focused window sticky, or pins it back to the
+// current workspace.
+func (m *OS) ToggleSticky() error {
+	focused := m.GetFocusedWindow()
+	if focused == nil {
+		return fmt.Errorf("no window is focused")
+	}
+	m.SetWindowSticky(focused, !focused.Sticky)
+	return nil
+}
+
+// SetWindowSticky shows a window on every workspace or pins it to the
+// current one. A window made sticky starts floating and stays floating when
+// it is pinned again.
+func (m *OS) SetWindowSticky(w *terminal.Window, sticky bool) {
+	if w.Sticky == sticky {
+		return
+	}
+	if sticky && !w.Floating {
+		m.SetWindowFloating(w, true)
+	}
+	w.Sticky = sticky
+	if sticky && w.Workspace != m.CurrentWorkspace {
+		if m.IsDaemonSession && m.DaemonClient != nil {
+			m.subscribeToPTY(w)
+		}
+		w.Workspace = m.CurrentWorkspace
+		w.MarkPositionDirty()
+	}
+	w.InvalidateCache()
+	m.LogInfo("Window %s sticky=%v", w.ID[:min(8, len(w.ID))], sticky)
+	m.SyncStateToDaemon()
+}
+
+// moveStickyWindows moves the sticky windows to workspace, keeping their
+// position.
+func (m *OS) moveStickyWindows(workspace int) {
+	for _, w := range m.Windows {
+		if w.Sticky && w.Workspace != workspace {
+			w.Workspace = workspace
+			w.MarkPositionDirty()
+		}
+	}
+}
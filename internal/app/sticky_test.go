@@ -0,0 +1,55 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// TestSetWindowSticky tests that sticky windows float, follow workspace
+// switches at the same position and are pinned again by a move
+func TestSetWindowSticky(t *testing.T) {
+	config.AnimationsEnabled = false
+	defer func() { config.AnimationsEnabled = true }()
+
+	m := newTestOS(
+		&terminal.Window{ID: "window-one", Workspace: 1, Z: 0},
+		&terminal.Window{ID: "window-two", Workspace: 1, Z: 1},
+		&terminal.Window{ID: "window-three", Workspace: 2, Z: 2},
+	)
+	m.TileAllWindows()
+
+	sticky := m.Windows[1]
+	m.SetWindowSticky(sticky, true)
+	if !sticky.Floating || m.WorkspaceTrees[1].HasWindow(m.getWindowIntID(sticky.ID)) {
+		t.Error("sticky window should float outside the BSP tree")
+	}
+	sticky.X, sticky.Y = 30, 10
+
+	m.SwitchToWorkspace(2)
+	if sticky.Workspace != 2 {
+		t.Errorf("sticky window on workspace %d, want 2", sticky.Workspace)
+	}
+	if tree := m.WorkspaceTrees[2]; tree != nil && tree.HasWindow(m.getWindowIntID(sticky.ID)) {
+		t.Error("sticky window should not be tiled on the new workspace")
+	}
+
+	m.SwitchToWorkspace(1)
+	if sticky.Workspace != 1 || sticky.X != 30 || sticky.Y != 10 {
+		t.Errorf("sticky window at workspace %d %d,%d, want workspace 1 at 30,10", sticky.Workspace, sticky.X, sticky.Y)
+	}
+
+	if state := m.BuildSessionState(); !state.Windows[1].Sticky {
+		t.Error("sticky state should be saved in the session")
+	}
+
+	m.MoveWindowToWorkspace(1, 3)
+	if sticky.Sticky || sticky.Workspace != 3 {
+		t.Error("moving a sticky window should pin it to the target workspace")
+	}
+	m.SwitchToWorkspace(2)
+	if sticky.Workspace != 3 {
+		t.Error("a pinned window should stay on its workspace")
+	}
+}
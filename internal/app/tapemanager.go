@@ -337,13 +337,22 @@ func (m *OS) TapeManagerPlaySelected() {
 	}
 
 	selected := m.TapeManager.Files[m.TapeManager.SelectedIndex]
+	if err := m.PlayTapeFile(selected); err != nil {
+		m.TapeManager.ErrorMessage = err.Error()
+		m.TapeManager.MessageTime = time.Now()
+		return
+	}
+
+	// Close the manager UI
+	m.ShowTapeManager = false
+}
 
+// PlayTapeFile starts playing a saved tape script.
+func (m *OS) PlayTapeFile(file TapeFile) error {
 	// Read the tape file
-	content, err := os.ReadFile(selected.Path)
+	content, err := os.ReadFile(file.Path)
 	if err != nil {
-		m.TapeManager.ErrorMessage = fmt.Sprintf("Failed to read tape: %s", err)
-		m.TapeManager.MessageTime = time.Now()
-		return
+		return fmt.Errorf("failed to read tape: %w", err)
 	}
 
 	// Parse the tape
@@ -362,9 +371,8 @@ func (m *OS) TapeManagerPlaySelected() {
 	m.ScriptExecutor = tape.NewCommandExecutor(m)
 	m.ScriptConverter = tape.NewScriptMessageConverter()
 
-	// Close the manager UI
-	m.ShowTapeManager = false
-	m.ShowNotification("Playing: "+selected.Name, "info", 2*time.Second)
+	m.ShowNotification("Playing: "+file.Name, "info", 2*time.Second)
+	return nil
 }
 
 // RenderTapeManager renders the tape manager overlay
@@ -0,0 +1,273 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"charm.land/lipgloss/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+	"github.com/charmbracelet/x/ansi"
+	tint "github.com/lrstanley/bubbletint/v2"
+)
+
+// The theme editor changes the colors of the current theme in place, so every
+// window and overlay shows the result while editing. Saving writes the theme
+// to the custom themes directory; closing without saving puts the original
+// colors back. Built-in themes are saved as a copy under a new name.
+
+// ThemeEditorState holds the state of the open theme editor.
+type ThemeEditorState struct {
+	Name          string // Theme ID the theme is saved under
+	SelectedIndex int
+	ScrollOffset  int
+	Input         string // Color or name being typed
+	Editing       bool   // Typing a color for the selected entry
+	EditingName   bool   // Typing the name to save under
+	Modified      bool
+
+	original *tint.Tint             // Palette to restore when closing without saving
+	roles    map[string]*tint.Color // Role overrides to restore
+}
+
+// themeEditorEntries lists the colors the theme editor can change.
+func themeEditorEntries() []theme.ColorKey {
+	return slices.Concat(theme.PaletteColors, theme.RoleColors)
+}
+
+// OpenThemeEditor shows the theme editor for the current theme.
+func (m *OS) OpenThemeEditor() error {
+	t := theme.Current()
+	if t == nil {
+		return fmt.Errorf("theming is disabled, pick a theme with SetTheme first")
+	}
+
+	name := t.ID
+	if slices.Contains(tint.DefaultTintIDs(), t.ID) {
+		name = t.ID + "_custom"
+	}
+	roles := map[string]*tint.Color{}
+	for _, k := range theme.RoleColors {
+		if c := theme.RoleOverride(t.ID, k.Key); c != nil {
+			roles[k.Key] = c
+		}
+	}
+	m.ThemeEditor = &ThemeEditorState{Name: name, original: theme.CopyTheme(t), roles: roles}
+	m.ShowThemeEditor = true
+	return nil
+}
+
+// CloseThemeEditor hides the theme editor, discarding unsaved changes.
+func (m *OS) CloseThemeEditor() {
+	if s := m.ThemeEditor; s != nil && s.Modified {
+		m.restoreEditedTheme()
+		m.ShowNotification("Theme changes discarded", "info", config.NotificationDuration)
+	}
+	m.ShowThemeEditor = false
+	m.ThemeEditor = nil
+}
+
+// restoreEditedTheme puts back the colors the current theme had when the
+// editor was opened.
+func (m *OS) restoreEditedTheme() {
+	s := m.ThemeEditor
+	t := theme.Current()
+	if s == nil || t == nil {
+		return
+	}
+	for _, k := range theme.PaletteColors {
+		theme.SetPaletteColor(t, k.Key, theme.PaletteColor(s.original, k.Key))
+	}
+	for _, k := range theme.RoleColors {
+		theme.SetRoleOverride(t.ID, k.Key, s.roles[k.Key])
+	}
+	m.refreshThemeColors()
+}
+
+// refreshThemeColors redraws everything with the current theme's colors.
+func (m *OS) refreshThemeColors() {
+	m.applyThemeColors()
+	m.MarkAllDirty()
+}
+
+// SelectedThemeColor returns the entry selected in the theme editor.
+func (m *OS) SelectedThemeColor() (theme.ColorKey, bool) {
+	entries := themeEditorEntries()
+	if m.ThemeEditor == nil || m.ThemeEditor.SelectedIndex < 0 || m.ThemeEditor.SelectedIndex >= len(entries) {
+		return theme.ColorKey{}, false
+	}
+	return entries[m.ThemeEditor.SelectedIndex], true
+}
+
+// MoveThemeEditorSelection moves the selection by delta entries, clamped to
+// the list.
+func (m *OS) MoveThemeEditorSelection(delta int) {
+	if s := m.ThemeEditor; s != nil {
+		s.SelectedIndex = max(min(s.SelectedIndex+delta, len(themeEditorEntries())-1), 0)
+	}
+}
+
+// SetThemeEditorColor sets the selected entry of the current theme to a hex
+// color and redraws everything with it.
+func (m *OS) SetThemeEditorColor(hex string) error {
+	t := theme.Current()
+	k, ok := m.SelectedThemeColor()
+	if t == nil || !ok {
+		return fmt.Errorf("no color selected")
+	}
+	c, err := theme.ParseHex(hex)
+	if err != nil {
+		return err
+	}
+	if k.IsRole() {
+		theme.SetRoleOverride(t.ID, k.Key, c)
+	} else {
+		theme.SetPaletteColor(t, k.Key, c)
+	}
+	m.ThemeEditor.Modified = true
+	m.refreshThemeColors()
+	return nil
+}
+
+// ResetThemeEditorColor puts the selected entry back to its color when the
+// editor was opened. A role without an override there uses its palette slot
+// again.
+func (m *OS) ResetThemeEditorColor() {
+	s := m.ThemeEditor
+	t := theme.Current()
+	k, ok := m.SelectedThemeColor()
+	if t == nil || !ok {
+		return
+	}
+	if k.IsRole() {
+		theme.SetRoleOverride(t.ID, k.Key, s.roles[k.Key])
+	} else {
+		theme.SetPaletteColor(t, k.Key, theme.PaletteColor(s.original, k.Key))
+	}
+	s.Modified = true
+	m.refreshThemeColors()
+}
+
+// SaveEditedTheme writes the edited theme to the custom themes directory
+// under the editor's name and switches to it.
+func (m *OS) SaveEditedTheme() (string, error) {
+	s := m.ThemeEditor
+	t := theme.Current()
+	if s == nil || t == nil {
+		return "", fmt.Errorf("theme editor is not open")
+	}
+	id := theme.ThemeID(s.Name)
+	if id == "" {
+		return "", fmt.Errorf("theme name is empty")
+	}
+	dir, err := theme.GetThemesDir()
+	if err != nil {
+		return "", err
+	}
+
+	saved := t
+	if id != t.ID {
+		// Save a copy under the new name and leave the original theme as it was
+		saved = theme.CopyTheme(t)
+		saved.ID, saved.DisplayName = id, id
+		for _, k := range theme.RoleColors {
+			theme.SetRoleOverride(id, k.Key, theme.RoleOverride(t.ID, k.Key))
+		}
+	}
+	path := filepath.Join(dir, id+".json")
+	if err := theme.SaveCustomThemeFile(path, saved); err != nil {
+		return "", err
+	}
+	if saved != t {
+		m.restoreEditedTheme()
+		tint.Register(saved)
+		tint.SetTintID(id)
+	}
+
+	// The running theme already matches the file, so the config watcher does
+	// not need to reload it; a reload would also go back to the configured theme.
+	if m.configWatch != nil {
+		m.configWatch.stamp = m.configWatch.currentStamp()
+	}
+	s.Name, s.Modified = id, false
+	s.original = theme.CopyTheme(saved)
+	s.roles = map[string]*tint.Color{}
+	for _, k := range theme.RoleColors {
+		if c := theme.RoleOverride(id, k.Key); c != nil {
+			s.roles[k.Key] = c
+		}
+	}
+	m.refreshThemeColors()
+	return path, nil
+}
+
+// renderThemeEditor renders the theme editor panel.
+func (m *OS) renderThemeEditor() (string, int, int) {
+	s := m.ThemeEditor
+	if s == nil {
+		return "", 0, 0
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpTabActive()).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpTabActive()).
+		Bold(true)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpGray())
+
+	const nameWidth = 28
+	title := "Theme Editor"
+	if s.Modified {
+		title += " *"
+	}
+	nameLine := dimStyle.Render("save as ") + s.Name
+	if s.EditingName {
+		nameLine = dimStyle.Render("save as ") + s.Input + "█"
+	}
+	lines := []string{titleStyle.Render(title), nameLine, ""}
+
+	entries := themeEditorEntries()
+	maxVisible := max(m.GetRenderHeight()-12, 3)
+	s.ScrollOffset = scrollToSelection(s.SelectedIndex, s.ScrollOffset, maxVisible, len(entries))
+	end := min(s.ScrollOffset+maxVisible, len(entries))
+
+	t := theme.Current()
+	for i := s.ScrollOffset; i < end; i++ {
+		k := entries[i]
+		if i == len(theme.PaletteColors) || i == s.ScrollOffset && k.IsRole() {
+			lines = append(lines, dimStyle.Render("roles"))
+		}
+		value := theme.ColorToString(k.Color())
+		marker := " "
+		if k.IsRole() && t != nil && theme.RoleOverride(t.ID, k.Key) != nil {
+			marker = "•"
+		}
+		swatch := lipgloss.NewStyle().Background(k.Color()).Render("    ")
+		if i == s.SelectedIndex && s.Editing {
+			value = s.Input + "█"
+		}
+		name := fmt.Sprintf("%-*s", nameWidth, ansi.Truncate(k.Name, nameWidth, "…"))
+		if i == s.SelectedIndex {
+			lines = append(lines, selectedStyle.Render("> "+name)+marker+swatch+" "+selectedStyle.Render(value))
+		} else {
+			lines = append(lines, "  "+name+marker+swatch+" "+dimStyle.Render(value))
+		}
+	}
+
+	lines = append(lines, "",
+		dimStyle.Render("enter: edit  r: reset  n: name  s: save"),
+		dimStyle.Render("esc: close (discards unsaved changes)"))
+
+	dialogBox := lipgloss.NewStyle().
+		Border(getBorder()).
+		BorderForeground(theme.HelpBorder()).
+		Padding(1, 2).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return dialogBox, lipgloss.Width(dialogBox), lipgloss.Height(dialogBox)
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+)
+
+// TestThemeEditor tests editing palette and role colors live and discarding
+// the changes
+func TestThemeEditor(t *testing.T) {
+	m := &OS{Width: 120, Height: 40, WorkspaceFocus: make(map[int]int)}
+
+	if err := m.OpenThemeEditor(); err == nil {
+		t.Error("expected an error when theming is disabled")
+	}
+
+	if err := theme.Initialize("dracula"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = theme.Initialize("") }()
+	original := theme.ColorToString(theme.BorderFocusedWindow())
+
+	if err := m.OpenThemeEditor(); err != nil {
+		t.Fatalf("OpenThemeEditor: %v", err)
+	}
+	if m.ThemeEditor.Name != "dracula_custom" {
+		t.Errorf("built-in themes should be saved under a new name, got %q", m.ThemeEditor.Name)
+	}
+
+	// Bright cyan is the palette slot behind the focused window border
+	for i, k := range themeEditorEntries() {
+		if k.Key == "bright_cyan" {
+			m.ThemeEditor.SelectedIndex = i
+		}
+	}
+	if err := m.SetThemeEditorColor("#123456"); err != nil {
+		t.Fatalf("SetThemeEditorColor: %v", err)
+	}
+	if got := theme.ColorToString(theme.BorderFocusedWindow()); got != "#123456" {
+		t.Errorf("editing bright cyan should recolor the focused border, got %s", got)
+	}
+
+	for i, k := range themeEditorEntries() {
+		if k.Key == "border_focused_window" {
+			m.ThemeEditor.SelectedIndex = i
+		}
+	}
+	if err := m.SetThemeEditorColor("#ff8800"); err != nil {
+		t.Fatalf("SetThemeEditorColor: %v", err)
+	}
+	if got := theme.ColorToString(theme.BorderFocusedWindow()); got != "#ff8800" {
+		t.Errorf("expected the role override, got %s", got)
+	}
+	if bg, _ := theme.CopyModeCursor(); theme.ColorToString(bg) != "#123456" {
+		t.Errorf("overriding a role should not change others on the same slot, got %s", theme.ColorToString(bg))
+	}
+	if err := m.SetThemeEditorColor("orange"); err == nil {
+		t.Error("expected an error for an invalid color")
+	}
+
+	m.ResetThemeEditorColor()
+	if got := theme.ColorToString(theme.BorderFocusedWindow()); got != "#123456" {
+		t.Errorf("resetting the role should use the palette slot again, got %s", got)
+	}
+
+	m.CloseThemeEditor()
+	if m.ShowThemeEditor || m.ThemeEditor != nil {
+		t.Error("expected the editor to be closed")
+	}
+	if got := theme.ColorToString(theme.BorderFocusedWindow()); got != original {
+		t.Errorf("closing without saving should restore %s, got %s", original, got)
+	}
+}
@@ -30,8 +30,18 @@ type tileLayout struct {
 	x, y, width, height int
 }
 
-// calculateTilingLayout is a wrapper around layout.CalculateTilingLayout for internal use
+// calculateTilingLayout returns positions for n windows using the current
+// workspace's registered layout, or layout.CalculateTilingLayout for bsp.
 func (m *OS) calculateTilingLayout(n int) []tileLayout {
+	if l, ok := layout.Get(m.CurrentLayoutName()); ok {
+		rects := layout.Apply(l, n, m.GetBSPBounds(), m.layoutParams())
+		result := make([]tileLayout, len(rects))
+		for i, r := range rects {
+			result[i] = tileLayout{x: r.X, y: r.Y, width: r.W, height: r.H}
+		}
+		return result
+	}
+
 	layouts := layout.CalculateTilingLayout(n, m.GetRenderWidth(), m.GetUsableHeight(), m.GetTopMargin(), m.MasterRatio)
 	result := make([]tileLayout, len(layouts))
 	for i, l := range layouts {
@@ -50,7 +60,7 @@ func (m *OS) TileAllWindows() {
 	// Get list of visible windows in current workspace (not minimized)
 	var visibleWindows []*terminal.Window
 	for _, w := range m.Windows {
-		if w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing {
+		if m.isTileable(w) {
 			visibleWindows = append(visibleWindows, w)
 		}
 	}
@@ -167,7 +177,7 @@ func (m *OS) ToggleAutoTiling() {
 		// Add all visible windows to the tree in order
 		var visibleWindows []*terminal.Window
 		for _, w := range m.Windows {
-			if w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing {
+			if m.isTileable(w) {
 				visibleWindows = append(visibleWindows, w)
 			}
 		}
@@ -207,7 +217,8 @@ func (m *OS) TileNewWindow() {
 	m.TileAllWindows()
 }
 
-// RetileAfterClose handles window close in tiling mode
+// RetileAfterClose handles window close in tiling mode. Floating windows are
+// not part of the layout and keep their position.
 func (m *OS) RetileAfterClose() {
 	if !m.AutoTiling {
 		return
@@ -355,7 +366,7 @@ func (m *OS) TileRemainingWindows(excludeIndex int) {
 	var visibleWindows []*terminal.Window
 	var visibleIndices []int
 	for i, w := range m.Windows {
-		if i != excludeIndex && w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing {
+		if i != excludeIndex && m.isTileable(w) {
 			visibleWindows = append(visibleWindows, w)
 			visibleIndices = append(visibleIndices, i)
 		}
@@ -366,7 +377,7 @@ func (m *OS) TileRemainingWindows(excludeIndex int) {
 	}
 
 	// Calculate tiling layout based on number of remaining windows
-	layouts := layout.CalculateTilingLayout(len(visibleWindows), m.GetRenderWidth(), m.GetUsableHeight(), m.GetTopMargin(), m.MasterRatio)
+	layouts := m.calculateTilingLayout(len(visibleWindows))
 
 	// Apply layout with animations
 	for i, idx := range visibleIndices {
@@ -379,7 +390,7 @@ func (m *OS) TileRemainingWindows(excludeIndex int) {
 		// Create animation for smooth transition
 		anim := ui.NewSnapAnimation(
 			m.Windows[idx],
-			l.X, l.Y, l.Width, l.Height,
+			l.x, l.y, l.width, l.height,
 			config.GetAnimationDuration(),
 		)
 
@@ -401,6 +412,9 @@ func (m *OS) SwapWindow(dir Direction) {
 	}
 
 	focusedWindow := m.Windows[m.FocusedWindow]
+	if focusedWindow.Floating {
+		return // Floating windows are not part of the layout
+	}
 	targetIndex := m.findAdjacentWindow(focusedWindow, dir)
 
 	if targetIndex >= 0 {
@@ -422,7 +436,7 @@ func (m *OS) findAdjacentWindow(focused *terminal.Window, dir Direction) int {
 	}
 
 	for i, window := range m.Windows {
-		if i == m.FocusedWindow || window.Workspace != m.CurrentWorkspace || window.Minimized || window.Minimizing {
+		if i == m.FocusedWindow || window.Workspace != m.CurrentWorkspace || window.Minimized || window.Minimizing || m.IsHiddenInGroup(window) || window.Floating {
 			continue
 		}
 
@@ -506,18 +520,23 @@ func (m *OS) SaveCurrentLayout() {
 	layouts := make([]WindowLayout, 0, len(m.Windows))
 	for _, win := range m.Windows {
 		if win.Workspace == m.CurrentWorkspace && !win.Minimized {
+			x, y, width, height := win.X, win.Y, win.Width, win.Height
+			if m.IsZoomed(win) {
+				x, y, width, height = m.zoomRestore.X, m.zoomRestore.Y, m.zoomRestore.W, m.zoomRestore.H
+			}
 			layouts = append(layouts, WindowLayout{
 				WindowID: win.ID,
-				X:        win.X,
-				Y:        win.Y,
-				Width:    win.Width,
-				Height:   win.Height,
+				X:        x,
+				Y:        y,
+				Width:    width,
+				Height:   height,
 			})
 		}
 	}
 
 	m.WorkspaceLayouts[m.CurrentWorkspace] = layouts
 	m.WorkspaceMasterRatio[m.CurrentWorkspace] = m.MasterRatio
+	m.WorkspaceMasterCount[m.CurrentWorkspace] = m.MasterCount
 }
 
 // RestoreWorkspaceLayout restores saved layout when switching to a workspace
@@ -532,6 +551,7 @@ func (m *OS) RestoreWorkspaceLayout(workspace int) {
 	} else {
 		m.MasterRatio = 0.5 // Default
 	}
+	m.MasterCount = max(m.WorkspaceMasterCount[workspace], 1)
 
 	// Check if we have a saved layout for this workspace
 	savedLayouts, hasCustom := m.WorkspaceLayouts[workspace]
@@ -545,7 +565,7 @@ func (m *OS) RestoreWorkspaceLayout(workspace int) {
 	for _, saved := range savedLayouts {
 		// Find window by ID
 		for _, win := range m.Windows {
-			if win.ID == saved.WindowID && win.Workspace == workspace {
+			if win.ID == saved.WindowID && win.Workspace == workspace && !win.Sticky {
 				// Restore saved position/size
 				win.X = saved.X
 				win.Y = saved.Y
@@ -577,7 +597,7 @@ func (m *OS) ResizeFocusedWindowHeight(deltaPixels int) {
 	}
 
 	focusedWindow := m.Windows[m.FocusedWindow]
-	if focusedWindow.Workspace != m.CurrentWorkspace || focusedWindow.Minimized {
+	if focusedWindow.Workspace != m.CurrentWorkspace || focusedWindow.Minimized || focusedWindow.Floating {
 		return
 	}
 
@@ -606,7 +626,7 @@ func (m *OS) ResizeFocusedWindowWidth(deltaPixels int) {
 	}
 
 	focusedWindow := m.Windows[m.FocusedWindow]
-	if focusedWindow.Workspace != m.CurrentWorkspace || focusedWindow.Minimized {
+	if focusedWindow.Workspace != m.CurrentWorkspace || focusedWindow.Minimized || focusedWindow.Floating {
 		return
 	}
 
@@ -634,7 +654,7 @@ func (m *OS) ResizeFocusedWindowWidthLeft(deltaPixels int) {
 	}
 
 	focusedWindow := m.Windows[m.FocusedWindow]
-	if focusedWindow.Workspace != m.CurrentWorkspace || focusedWindow.Minimized {
+	if focusedWindow.Workspace != m.CurrentWorkspace || focusedWindow.Minimized || focusedWindow.Floating {
 		return
 	}
 
@@ -662,7 +682,7 @@ func (m *OS) ResizeFocusedWindowHeightTop(deltaPixels int) {
 	}
 
 	focusedWindow := m.Windows[m.FocusedWindow]
-	if focusedWindow.Workspace != m.CurrentWorkspace || focusedWindow.Minimized {
+	if focusedWindow.Workspace != m.CurrentWorkspace || focusedWindow.Minimized || focusedWindow.Floating {
 		return
 	}
 
@@ -900,7 +920,7 @@ func findWindowsOnVerticalSplitAll(m *OS, splitX int) (leftWindows, rightWindows
 	const tolerance = 1
 
 	for _, win := range m.Windows {
-		if win.Workspace != m.CurrentWorkspace || win.Minimized {
+		if win.Workspace != m.CurrentWorkspace || win.Minimized || win.Floating {
 			continue
 		}
 
@@ -920,7 +940,7 @@ func findWindowsOnHorizontalSplitAll(m *OS, splitY int) (topWindows, bottomWindo
 	const tolerance = 1
 
 	for _, win := range m.Windows {
-		if win.Workspace != m.CurrentWorkspace || win.Minimized {
+		if win.Workspace != m.CurrentWorkspace || win.Minimized || win.Floating {
 			continue
 		}
 
@@ -999,7 +1019,7 @@ func (m *OS) BuildBSPTreeFromCurrentLayout() {
 	var windowIDs []int
 
 	for _, w := range m.Windows {
-		if w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing {
+		if m.isTileable(w) {
 			windows = append(windows, layout.Rect{
 				X: w.X,
 				Y: w.Y,
@@ -1073,8 +1093,17 @@ func (m *OS) getWindowByIntID(intID int) *terminal.Window {
 	return nil
 }
 
-// ApplyBSPLayout applies the BSP tree layout to all windows in the current workspace
+// ApplyBSPLayout applies the BSP tree layout to all windows in the current workspace.
+// When the workspace uses a registered layout the tree is kept up to date but
+// the registered layout positions the windows instead.
 func (m *OS) ApplyBSPLayout() {
+	defer m.syncGroupVisibility()
+
+	if m.UsingLayoutEngine() {
+		m.applyEngineLayout()
+		return
+	}
+
 	tree := m.GetOrCreateBSPTree()
 	if tree == nil || tree.IsEmpty() {
 		return
@@ -1088,6 +1117,7 @@ func (m *OS) ApplyBSPLayout() {
 		if win == nil || win.Workspace != m.CurrentWorkspace || win.Minimized {
 			continue
 		}
+		rect = m.zoomRect(win, rect)
 
 		// Create animation for smooth transition
 		anim := ui.NewSnapAnimation(
@@ -1170,7 +1200,7 @@ func (m *OS) SyncBSPTreeFromGeometry() {
 	// Build geometry map from current window positions
 	geometry := make(map[int]layout.Rect)
 	for _, win := range m.Windows {
-		if win.Workspace == m.CurrentWorkspace && !win.Minimized && !win.Minimizing {
+		if m.isTileable(win) {
 			windowIntID := m.getWindowIntID(win.ID)
 			geometry[windowIntID] = layout.Rect{
 				X: win.X,
@@ -8,6 +8,7 @@ import (
 	"github.com/Gaurav-Gosain/tuios/internal/config"
 	"github.com/Gaurav-Gosain/tuios/internal/session"
 	"github.com/Gaurav-Gosain/tuios/internal/tape"
+	uv "github.com/charmbracelet/ultraviolet"
 )
 
 // TickerMsg represents a periodic tick event for updating the UI.
@@ -148,6 +149,24 @@ func (m *OS) Init() tea.Cmd {
 		cmds = append(cmds, ListenForClientEvents(m.ClientEventChan))
 	}
 
+	// Watch config.toml and custom themes for changes
+	if m.configWatch != nil {
+		cmds = append(cmds, ConfigWatchCmd())
+	}
+
+	// Pick the light or dark theme for the host terminal's background
+	if cmd := requestHostColorScheme(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+
+	// Read status bar segments and start custom segment commands
+	cmds = append(cmds, m.UpdateStatus(), StatusTickCmd())
+
+	// Keep the foreground processes of daemon windows for rules
+	if cmd := m.ForegroundSyncCmd(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+
 	// If this is a restored daemon session, enable callbacks after a delay
 	// This allows buffered PTY output to settle before callbacks start tracking changes
 	if m.IsDaemonSession && m.RestoredFromState {
@@ -268,8 +287,11 @@ func TriggerAltScreenRedrawCmd() tea.Cmd {
 // Update handles all incoming messages and updates the application state.
 // It processes keyboard, mouse, and timer events, managing windows and UI updates.
 func (m *OS) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	// Any non-tick message invalidates the render cache
-	if _, isTick := msg.(TickerMsg); !isTick {
+	// Any non-tick message invalidates the render cache. Foreground syncs
+	// only do when they change a window.
+	switch msg.(type) {
+	case TickerMsg, ForegroundSyncMsg:
+	default:
 		m.renderSkipped = false
 	}
 
@@ -283,9 +305,20 @@ func (m *OS) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Apply window rules to windows whose title changed
+		m.checkWindowRules()
+
+		// Leave a key table that timed out
+		m.checkKeyTableTimeout()
+
 		// Update animations
 		m.UpdateAnimations()
 
+		// End a zoom whose window lost focus, then record settled layout
+		// changes for undo
+		m.checkZoom()
+		m.recordLayoutHistory()
+
 		// Update system info (only needed when dockbar is visible)
 		if config.DockbarPosition != "hidden" {
 			m.UpdateCPUHistory()
@@ -395,6 +428,40 @@ func (m *OS) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, ListenForWindowExits(m.WindowExitChan)
 
+	case ConfigWatchMsg:
+		autoTheme := config.AutoThemeEnabled()
+		m.checkConfigChanges()
+		if !autoTheme && config.AutoThemeEnabled() {
+			// Light and dark themes were just configured
+			return m, tea.Batch(ConfigWatchCmd(), requestHostColorScheme())
+		}
+		return m, ConfigWatchCmd()
+
+	case StatusTickMsg:
+		return m, tea.Batch(StatusTickCmd(), m.UpdateStatus())
+
+	case StatusSegmentMsg:
+		m.SetStatusSegment(msg)
+		return m, nil
+
+	case ForegroundSyncMsg:
+		if m.SetForegroundProcesses(msg) {
+			m.renderSkipped = false
+		}
+		return m, m.ForegroundSyncCmd()
+
+	case tea.BackgroundColorMsg:
+		m.SetHostColorScheme(msg.IsDark())
+		return m, nil
+
+	case uv.DarkColorSchemeEvent:
+		m.SetHostColorScheme(true)
+		return m, nil
+
+	case uv.LightColorSchemeEvent:
+		m.SetHostColorScheme(false)
+		return m, nil
+
 	case EnableCallbacksMsg:
 		// Re-enable VT emulator callbacks after buffered output has settled
 		// This prevents the race condition where buffered PTY output overwrites
@@ -508,9 +575,9 @@ func (m *OS) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.FocusMsg:
-		// Terminal gained focus
-		// Could be used to refresh or resume operations
-		return m, nil
+		// Terminal gained focus. Its background may have changed meanwhile,
+		// for terminals that do not report color scheme changes
+		return m, requestHostColorScheme()
 
 	case tea.BlurMsg:
 		// Terminal lost focus
@@ -663,6 +730,17 @@ func (m *OS) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 				return m, nil
+			case "ExportWindow":
+				// Render a window's history for export (read-only, no notification)
+				resultData, err = m.ExportWindowData(msg.TapeArgs)
+				if m.DaemonClient != nil && msg.RequestID != "" {
+					if err != nil {
+						_ = m.DaemonClient.SendCommandResult(msg.RequestID, false, err.Error())
+					} else {
+						_ = m.DaemonClient.SendCommandResultWithData(msg.RequestID, true, "command executed", resultData)
+					}
+				}
+				return m, nil
 			default:
 				// Handle tape commands that return data specially
 				switch tape.CommandType(msg.TapeCommand) {
@@ -751,6 +829,12 @@ func (m *OS) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, cmd
 
+	case GlobalSearchMsg:
+		if inputHandler != nil {
+			return inputHandler(msg, m)
+		}
+		return m, nil
+
 	case RemoteKeyMsg:
 		// Process a single key from a remote send-keys command
 		var cmd tea.Cmd
@@ -0,0 +1,306 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// The window switcher shows every window on every workspace as a scaled-down
+// thumbnail of its screen, in a grid that can be filtered by typing.
+// Thumbnails are redrawn on every frame, so output keeps showing while the
+// switcher is open. In daemon sessions windows on other workspaces are not
+// streamed, so their thumbnails show the screen as it was last seen.
+
+// Thumbnail size in cells, without the card border and title line.
+const (
+	switcherThumbWidth  = 32
+	switcherThumbHeight = 9
+)
+
+// WindowSwitcherState holds the state of the open window switcher.
+type WindowSwitcherState struct {
+	Query         string
+	SelectedIndex int
+	ScrollRow     int // First visible row of the grid
+	Columns       int // Cards per row at the last render, for up/down
+}
+
+// OpenWindowSwitcher shows the window switcher with the window after the
+// focused one selected, like alt-tab.
+func (m *OS) OpenWindowSwitcher() {
+	m.WindowSwitcher = &WindowSwitcherState{Columns: 1}
+	m.ShowWindowSwitcher = true
+	entries := m.WindowSwitcherEntries()
+	for i, w := range entries {
+		if w == m.GetFocusedWindow() && len(entries) > 1 {
+			m.WindowSwitcher.SelectedIndex = (i + 1) % len(entries)
+			break
+		}
+	}
+}
+
+// CloseWindowSwitcher hides the window switcher.
+func (m *OS) CloseWindowSwitcher() {
+	m.ShowWindowSwitcher = false
+	m.WindowSwitcher = nil
+}
+
+// WindowSwitcherEntries returns the windows matching the switcher's query,
+// by workspace and then in window order. The query is fuzzy matched against
+// the window name, its title and its workspace.
+func (m *OS) WindowSwitcherEntries() []*terminal.Window {
+	query := ""
+	if m.WindowSwitcher != nil {
+		query = strings.TrimSpace(m.WindowSwitcher.Query)
+	}
+
+	var entries []*terminal.Window
+	for ws := 1; ws <= m.NumWorkspaces; ws++ {
+		for _, w := range m.Windows {
+			if w.Workspace != ws {
+				continue
+			}
+			target := w.CustomName + " " + w.Title + " " + m.WorkspaceLabel(ws)
+			if matched, _ := FuzzyMatch(query, target); matched {
+				entries = append(entries, w)
+			}
+		}
+	}
+	return entries
+}
+
+// SelectedSwitcherWindow returns the window selected in the switcher.
+func (m *OS) SelectedSwitcherWindow() *terminal.Window {
+	if m.WindowSwitcher == nil {
+		return nil
+	}
+	entries := m.WindowSwitcherEntries()
+	if m.WindowSwitcher.SelectedIndex < 0 || m.WindowSwitcher.SelectedIndex >= len(entries) {
+		return nil
+	}
+	return entries[m.WindowSwitcher.SelectedIndex]
+}
+
+// MoveSwitcherSelection moves the selection by delta entries, clamped to
+// the list.
+func (m *OS) MoveSwitcherSelection(delta int) {
+	s := m.WindowSwitcher
+	if s == nil {
+		return
+	}
+	count := len(m.WindowSwitcherEntries())
+	s.SelectedIndex = max(min(s.SelectedIndex+delta, count-1), 0)
+}
+
+// JumpToWindow switches to a window's workspace, restores the window if it
+// is minimized and focuses it.
+func (m *OS) JumpToWindow(w *terminal.Window) {
+	index := m.windowIndex(w)
+	if index < 0 {
+		return
+	}
+	if w.Workspace != m.CurrentWorkspace && !w.Sticky {
+		m.SwitchToWorkspace(w.Workspace)
+	}
+	if w.Minimized {
+		m.RestoreWindow(index)
+		if m.AutoTiling {
+			m.TileAllWindows()
+		}
+	}
+	m.FocusWindow(index)
+	m.MarkAllDirty()
+}
+
+// windowIndex returns the index of a window in m.Windows, or -1.
+func (m *OS) windowIndex(w *terminal.Window) int {
+	for i, win := range m.Windows {
+		if win == w {
+			return i
+		}
+	}
+	return -1
+}
+
+// renderWindowThumbnail draws a window's screen scaled down to width x
+// height cells by sampling one cell per thumbnail cell.
+func (m *OS) renderWindowThumbnail(w *terminal.Window, width, height int) string {
+	m.terminalMu.Lock()
+	defer m.terminalMu.Unlock()
+
+	blank := strings.Repeat(" ", width)
+	lines := make([]string, height)
+	screen := w.Terminal
+	if screen == nil || screen.Width() == 0 || screen.Height() == 0 {
+		for y := range lines {
+			lines[y] = blank
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	srcWidth, srcHeight := screen.Width(), screen.Height()
+	var sb strings.Builder
+	for y := range height {
+		sb.Reset()
+		srcY := y * srcHeight / height
+		for x := range width {
+			cell := screen.CellAt(x*srcWidth/width, srcY)
+			char := " "
+			if cell != nil && cell.Content != "" && cell.Width == 1 {
+				char = string(cell.Content)
+			}
+			if cell != nil && shouldApplyStyle(cell) {
+				sb.WriteString(renderStyledText(buildOptimizedCellStyleCached(cell), char))
+			} else {
+				sb.WriteString(char)
+			}
+		}
+		lines[y] = sb.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderWindowSwitcher renders the window switcher grid.
+func (m *OS) renderWindowSwitcher() (string, int, int) {
+	s := m.WindowSwitcher
+	if s == nil {
+		return "", 0, 0
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpTabActive()).
+		Bold(true)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpGray())
+
+	cardWidth := switcherThumbWidth + 2
+	cardHeight := switcherThumbHeight + 3
+	columns := max((m.GetRenderWidth()-8)/(cardWidth+1), 1)
+	rows := max((m.GetRenderHeight()-12)/cardHeight, 1)
+	s.Columns = columns
+
+	entries := m.WindowSwitcherEntries()
+	if s.SelectedIndex >= len(entries) {
+		s.SelectedIndex = max(len(entries)-1, 0)
+	}
+	s.ScrollRow = scrollToSelection(s.SelectedIndex/columns, s.ScrollRow, rows, (len(entries)+columns-1)/columns)
+
+	gridWidth := min(len(entries), columns)*(cardWidth+1) - 1
+	input := lipgloss.NewStyle().
+		Width(max(gridWidth, 40)).
+		Render("> " + s.Query + "█")
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("Windows (%d)", len(entries))),
+		"",
+		input,
+		"",
+	}
+
+	if len(entries) == 0 {
+		lines = append(lines, dimStyle.Render("no matching window"))
+	}
+	for row := s.ScrollRow; row < s.ScrollRow+rows && row*columns < len(entries); row++ {
+		var cards []string
+		for i := row * columns; i < min((row+1)*columns, len(entries)); i++ {
+			cards = append(cards, m.renderSwitcherCard(entries[i], i == s.SelectedIndex))
+			cards = append(cards, " ")
+		}
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, cards[:len(cards)-1]...))
+	}
+	lines = append(lines, "", dimStyle.Render("arrows: select  enter: focus  ctrl+x: close  ctrl+d: minimize  alt+1-9: move  esc: cancel"))
+
+	dialogBox := lipgloss.NewStyle().
+		Border(getBorder()).
+		BorderForeground(theme.HelpBorder()).
+		Padding(1, 2).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return dialogBox, lipgloss.Width(dialogBox), lipgloss.Height(dialogBox)
+}
+
+// renderSwitcherCard renders one window: a title line with the window name
+// and workspace above its thumbnail.
+func (m *OS) renderSwitcherCard(w *terminal.Window, selected bool) string {
+	borderColor := theme.HelpBorder()
+	titleStyle := lipgloss.NewStyle().Foreground(theme.HelpGray())
+	if selected {
+		borderColor = theme.HelpTabActive()
+		titleStyle = lipgloss.NewStyle().Foreground(theme.HelpTabActive()).Bold(true)
+	}
+
+	name := w.CustomName
+	if name == "" {
+		name = w.Title
+	}
+	if name == "" {
+		name = w.ID
+	}
+	status := m.WorkspaceLabel(w.Workspace)
+	switch {
+	case w.Minimized:
+		status += " _"
+	case w == m.GetFocusedWindow():
+		status += " *"
+	}
+	name = ansi.Truncate(name, switcherThumbWidth-ansi.StringWidth(status)-1, "…")
+	padding := strings.Repeat(" ", max(switcherThumbWidth-ansi.StringWidth(name)-ansi.StringWidth(status), 1))
+	title := titleStyle.Render(name + padding + status)
+
+	return lipgloss.NewStyle().
+		Border(getBorder()).
+		BorderForeground(borderColor).
+		Render(title + "\n" + m.renderWindowThumbnail(w, switcherThumbWidth, switcherThumbHeight))
+}
+
+// switcherMoveNotice describes moving a window to a workspace.
+func (m *OS) switcherMoveNotice(w *terminal.Window, workspace int) string {
+	name := w.CustomName
+	if name == "" {
+		name = w.Title
+	}
+	return fmt.Sprintf("Moved %s to workspace %s", name, m.WorkspaceLabel(workspace))
+}
+
+// SwitcherMoveSelected moves the selected window to a workspace, staying on
+// the current one.
+func (m *OS) SwitcherMoveSelected(workspace int) {
+	w := m.SelectedSwitcherWindow()
+	if w == nil {
+		return
+	}
+	m.MoveWindowToWorkspace(m.windowIndex(w), workspace)
+	if w.Workspace == workspace {
+		m.ShowNotification(m.switcherMoveNotice(w, workspace), "info", config.NotificationDuration)
+	}
+}
+
+// SwitcherCloseSelected closes the selected window.
+func (m *OS) SwitcherCloseSelected() {
+	if w := m.SelectedSwitcherWindow(); w != nil {
+		m.DeleteWindow(m.windowIndex(w))
+	}
+}
+
+// SwitcherToggleMinimizeSelected minimizes the selected window, or restores
+// it if it is minimized.
+func (m *OS) SwitcherToggleMinimizeSelected() {
+	w := m.SelectedSwitcherWindow()
+	if w == nil {
+		return
+	}
+	if w.Minimized {
+		m.RestoreWindow(m.windowIndex(w))
+	} else {
+		m.MinimizeWindow(m.windowIndex(w))
+	}
+	if m.AutoTiling {
+		m.TileAllWindows()
+	}
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// TestWindowSwitcher tests listing, filtering and jumping to windows across
+// workspaces from the window switcher
+func TestWindowSwitcher(t *testing.T) {
+	m := newTestOS(
+		&terminal.Window{ID: "window-logs", CustomName: "logs", Workspace: 3},
+		&terminal.Window{ID: "window-shell", Title: "zsh", Workspace: 1},
+		&terminal.Window{ID: "window-editor", Title: "nvim", Workspace: 1},
+	)
+	m.AutoTiling = false
+	m.FocusedWindow = 1
+
+	m.OpenWindowSwitcher()
+	entries := m.WindowSwitcherEntries()
+	if len(entries) != 3 || entries[0] != m.Windows[1] || entries[2] != m.Windows[0] {
+		t.Fatalf("expected windows ordered by workspace, got %+v", entries)
+	}
+	if m.SelectedSwitcherWindow() != m.Windows[2] {
+		t.Errorf("expected the window after the focused one to be selected, got %+v", m.SelectedSwitcherWindow())
+	}
+
+	m.MoveSwitcherSelection(10)
+	if m.SelectedSwitcherWindow() != m.Windows[0] {
+		t.Errorf("selection should stop at the last window, got %+v", m.SelectedSwitcherWindow())
+	}
+
+	m.WindowSwitcher.Query = "lgs"
+	m.WindowSwitcher.SelectedIndex = 0
+	entries = m.WindowSwitcherEntries()
+	if len(entries) != 1 || entries[0] != m.Windows[0] {
+		t.Fatalf("expected only the logs window to match, got %+v", entries)
+	}
+
+	w := m.SelectedSwitcherWindow()
+	m.CloseWindowSwitcher()
+	m.JumpToWindow(w)
+	if m.ShowWindowSwitcher || m.CurrentWorkspace != 3 || m.GetFocusedWindow() != w {
+		t.Errorf("jumping should switch to workspace 3 and focus logs, on %d", m.CurrentWorkspace)
+	}
+
+	m.Windows[1].Minimized = true
+	m.JumpToWindow(m.Windows[1])
+	if m.CurrentWorkspace != 1 || m.Windows[1].Minimized || m.GetFocusedWindow() != m.Windows[1] {
+		t.Errorf("jumping to a minimized window should restore and focus it, on %d", m.CurrentWorkspace)
+	}
+}
@@ -11,7 +11,7 @@ import (
 
 // SwitchToWorkspace switches to the specified workspace.
 func (m *OS) SwitchToWorkspace(workspace int) {
-	if workspace < 1 || workspace > m.NumWorkspaces {
+	if !m.ensureWorkspace(workspace) {
 		m.LogWarn("Cannot switch to workspace %d: out of range (1-%d)", workspace, m.NumWorkspaces)
 		return
 	}
@@ -25,6 +25,9 @@ func (m *OS) SwitchToWorkspace(workspace int) {
 		m.TapeRecorder.RecordWorkspaceSwitch(workspace)
 	}
 
+	// Put a zoomed window back in its tile before the layout is saved
+	m.Unzoom()
+
 	oldWorkspace := m.CurrentWorkspace
 	windowsInNew := m.GetWorkspaceWindowCount(workspace)
 	m.LogInfo("Switching workspace: %d → %d (%d windows)", oldWorkspace, workspace, windowsInNew)
@@ -39,7 +42,7 @@ func (m *OS) SwitchToWorkspace(workspace int) {
 		if m.AutoTiling {
 			visibleWindows := make([]int, 0)
 			for i, w := range m.Windows {
-				if w.Workspace == oldWorkspace && !w.Minimized && !w.Minimizing {
+				if m.isTileable(w) {
 					visibleWindows = append(visibleWindows, i)
 				}
 			}
@@ -69,6 +72,9 @@ func (m *OS) SwitchToWorkspace(workspace int) {
 	}
 	m.SaveCurrentLayout() // Save layout before switching
 
+	// Sticky windows come along, before the PTY subscriptions change
+	m.moveStickyWindows(workspace)
+
 	// Unsubscribe from old workspace PTYs and subscribe to new workspace PTYs
 	// This optimization reduces network traffic by only streaming output for visible windows
 	if m.IsDaemonSession && m.DaemonClient != nil {
@@ -153,7 +159,7 @@ func (m *OS) MoveWindowToWorkspace(windowIndex int, workspace int) {
 		m.LogWarn("Cannot move window: invalid index %d", windowIndex)
 		return
 	}
-	if workspace < 1 || workspace > m.NumWorkspaces {
+	if !m.ensureWorkspace(workspace) {
 		m.LogWarn("Cannot move window: workspace %d out of range (1-%d)", workspace, m.NumWorkspaces)
 		return
 	}
@@ -172,7 +178,8 @@ func (m *OS) MoveWindowToWorkspace(windowIndex int, workspace int) {
 		m.unsubscribeFromPTY(window)
 	}
 
-	// Move window to new workspace FIRST
+	// Move window to new workspace FIRST, pinning it there if it was sticky
+	window.Sticky = false
 	window.Workspace = workspace
 	window.MarkPositionDirty()
 
@@ -199,7 +206,7 @@ func (m *OS) MoveWindowToWorkspaceAndFollow(windowIndex int, workspace int) {
 	if windowIndex < 0 || windowIndex >= len(m.Windows) {
 		return
 	}
-	if workspace < 1 || workspace > m.NumWorkspaces {
+	if !m.ensureWorkspace(workspace) {
 		return
 	}
 
@@ -216,7 +223,8 @@ func (m *OS) MoveWindowToWorkspaceAndFollow(windowIndex int, workspace int) {
 		m.unsubscribeFromPTY(window)
 	}
 
-	// Move window to new workspace FIRST
+	// Move window to new workspace FIRST, pinning it there if it was sticky
+	window.Sticky = false
 	window.Workspace = workspace
 	window.MarkPositionDirty()
 
@@ -283,12 +291,24 @@ func (m *OS) GetWorkspaceWindowCount(workspace int) int {
 	return count
 }
 
+// workspaceWindowCounts returns the number of windows of every workspace that
+// has any, counted in one pass over the windows.
+func (m *OS) workspaceWindowCounts() map[int]int {
+	counts := make(map[int]int)
+	for _, w := range m.Windows {
+		if w.Workspace >= 1 && w.Workspace <= m.NumWorkspaces {
+			counts[w.Workspace]++
+		}
+	}
+	return counts
+}
+
 // TileVisibleWorkspaceWindows tiles all visible windows in the current workspace with animations.
 func (m *OS) TileVisibleWorkspaceWindows() {
 	// Only tile windows in current workspace
 	visibleWindows := make([]int, 0)
 	for i, w := range m.Windows {
-		if w.Workspace == m.CurrentWorkspace && !w.Minimized && !w.Minimizing {
+		if m.isTileable(w) {
 			visibleWindows = append(visibleWindows, i)
 		}
 	}
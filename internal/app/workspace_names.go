@@ -0,0 +1,164 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+)
+
+// Workspaces are numbered from 1 and can be given names. Names come from the
+// config's [workspaces] names and can be changed at runtime; runtime names
+// are kept in WorkspaceNames and saved with the session. With dynamic
+// workspaces, switching to a workspace past NumWorkspaces or to an unknown
+// name creates it, up to config.MaxDynamicWorkspaces.
+
+// WorkspaceName returns the name of a workspace, or "" if it has none.
+func (m *OS) WorkspaceName(workspace int) string {
+	if name, ok := m.WorkspaceNames[workspace]; ok {
+		return name
+	}
+	if m.KeybindRegistry != nil {
+		if names := m.KeybindRegistry.WorkspaceNames(); workspace >= 1 && workspace <= len(names) {
+			return strings.TrimSpace(names[workspace-1])
+		}
+	}
+	return ""
+}
+
+// WorkspaceLabel returns how a workspace is shown: its name, or its number if
+// it has no name.
+func (m *OS) WorkspaceLabel(workspace int) string {
+	if name := m.WorkspaceName(workspace); name != "" {
+		return name
+	}
+	return strconv.Itoa(workspace)
+}
+
+// DynamicWorkspaces reports whether workspaces are created on demand.
+func (m *OS) DynamicWorkspaces() bool {
+	return m.KeybindRegistry != nil && m.KeybindRegistry.DynamicWorkspaces()
+}
+
+// ensureWorkspace reports whether workspace exists, creating it and the
+// workspaces before it when workspaces are dynamic.
+func (m *OS) ensureWorkspace(workspace int) bool {
+	if workspace < 1 {
+		return false
+	}
+	if workspace > m.NumWorkspaces {
+		if !m.DynamicWorkspaces() || workspace > config.MaxDynamicWorkspaces {
+			return false
+		}
+		m.LogInfo("Creating workspaces %d-%d", m.NumWorkspaces+1, workspace)
+		m.NumWorkspaces = workspace
+	}
+	return true
+}
+
+// FindWorkspace looks up a workspace by number or name. Names match exactly
+// first, then ignoring case.
+func (m *OS) FindWorkspace(ref string) (int, bool) {
+	ref = strings.TrimSpace(ref)
+	if n, err := strconv.Atoi(ref); err == nil {
+		return n, n >= 1 && n <= m.NumWorkspaces
+	}
+	for ws := 1; ws <= m.NumWorkspaces; ws++ {
+		if m.WorkspaceName(ws) == ref {
+			return ws, true
+		}
+	}
+	for ws := 1; ws <= m.NumWorkspaces; ws++ {
+		if strings.EqualFold(m.WorkspaceName(ws), ref) {
+			return ws, true
+		}
+	}
+	return 0, false
+}
+
+// ResolveWorkspace returns the workspace a number or name refers to. With
+// dynamic workspaces, a number past the last workspace or an unknown name
+// creates the workspace.
+func (m *OS) ResolveWorkspace(ref string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return 0, fmt.Errorf("workspace number or name is required")
+	}
+	if ws, ok := m.FindWorkspace(ref); ok {
+		return ws, nil
+	}
+	if n, err := strconv.Atoi(ref); err == nil {
+		if m.ensureWorkspace(n) {
+			return n, nil
+		}
+		return 0, fmt.Errorf("workspace %d out of range (1-%d)", n, m.NumWorkspaces)
+	}
+	if !m.DynamicWorkspaces() {
+		return 0, fmt.Errorf("unknown workspace: %s", ref)
+	}
+	return m.AddWorkspace(ref)
+}
+
+// AddWorkspace creates a workspace after the last one and names it. It
+// needs dynamic workspaces.
+func (m *OS) AddWorkspace(name string) (int, error) {
+	if !m.DynamicWorkspaces() {
+		return 0, fmt.Errorf("dynamic workspaces are disabled")
+	}
+	ws := m.NumWorkspaces + 1
+	if ws > config.MaxDynamicWorkspaces {
+		return 0, fmt.Errorf("at most %d workspaces can be created", config.MaxDynamicWorkspaces)
+	}
+	if err := m.checkWorkspaceName(ws, name); err != nil {
+		return 0, err
+	}
+	m.NumWorkspaces = ws
+	m.setWorkspaceName(ws, name)
+	m.LogInfo("Created workspace %d %q", ws, name)
+	m.SyncStateToDaemon()
+	return ws, nil
+}
+
+// RenameWorkspace names a workspace. An empty name clears it, so the
+// workspace is shown by number.
+func (m *OS) RenameWorkspace(workspace int, name string) error {
+	if workspace < 1 || workspace > m.NumWorkspaces {
+		return fmt.Errorf("workspace %d out of range (1-%d)", workspace, m.NumWorkspaces)
+	}
+	name = strings.TrimSpace(name)
+	if name != "" {
+		if err := m.checkWorkspaceName(workspace, name); err != nil {
+			return err
+		}
+	}
+	m.setWorkspaceName(workspace, name)
+	m.LogInfo("Renamed workspace %d to %q", workspace, name)
+	m.SyncStateToDaemon()
+	return nil
+}
+
+// checkWorkspaceName returns an error if name cannot name workspace: names
+// must not be numbers and must be unique, ignoring case.
+func (m *OS) checkWorkspaceName(workspace int, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("workspace name is required")
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		return fmt.Errorf("workspace name %q is a number", name)
+	}
+	for ws := 1; ws <= m.NumWorkspaces; ws++ {
+		if ws != workspace && strings.EqualFold(m.WorkspaceName(ws), name) {
+			return fmt.Errorf("workspace %d is already named %s", ws, m.WorkspaceName(ws))
+		}
+	}
+	return nil
+}
+
+func (m *OS) setWorkspaceName(workspace int, name string) {
+	if m.WorkspaceNames == nil {
+		m.WorkspaceNames = make(map[int]string)
+	}
+	m.WorkspaceNames[workspace] = strings.TrimSpace(name)
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+func workspaceTestOS(names []string, dynamic bool) *OS {
+	cfg := config.DefaultConfig()
+	cfg.Workspaces.Names = names
+	cfg.Workspaces.Dynamic = dynamic
+	return NewOS(OSOptions{KeybindRegistry: config.NewKeybindRegistry(cfg), Width: 120, Height: 40})
+}
+
+// TestWorkspaceNames tests that workspaces are found by number or name and
+// that names can be changed and are saved with the session
+func TestWorkspaceNames(t *testing.T) {
+	m := workspaceTestOS([]string{"api", "", "db"}, false)
+
+	if got := m.WorkspaceLabel(1); got != "api" {
+		t.Errorf("WorkspaceLabel(1) = %q, want api", got)
+	}
+	if got := m.WorkspaceLabel(2); got != "2" {
+		t.Errorf("WorkspaceLabel(2) = %q, want 2", got)
+	}
+	for ref, want := range map[string]int{"db": 3, "DB": 3, "2": 2, " api ": 1} {
+		if ws, err := m.ResolveWorkspace(ref); err != nil || ws != want {
+			t.Errorf("ResolveWorkspace(%q) = %d, %v, want %d", ref, ws, err, want)
+		}
+	}
+	if _, err := m.ResolveWorkspace("infra"); err == nil {
+		t.Error("unknown names should not resolve without dynamic workspaces")
+	}
+	if _, err := m.ResolveWorkspace("10"); err == nil {
+		t.Error("workspace 10 should be out of range without dynamic workspaces")
+	}
+
+	if err := m.RenameWorkspace(2, "infra"); err != nil {
+		t.Fatalf("RenameWorkspace: %v", err)
+	}
+	if err := m.RenameWorkspace(4, "Infra"); err == nil {
+		t.Error("names should be unique ignoring case")
+	}
+	if err := m.RenameWorkspace(4, "7"); err == nil {
+		t.Error("names should not be numbers")
+	}
+	if err := m.RenameWorkspace(1, ""); err != nil || m.WorkspaceLabel(1) != "1" {
+		t.Errorf("clearing a configured name: %v, label %q", err, m.WorkspaceLabel(1))
+	}
+
+	m.WorkspacePicker = &WorkspacePickerState{Query: "in"}
+	if entries := m.WorkspacePickerEntries(); len(entries) != 1 || entries[0].Workspace != 2 {
+		t.Errorf("picker entries for %q = %+v, want workspace 2", "in", entries)
+	}
+
+	state := m.BuildSessionState()
+	restored := workspaceTestOS([]string{"api", "", "db"}, false)
+	restored.restoreWorkspaces(state)
+	if restored.WorkspaceLabel(1) != "1" || restored.WorkspaceLabel(2) != "infra" || restored.WorkspaceLabel(3) != "db" {
+		t.Errorf("restored labels %q %q %q, want 1 infra db",
+			restored.WorkspaceLabel(1), restored.WorkspaceLabel(2), restored.WorkspaceLabel(3))
+	}
+}
+
+// TestDynamicWorkspaces tests that dynamic workspaces are created by number
+// and by name and survive a session restore
+func TestDynamicWorkspaces(t *testing.T) {
+	config.AnimationsEnabled = false
+	defer func() { config.AnimationsEnabled = true }()
+
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}
+	m := workspaceTestOS(names, true)
+	if m.NumWorkspaces != len(names) {
+		t.Fatalf("NumWorkspaces = %d, want one per configured name", m.NumWorkspaces)
+	}
+	m.Windows = []*terminal.Window{{ID: "window-one", Workspace: 1}}
+	m.FocusedWindow = 0
+
+	m.SwitchToWorkspace(14)
+	if m.CurrentWorkspace != 14 || m.NumWorkspaces != 14 {
+		t.Errorf("switching to 14 left workspace %d of %d", m.CurrentWorkspace, m.NumWorkspaces)
+	}
+
+	ws, err := m.ResolveWorkspace("scratch")
+	if err != nil || ws != 15 || m.WorkspaceName(15) != "scratch" {
+		t.Fatalf("ResolveWorkspace(scratch) = %d, %v, want a new workspace 15", ws, err)
+	}
+	m.MoveWindowToWorkspace(0, ws)
+	if m.Windows[0].Workspace != 15 {
+		t.Errorf("window on workspace %d, want 15", m.Windows[0].Workspace)
+	}
+
+	if _, err := m.ResolveWorkspace("5000000"); err == nil || m.NumWorkspaces != 15 {
+		t.Errorf("a workspace past the maximum should be rejected, got %d workspaces, %v", m.NumWorkspaces, err)
+	}
+
+	restored := workspaceTestOS(names, true)
+	restored.restoreWorkspaces(m.BuildSessionState())
+	if restored.NumWorkspaces != 15 || restored.WorkspaceName(15) != "scratch" {
+		t.Errorf("restored %d workspaces, workspace 15 named %q", restored.NumWorkspaces, restored.WorkspaceName(15))
+	}
+}
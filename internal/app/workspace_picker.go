@@ -0,0 +1,150 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// WorkspacePickerState holds the state for the workspace picker, which also
+// serves as the prompt for renaming a workspace.
+type WorkspacePickerState struct {
+	Query         string // Filter typed so far, or the new name when renaming
+	SelectedIndex int    // Index of the selected entry
+	ScrollOffset  int    // First visible entry
+	Renaming      int    // Workspace being renamed (0: picking a workspace)
+}
+
+// WorkspacePickerEntry is a workspace listed in the picker.
+type WorkspacePickerEntry struct {
+	Workspace int
+	Name      string
+	Windows   int
+}
+
+// OpenWorkspacePicker shows the workspace picker.
+func (m *OS) OpenWorkspacePicker() {
+	m.WorkspacePicker = &WorkspacePickerState{}
+	m.ShowWorkspacePicker = true
+}
+
+// OpenWorkspaceRename shows the picker as a prompt for a new name for the
+// current workspace.
+func (m *OS) OpenWorkspaceRename() {
+	m.WorkspacePicker = &WorkspacePickerState{
+		Query:    m.WorkspaceName(m.CurrentWorkspace),
+		Renaming: m.CurrentWorkspace,
+	}
+	m.ShowWorkspacePicker = true
+}
+
+// CloseWorkspacePicker hides the workspace picker.
+func (m *OS) CloseWorkspacePicker() {
+	m.ShowWorkspacePicker = false
+	m.WorkspacePicker = nil
+}
+
+// WorkspacePickerEntries returns the workspaces matching the picker's query:
+// by number prefix, or by name ignoring case.
+func (m *OS) WorkspacePickerEntries() []WorkspacePickerEntry {
+	query := ""
+	if m.WorkspacePicker != nil {
+		query = strings.ToLower(strings.TrimSpace(m.WorkspacePicker.Query))
+	}
+
+	counts := m.workspaceWindowCounts()
+	var entries []WorkspacePickerEntry
+	for ws := 1; ws <= m.NumWorkspaces; ws++ {
+		name := m.WorkspaceName(ws)
+		if query != "" && !strings.HasPrefix(strconv.Itoa(ws), query) && !strings.Contains(strings.ToLower(name), query) {
+			continue
+		}
+		entries = append(entries, WorkspacePickerEntry{
+			Workspace: ws,
+			Name:      name,
+			Windows:   counts[ws],
+		})
+	}
+	return entries
+}
+
+// renderWorkspacePicker renders the workspace picker, or the rename prompt.
+func (m *OS) renderWorkspacePicker() (string, int, int) {
+	s := m.WorkspacePicker
+	if s == nil {
+		return "", 0, 0
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpTabActive()).
+		Bold(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("0")).
+		Background(theme.HelpTabActive()).
+		Bold(true)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(theme.HelpGray())
+
+	const innerWidth = 36
+	input := lipgloss.NewStyle().
+		Width(innerWidth).
+		Render("> " + s.Query + "█")
+
+	var lines []string
+	if s.Renaming > 0 {
+		lines = append(lines,
+			titleStyle.Render(fmt.Sprintf("Rename workspace %d", s.Renaming)),
+			"",
+			input,
+			"",
+			dimStyle.Render("enter: rename  esc: cancel"))
+	} else {
+		lines = append(lines, titleStyle.Render("Workspaces"), "", input, "")
+
+		entries := m.WorkspacePickerEntries()
+		maxVisible := max(m.GetRenderHeight()-14, 3)
+		if s.SelectedIndex >= len(entries) {
+			s.SelectedIndex = max(len(entries)-1, 0)
+		}
+		s.ScrollOffset = scrollToSelection(s.SelectedIndex, s.ScrollOffset, maxVisible, len(entries))
+		end := min(s.ScrollOffset+maxVisible, len(entries))
+
+		if len(entries) == 0 {
+			empty := "no matching workspace"
+			if m.DynamicWorkspaces() && strings.TrimSpace(s.Query) != "" {
+				empty = "enter: create " + strings.TrimSpace(s.Query)
+			}
+			lines = append(lines, dimStyle.Render(ansi.Truncate(empty, innerWidth, "…")))
+		}
+		for i, entry := range entries[s.ScrollOffset:end] {
+			marker := "  "
+			if entry.Workspace == m.CurrentWorkspace {
+				marker = "* "
+			}
+			text := fmt.Sprintf("%s%d  %s", marker, entry.Workspace, entry.Name)
+			text = ansi.Truncate(text, innerWidth-6, "…")
+			text += strings.Repeat(" ", max(innerWidth-6-ansi.StringWidth(text), 0))
+			text += fmt.Sprintf("%6s", fmt.Sprintf("%d win", entry.Windows))
+			if s.ScrollOffset+i == s.SelectedIndex {
+				lines = append(lines, selectedStyle.Render(text))
+			} else {
+				lines = append(lines, text)
+			}
+		}
+		lines = append(lines, "", dimStyle.Render("↑/↓: select  enter: switch  esc: cancel"))
+	}
+
+	dialogBox := lipgloss.NewStyle().
+		Border(getBorder()).
+		BorderForeground(theme.HelpBorder()).
+		Padding(1, 3).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return dialogBox, lipgloss.Width(dialogBox), lipgloss.Height(dialogBox)
+}
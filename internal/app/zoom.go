@@ -0,0 +1,103 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/layout"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/Gaurav-Gosain/tuios/internal/ui"
+)
+
+// Zooming shows one window over its whole workspace, like tmux's zoom. Only
+// the window's geometry changes: the BSP tree, the layout and the saved
+// workspace layouts keep describing its tile, so unzooming puts it back.
+
+// IsZoomed reports whether w is the zoomed window.
+func (m *OS) IsZoomed(w *terminal.Window) bool {
+	return w != nil && m.ZoomedWindowID != "" && w.ID == m.ZoomedWindowID
+}
+
+// ZoomedWindow returns the zoomed window, or nil if no window is zoomed.
+func (m *OS) ZoomedWindow() *terminal.Window {
+	if m.ZoomedWindowID == "" {
+		return nil
+	}
+	for _, w := range m.Windows {
+		if w.ID == m.ZoomedWindowID {
+			return w
+		}
+	}
+	return nil
+}
+
+// ToggleZoom zooms the focused window to cover its workspace, or unzooms it
+// if it is already zoomed.
+func (m *OS) ToggleZoom() error {
+	focused := m.GetFocusedWindow()
+	if focused == nil {
+		return fmt.Errorf("no focused window")
+	}
+	if m.IsZoomed(focused) {
+		m.Unzoom()
+		return nil
+	}
+
+	m.Unzoom()
+	m.ZoomedWindowID = focused.ID
+	m.zoomRestore = layout.Rect{X: focused.X, Y: focused.Y, W: focused.Width, H: focused.Height}
+	m.animateZoom(focused, m.GetBSPBounds())
+	m.SyncStateToDaemon()
+	return nil
+}
+
+// Unzoom returns the zoomed window, if any, to its tile or, when it is not
+// tiled, to the geometry it had before zooming.
+func (m *OS) Unzoom() {
+	w := m.ZoomedWindow()
+	m.ZoomedWindowID = ""
+	if w == nil {
+		return
+	}
+
+	switch {
+	case w.Minimized || w.Minimizing:
+		w.PreMinimizeX, w.PreMinimizeY = m.zoomRestore.X, m.zoomRestore.Y
+		w.PreMinimizeWidth, w.PreMinimizeHeight = m.zoomRestore.W, m.zoomRestore.H
+	case m.IsTiledWindow(w) && w.Workspace == m.CurrentWorkspace:
+		m.ApplyBSPLayout()
+	case m.zoomRestore.W > 0 && m.zoomRestore.H > 0:
+		m.animateZoom(w, m.zoomRestore)
+	}
+	w.InvalidateCache()
+	m.SyncStateToDaemon()
+}
+
+// zoomRect returns where a window the layout places at rect is drawn: the
+// whole workspace when it is zoomed. The tile is remembered for unzooming.
+func (m *OS) zoomRect(w *terminal.Window, rect layout.Rect) layout.Rect {
+	if !m.IsZoomed(w) {
+		return rect
+	}
+	m.zoomRestore = rect
+	return m.GetBSPBounds()
+}
+
+// checkZoom unzooms when focus has moved away from the zoomed window, or the
+// window was closed, minimized or left the workspace.
+func (m *OS) checkZoom() {
+	if m.ZoomedWindowID == "" {
+		return
+	}
+	w := m.ZoomedWindow()
+	if w == nil || w != m.GetFocusedWindow() || w.Workspace != m.CurrentWorkspace || w.Minimized || w.Minimizing {
+		m.Unzoom()
+	}
+}
+
+func (m *OS) animateZoom(w *terminal.Window, rect layout.Rect) {
+	anim := ui.NewSnapAnimation(w, rect.X, rect.Y, rect.W, rect.H, config.GetAnimationDuration())
+	if anim != nil {
+		m.Animations = append(m.Animations, anim)
+	}
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/layout"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// TestToggleZoom tests that zooming covers the workspace without touching the
+// BSP tree and that focusing another window puts the zoomed one back
+func TestToggleZoom(t *testing.T) {
+	config.AnimationsEnabled = false
+	defer func() { config.AnimationsEnabled = true }()
+
+	m := newTestOS(
+		&terminal.Window{ID: "window-one", Workspace: 1, Z: 0},
+		&terminal.Window{ID: "window-two", Workspace: 1, Z: 1},
+		&terminal.Window{ID: "window-three", Workspace: 1, Z: 2},
+	)
+	m.TileAllWindows()
+	m.FocusWindow(1)
+
+	// Windows without a terminal keep their size, so positions are compared
+	zoomed := m.Windows[1]
+	tile := layout.Rect{X: zoomed.X, Y: zoomed.Y}
+	if tile.X == 0 {
+		t.Fatal("expected the second window to be tiled on the right")
+	}
+	tree, _ := json.Marshal(m.WorkspaceTrees[1].Serialize())
+
+	if err := m.ToggleZoom(); err != nil {
+		t.Fatalf("ToggleZoom: %v", err)
+	}
+	bounds := m.GetBSPBounds()
+	if zoomed.X != bounds.X || zoomed.Y != bounds.Y {
+		t.Errorf("zoomed window at %d,%d, want the workspace origin %d,%d", zoomed.X, zoomed.Y, bounds.X, bounds.Y)
+	}
+	for _, w := range m.Windows {
+		if w != zoomed && m.StackZ(w) >= m.StackZ(zoomed) {
+			t.Errorf("%s drawn above the zoomed window", w.ID)
+		}
+	}
+
+	// Retiling and saving the workspace layout keep the tile, not the zoom
+	m.ApplyBSPLayout()
+	m.SaveCurrentLayout()
+	if zoomed.X != bounds.X {
+		t.Error("retiling should keep the window zoomed")
+	}
+	if saved := m.WorkspaceLayouts[1][1]; saved.X != tile.X || saved.Y != tile.Y {
+		t.Errorf("saved layout has %+v, want the tile %+v", saved, tile)
+	}
+	if after, _ := json.Marshal(m.WorkspaceTrees[1].Serialize()); string(after) != string(tree) {
+		t.Error("zooming should not change the BSP tree")
+	}
+	if info := m.getWindowInfo(zoomed, true); info["zoomed"] != true {
+		t.Error("window info should report the zoom")
+	}
+
+	m.FocusWindow(0)
+	if m.ZoomedWindowID != "" {
+		t.Error("focusing another window should unzoom")
+	}
+	if zoomed.X != tile.X || zoomed.Y != tile.Y {
+		t.Errorf("unzoomed window at %d,%d, want its tile %d,%d", zoomed.X, zoomed.Y, tile.X, tile.Y)
+	}
+}
+
+// TestUnzoomFloating tests that a floating window returns to its own
+// geometry when unzoomed, also after the zoom went through a session state
+func TestUnzoomFloating(t *testing.T) {
+	config.AnimationsEnabled = false
+	defer func() { config.AnimationsEnabled = true }()
+
+	m := newTestOS(
+		&terminal.Window{ID: "window-one", Workspace: 1, Z: 0},
+		&terminal.Window{ID: "window-float", Workspace: 1, Z: 1, Floating: true, X: 30, Y: 10, Width: 40, Height: 12},
+	)
+	m.FocusedWindow = 1
+	float := m.Windows[1]
+
+	if err := m.ToggleZoom(); err != nil {
+		t.Fatalf("ToggleZoom: %v", err)
+	}
+	if err := m.ToggleZoom(); err != nil {
+		t.Fatalf("ToggleZoom: %v", err)
+	}
+	if float.X != 30 || float.Y != 10 || m.ZoomedWindowID != "" {
+		t.Errorf("unzoomed window at %d,%d, want 30,10", float.X, float.Y)
+	}
+
+	if err := m.ToggleZoom(); err != nil {
+		t.Fatalf("ToggleZoom: %v", err)
+	}
+	if bounds := m.GetBSPBounds(); float.X != bounds.X || float.Y != bounds.Y {
+		t.Fatalf("zoomed window at %d,%d, want %d,%d", float.X, float.Y, bounds.X, bounds.Y)
+	}
+
+	// A client that takes the zoom over from the session state can unzoom it
+	restored := &OS{CurrentWorkspace: 1, AutoTiling: true, Windows: m.Windows, FocusedWindow: 1}
+	restored.restoreZoom(m.BuildSessionState())
+	if !restored.IsZoomed(float) {
+		t.Fatal("restored state should keep the zoom")
+	}
+	restored.Unzoom()
+	if float.X != 30 || float.Y != 10 {
+		t.Errorf("unzoomed window at %d,%d, want 30,10", float.X, float.Y)
+	}
+}
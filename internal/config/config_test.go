@@ -1,10 +1,14 @@
 package config_test
 
 import (
+	"os"
+	"path/filepath"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/adrg/xdg"
 )
 
 // =============================================================================
@@ -187,6 +191,215 @@ func TestKeyNormalizer_ValidateKey(t *testing.T) {
 	}
 }
 
+func TestScratchpads(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Scratchpads = []config.ScratchpadConfig{
+		{Name: "git", Command: "lazygit", Key: "alt+g"},
+		{Name: "notes", Key: "alt+n", Width: 0.5},
+	}
+	if result := config.ValidateConfig(cfg); result.HasErrors() {
+		t.Fatalf("valid scratchpads reported errors: %v", result.Errors)
+	}
+
+	registry := config.NewKeybindRegistry(cfg)
+	if name := registry.GetScratchpadForKey("alt+g"); name != "git" {
+		t.Errorf("GetScratchpadForKey(alt+g) = %q, want git", name)
+	}
+	if name := registry.GetScratchpadForKey("alt+x"); name != "" {
+		t.Errorf("GetScratchpadForKey(alt+x) = %q, want none", name)
+	}
+	if pad, ok := registry.GetScratchpad("notes"); !ok || pad.Width != 0.5 {
+		t.Errorf("GetScratchpad(notes) = %+v, %v", pad, ok)
+	}
+
+	cfg.Scratchpads = append(cfg.Scratchpads,
+		config.ScratchpadConfig{Name: "git", Key: "alt+h"},
+		config.ScratchpadConfig{Name: "big", Height: 1.5},
+	)
+	if result := config.ValidateConfig(cfg); len(result.Errors) != 2 {
+		t.Errorf("expected errors for a duplicate name and a bad height, got %v", result.Errors)
+	}
+}
+
+func TestWindowRules(t *testing.T) {
+	rule := config.WindowRule{Title: "/^ssh prod/", Command: "ssh"}
+	if !rule.Matches("ssh prod-db", "ssh") {
+		t.Error("rule should match title and command")
+	}
+	if rule.Matches("ssh prod-db", "zsh") || rule.Matches("ssh staging", "ssh") {
+		t.Error("rule should need both title and command to match")
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.WindowRules = []config.WindowRule{
+		{Command: "htop", Workspace: 2},
+		{Title: "([", Name: "bad"},
+		{Name: "no matcher"},
+		{Command: "btop", BorderColor: "red"},
+	}
+	if result := config.ValidateConfig(cfg); len(result.Errors) != 3 {
+		t.Errorf("expected errors for a bad expression, a missing matcher and a bad color, got %v", result.Errors)
+	}
+
+	// Workspaces are given by number or name
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := "[[rule]]\ncommand = \"htop\"\nworkspace = 12\n\n[[rule]]\ncommand = \"psql\"\nworkspace = \"db\"\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.LoadUserConfigFile(path); err == nil {
+		t.Error("workspace 12 should be rejected without dynamic workspaces")
+	}
+	if err := os.WriteFile(path, []byte("[workspaces]\ndynamic = true\n"+content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := config.LoadUserConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadUserConfigFile: %v", err)
+	}
+	if got := loaded.WindowRules[0].WorkspaceTarget(); got != "12" {
+		t.Errorf("numbered workspace target = %q, want 12", got)
+	}
+	if got := loaded.WindowRules[1].WorkspaceTarget(); got != "db" {
+		t.Errorf("named workspace target = %q, want db", got)
+	}
+}
+
+func TestKeyTables(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Keybindings.Tables = map[string]config.KeyTable{
+		"git": {
+			Key:         "g",
+			Description: "Git",
+			Bindings: map[string]string{
+				"s": "tape:Type 'git status'\nEnter",
+				"b": "table:branch",
+				"n": "new_window",
+			},
+		},
+		"branch": {Timeout: 1500, Bindings: map[string]string{"l": "tape:Type 'git branch'"}},
+	}
+	if result := config.ValidateConfig(cfg); result.HasErrors() {
+		t.Fatalf("valid key tables reported errors: %v", result.Errors)
+	}
+
+	registry := config.NewKeybindRegistry(cfg)
+	if got := registry.GetKeyTableForKey("g"); got != "git" {
+		t.Errorf("GetKeyTableForKey(g) = %q, want git", got)
+	}
+	if got := registry.GetKeyTableBinding("git", "b"); got != "table:branch" {
+		t.Errorf("GetKeyTableBinding(git, b) = %q, want table:branch", got)
+	}
+	if kind, arg := config.ParseBindingTarget(registry.GetKeyTableBinding("git", "s")); kind != config.BindingTape || arg != "Type 'git status'\nEnter" {
+		t.Errorf("tape binding parsed as %v %q", kind, arg)
+	}
+	if table, _ := registry.GetKeyTable("branch"); table.TimeoutDuration().Milliseconds() != 1500 {
+		t.Errorf("branch timeout = %v, want 1.5s", table.TimeoutDuration())
+	}
+
+	want := []config.Keybinding{
+		{Key: "b", Description: "branch..."},
+		{Key: "n", Description: "New window"},
+		{Key: "s", Description: "Tape: Type 'git status' ..."},
+		{Key: "Esc", Description: "Cancel"},
+	}
+	if got := registry.KeyTableKeybindings("git"); !slices.Equal(got, want) {
+		t.Errorf("KeyTableKeybindings(git) = %v, want %v", got, want)
+	}
+	if got := registry.KeyTableEntries(); len(got) != 1 || got[0].Description != "Git..." {
+		t.Errorf("KeyTableEntries() = %v, want the git table", got)
+	}
+
+	cfg.Keybindings.Tables["broken"] = config.KeyTable{
+		Key:      "ctrl+",
+		Bindings: map[string]string{"x": "table:missing", "y": "tape:"},
+	}
+	if result := config.ValidateConfig(cfg); len(result.Errors) != 3 {
+		t.Errorf("expected errors for the key, the missing table and the empty tape, got %v", result.Errors)
+	}
+}
+
+func TestMacros(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Keybindings.Tables = map[string]config.KeyTable{"git": {Bindings: map[string]string{"p": "run:git pull"}}}
+	cfg.Keybindings.Macros = map[string]string{
+		"alt+m": "tape:NewWindow\nType \"make test\"\nEnter",
+		"alt+p": "popup:htop",
+		"alt+k": "send:kubectl get pods\n",
+		"alt+g": "table:git",
+	}
+	if result := config.ValidateConfig(cfg); result.HasErrors() {
+		t.Fatalf("valid macros reported errors: %v", result.Errors)
+	}
+
+	registry := config.NewKeybindRegistry(cfg)
+	kind, arg := config.ParseBindingTarget(registry.GetMacroForKey("alt+k"))
+	if kind != config.BindingSend || arg != "kubectl get pods\n" {
+		t.Errorf("send macro parsed as %v %q, want the text with its new line", kind, arg)
+	}
+	if kind, arg := config.ParseBindingTarget(registry.GetMacroForKey("alt+p")); kind != config.BindingPopup || arg != "htop" {
+		t.Errorf("popup macro parsed as %v %q", kind, arg)
+	}
+	if registry.GetMacroForKey("alt+x") != "" {
+		t.Error("unbound keys should have no macro")
+	}
+
+	cfg.Keybindings.Macros = map[string]string{
+		"alt+1": "tape:Type",
+		"alt+2": "tape:Bogus 3",
+		"alt+3": "run:",
+		"alt+4": "table:missing",
+	}
+	if result := config.ValidateConfig(cfg); len(result.Errors) != 4 {
+		t.Errorf("expected an error for each broken macro, got %v", result.Errors)
+	}
+}
+
+func TestLoadUserConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("[appearance]\nborder_style = \"thick\"\n[keybindings.window_management]\nnew_window = [\"N\"]\n")
+	cfg, err := config.LoadUserConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadUserConfigFile: %v", err)
+	}
+	if cfg.Appearance.BorderStyle != "thick" || cfg.Appearance.DockbarPosition != "bottom" {
+		t.Errorf("got border %q dockbar %q, want thick and the default dockbar",
+			cfg.Appearance.BorderStyle, cfg.Appearance.DockbarPosition)
+	}
+	if registry := config.NewKeybindRegistry(cfg); registry.GetAction("N") != "new_window" {
+		t.Error("configured keybinding should be loaded")
+	}
+
+	write("[workspaces]\nnames = [\"1\"]\n")
+	if _, err := config.LoadUserConfigFile(path); err == nil {
+		t.Error("an invalid config should return its validation error")
+	}
+
+	write("[appearance\n")
+	if _, err := config.LoadUserConfigFile(path); err == nil {
+		t.Error("malformed TOML should return an error")
+	}
+}
+
+func TestWorkspaceNames(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Workspaces.Names = []string{"api", "", "db"}
+	if result := config.ValidateConfig(cfg); result.HasErrors() {
+		t.Fatalf("valid workspace names reported errors: %v", result.Errors)
+	}
+
+	cfg.Workspaces.Names = []string{"api", "API", "3"}
+	if result := config.ValidateConfig(cfg); len(result.Errors) != 2 {
+		t.Errorf("expected errors for a duplicate name and a number, got %v", result.Errors)
+	}
+}
+
 // =============================================================================
 // Animation Configuration Tests
 // =============================================================================
@@ -523,3 +736,242 @@ func TestApplyOverrides_HideClock(t *testing.T) {
 		t.Error("Expected HideClock to be true from user config (OR)")
 	}
 }
+
+func TestApplyOverrides_LightDarkThemes(t *testing.T) {
+	defer config.ApplyOverrides(config.Overrides{}, config.DefaultConfig())
+
+	userCfg := config.DefaultConfig()
+	userCfg.Appearance.ThemeLight = "github"
+	userCfg.Appearance.ThemeDark = "dracula"
+	config.ApplyOverrides(config.Overrides{}, userCfg)
+	if !config.AutoThemeEnabled() {
+		t.Fatal("Expected light/dark themes to be enabled")
+	}
+	if config.AutoThemeName(false) != "github" || config.AutoThemeName(true) != "dracula" {
+		t.Errorf("Expected github/dracula, got %q/%q", config.AutoThemeName(false), config.AutoThemeName(true))
+	}
+
+	// Only one side set: the other side uses the plain theme
+	userCfg.Appearance.ThemeLight = ""
+	userCfg.Appearance.Theme = "nord"
+	config.ApplyOverrides(config.Overrides{}, userCfg)
+	if config.AutoThemeName(false) != "nord" {
+		t.Errorf("Expected nord for light terminals, got %q", config.AutoThemeName(false))
+	}
+
+	// A theme given by flag turns switching off
+	config.ApplyOverrides(config.Overrides{ThemeName: "nord"}, userCfg)
+	if config.AutoThemeEnabled() {
+		t.Error("Expected --theme to disable light/dark themes")
+	}
+}
+
+func TestReapplyUserConfig_RemovedKeys(t *testing.T) {
+	defer config.ApplyOverrides(config.Overrides{}, config.DefaultConfig())
+
+	userCfg := config.DefaultConfig()
+	userCfg.Appearance.BorderStyle = "thick"
+	userCfg.Appearance.DockbarPosition = "top"
+	userCfg.Appearance.ScrollbackHistoryDir = "/tmp/tuios-history"
+	config.ReapplyUserConfig(userCfg)
+	if config.BorderStyle != "thick" || config.DockbarPosition != "top" {
+		t.Fatalf("got border %q, dock %q", config.BorderStyle, config.DockbarPosition)
+	}
+
+	// Keys removed from the config go back to their defaults
+	config.ReapplyUserConfig(&config.UserConfig{})
+	if config.BorderStyle != "rounded" || config.DockbarPosition != "bottom" || config.ScrollbackHistoryDir != "" {
+		t.Errorf("after removing the keys got border %q, dock %q, history dir %q",
+			config.BorderStyle, config.DockbarPosition, config.ScrollbackHistoryDir)
+	}
+}
+
+func TestPassthroughRules(t *testing.T) {
+	rule := config.PassthroughRule{Command: "nvim", AltScreen: true}
+	if !rule.Matches("nvim", true) || rule.Matches("nvim", false) || rule.Matches("less", true) {
+		t.Error("a rule should only match when every matcher it sets matches")
+	}
+	if (&config.PassthroughRule{}).Matches("nvim", true) {
+		t.Error("a rule without matchers should never match")
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Passthrough = config.PassthroughConfig{
+		Navigate: map[string]string{"ctrl+h": "left", "ctrl+j": "down"},
+		Rules:    []config.PassthroughRule{{Command: "nvim", Leader: "ctrl+a", Keys: []string{"ctrl+h"}}},
+	}
+	if result := config.ValidateConfig(cfg); result.HasErrors() {
+		t.Fatalf("valid passthrough config reported errors: %v", result.Errors)
+	}
+	registry := config.NewKeybindRegistry(cfg)
+	if registry.GetNavigateDirection("ctrl+j") != "down" || registry.GetNavigateDirection("ctrl+k") != "" {
+		t.Error("navigate keys should map to their direction")
+	}
+	if !registry.PassesKey(&cfg.Passthrough.Rules[0], "ctrl+h") || registry.PassesKey(&cfg.Passthrough.Rules[0], "ctrl+j") {
+		t.Error("a rule should only pass the keys it lists")
+	}
+
+	cfg.Passthrough = config.PassthroughConfig{
+		Navigate: map[string]string{"ctrl+h": "sideways"},
+		Rules: []config.PassthroughRule{
+			{Leader: "ctrl+a"},
+			{Command: "nvim", DisableLeader: true, Leader: "ctrl+a"},
+		},
+	}
+	if result := config.ValidateConfig(cfg); len(result.Errors) != 3 {
+		t.Errorf("expected errors for the direction and both rules, got %v", result.Errors)
+	}
+}
+
+func TestStatusBar(t *testing.T) {
+	left, center, right := config.StatusBarConfig{}.Sections()
+	if len(left) != 2 || len(center) != 1 || center[0] != "windows" || len(right) != 2 {
+		t.Errorf("unset sections should keep the default dock layout, got %v %v %v", left, center, right)
+	}
+	if _, _, right := (config.StatusBarConfig{Right: []string{}}).Sections(); len(right) != 0 {
+		t.Error("an empty section should stay empty")
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.StatusBar = config.StatusBarConfig{
+		Left:     []string{"mode", "session"},
+		Right:    []string{"weather", "git_branch", "clock"},
+		Segments: []config.StatusSegment{{Name: "weather", Command: "curl wttr.in?format=1", Timeout: 3000}},
+	}
+	if result := config.ValidateConfig(cfg); result.HasErrors() {
+		t.Fatalf("valid status bar config reported errors: %v", result.Errors)
+	}
+	seg, ok := cfg.StatusBar.Segment("weather")
+	if !ok || seg.IntervalDuration() != config.DefaultStatusSegmentInterval || seg.TimeoutDuration() != 3*time.Second {
+		t.Errorf("segment timings should default or use the configured milliseconds, got %+v", seg)
+	}
+
+	cfg.StatusBar = config.StatusBarConfig{
+		Left:  []string{"windows", "nope"},
+		Right: []string{"a"},
+		Segments: []config.StatusSegment{
+			{Name: "a", Command: "date"},
+			{Name: "a", Command: "date"},
+			{Name: "clock", Command: "date"},
+			{Name: "b"},
+		},
+	}
+	if result := config.ValidateConfig(cfg); len(result.Errors) != 5 {
+		t.Errorf("expected errors for windows on the left, the unknown segment, the duplicate, the built-in name and the missing command, got %v", result.Errors)
+	}
+}
+
+func TestConfigLayers(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	base := write("config.toml", "[appearance]\ntheme = \"nord\"\nborder_style = \"thick\"\n"+
+		"[[scratchpad]]\nname = \"notes\"\nkey = \"alt+n\"\n")
+	profile := write("profiles/work.toml", "[appearance]\ntheme = \"dracula\"\n"+
+		"[keybindings.window_management]\nnew_window = [\"alt+t\"]\n")
+	project := write("project/.tuios.toml", "[startup]\nlayout = \"columns\"\ntape = \"setup.tape\"\n"+
+		"[[scratchpad]]\nname = \"tests\"\nkey = \"alt+y\"\n")
+
+	cfg, sources, err := config.LoadConfigLayers([]config.ConfigLayer{
+		{Kind: config.LayerConfig, Path: base},
+		{Kind: config.LayerProfile, Path: profile},
+		{Kind: config.LayerProject, Path: project},
+	})
+	if err != nil {
+		t.Fatalf("LoadConfigLayers: %v", err)
+	}
+	if cfg.Appearance.Theme != "dracula" || cfg.Appearance.BorderStyle != "thick" {
+		t.Errorf("an overlay should replace only the values it sets, got theme %q border %q",
+			cfg.Appearance.Theme, cfg.Appearance.BorderStyle)
+	}
+	if registry := config.NewKeybindRegistry(cfg); registry.GetAction("alt+t") != "new_window" || len(registry.GetKeys("close_window")) == 0 {
+		t.Error("overlay keybindings should be added to the other keybindings")
+	}
+	if len(cfg.Scratchpads) != 2 || cfg.Scratchpads[1].Name != "tests" {
+		t.Errorf("overlay scratchpads should be added to the others, got %+v", cfg.Scratchpads)
+	}
+	if want := filepath.Join(dir, "project", "setup.tape"); cfg.Startup.Layout != "columns" || cfg.Startup.Tape != want {
+		t.Errorf("startup = %+v, want the columns layout and the tape next to the project config", cfg.Startup)
+	}
+	for key, want := range map[string]string{
+		"appearance.theme":        profile,
+		"appearance.border_style": base,
+		"scratchpad[0].name":      base,
+		"scratchpad[1].name":      project,
+		"startup.tape":            project,
+	} {
+		if sources[key] != want {
+			t.Errorf("source of %s = %q, want %q", key, sources[key], want)
+		}
+	}
+
+	values, err := config.EffectiveValues(cfg, sources)
+	if err != nil {
+		t.Fatalf("EffectiveValues: %v", err)
+	}
+	found := map[string]config.EffectiveValue{}
+	for _, v := range values {
+		found[v.Key] = v
+	}
+	if v := found["appearance.theme"]; v.Value != "'dracula'" || v.Source != profile {
+		t.Errorf("effective theme = %+v, want dracula from the profile", v)
+	}
+	if v, ok := found["appearance.dockbar_position"]; !ok || v.Source != "" {
+		t.Errorf("a default value should have no source, got %+v", v)
+	}
+
+	if _, _, err := config.LoadConfigLayers([]config.ConfigLayer{
+		{Kind: config.LayerConfig, Path: filepath.Join(dir, "missing.toml")},
+		{Kind: config.LayerProfile, Path: write("bad.toml", "[workspaces]\nnames = [\"1\"]\n")},
+	}); err == nil {
+		t.Error("an overlay that makes the config invalid should return its validation error")
+	}
+
+	if got := config.FindProjectConfig(filepath.Join(dir, "project")); got != project {
+		t.Errorf("FindProjectConfig = %q, want %q", got, project)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "project", "src", "pkg"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if got := config.FindProjectConfig(filepath.Join(dir, "project", "src", "pkg")); got != project {
+		t.Errorf("the project config should be found from a subdirectory, got %q", got)
+	}
+	if _, err := config.GetProfilePath("../work"); err == nil {
+		t.Error("a profile name with a path should be rejected")
+	}
+}
+
+func TestTrustProjectConfig(t *testing.T) {
+	// Runs after t.Setenv restores the environment
+	t.Cleanup(xdg.Reload)
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	xdg.Reload()
+
+	path := filepath.Join(t.TempDir(), config.ProjectConfigName)
+	if err := os.WriteFile(path, []byte("[startup]\nlayout = \"rows\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if config.IsProjectConfigTrusted(path) {
+		t.Fatal("a new project config should not be trusted")
+	}
+	if err := config.TrustProjectConfig(path); err != nil {
+		t.Fatalf("TrustProjectConfig: %v", err)
+	}
+	if !config.IsProjectConfigTrusted(path) {
+		t.Error("a trusted project config should be trusted")
+	}
+	if err := os.WriteFile(path, []byte("[startup]\ntape = \"evil.tape\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if config.IsProjectConfigTrusted(path) {
+		t.Error("a project config should no longer be trusted once it changes")
+	}
+}
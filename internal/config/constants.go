@@ -25,6 +25,9 @@ const (
 
 	// MinWindowHeight is the minimum height a window can be resized to
 	MinWindowHeight = 3
+
+	// LayoutHistoryLimit is the number of layout changes each workspace can undo
+	LayoutHistoryLimit = 50
 )
 
 // =============================================================================
@@ -64,6 +67,30 @@ const (
 
 	// ProcessShutdownTimeout is the timeout for graceful process shutdown
 	ProcessShutdownTimeout = 500 * time.Millisecond
+
+	// ConfigWatchInterval is the interval between checks of config.toml and
+	// the custom themes directory for changes
+	ConfigWatchInterval = time.Second
+
+	// StatusRefreshInterval is the interval between status bar updates, which
+	// start custom segment commands that are due
+	StatusRefreshInterval = time.Second
+
+	// StatusPollInterval is how long built-in status segments such as the
+	// battery and load average keep a reading
+	StatusPollInterval = 5 * time.Second
+
+	// ForegroundCommandCacheTTL is how long the focused window's foreground
+	// command is reused for key passthrough rules before it is looked up again
+	ForegroundCommandCacheTTL = 250 * time.Millisecond
+
+	// ForegroundSyncInterval is how often a daemon client asks the daemon for
+	// the foreground process of its windows while rules match on commands
+	ForegroundSyncInterval = 250 * time.Millisecond
+
+	// GlobalSearchDelay is how long the global search waits after the last
+	// key press before it searches every window
+	GlobalSearchDelay = 150 * time.Millisecond
 )
 
 // =============================================================================
@@ -173,6 +200,9 @@ var (
 	// DockModeIconTiling is the icon for tiling mode (Nerd Font: nf-fa-th - 3x3 grid)
 	DockModeIconTiling string
 
+	// DockModeIconZoom is appended to the mode icon while a window is zoomed (Nerd Font: nf-fa-expand)
+	DockModeIconZoom string
+
 	// DockIconTerminalCount is the icon for terminal count (Nerd Font: nf-fa-terminal)
 	DockIconTerminalCount string
 
@@ -194,6 +224,7 @@ func init() {
 	DockModeIconWindow = " " + fa.WindowRestore.String() + " "
 	DockModeIconTerminal = " " + fa.Terminal.String() + " "
 	DockModeIconTiling = " " + fa.Th.String() + " "
+	DockModeIconZoom = fa.Expand.String() + " "
 	DockIconTerminalCount = fa.Terminal.String()
 	DockIconWorkspaceCount = fa.ThLarge.String()
 	WindowPillLeft = ple.LeftHalfCircleThick.String()
@@ -222,6 +253,9 @@ const (
 	// DockModeIconTilingASCII is the ASCII fallback for tiling mode
 	DockModeIconTilingASCII = " # "
 
+	// DockModeIconZoomASCII is the ASCII fallback for the zoom indicator
+	DockModeIconZoomASCII = "Z "
+
 	// DockIconTerminalCountASCII is the ASCII fallback for terminal count
 	DockIconTerminalCountASCII = "win"
 
@@ -331,6 +365,37 @@ var BorderStyle = "rounded"
 // Set via --dockbar-position flag or appearance.dockbar_position config
 var DockbarPosition = "bottom"
 
+// ThemeLight and ThemeDark are the themes used on host terminals with a light
+// or dark background. Set via appearance.theme_light and appearance.theme_dark
+// config, and ignored when a theme is given by flag
+var (
+	ThemeLight = ""
+	ThemeDark  = ""
+)
+
+// baseTheme is the theme from appearance.theme, used for the side of
+// ThemeLight and ThemeDark that is not set
+var baseTheme = ""
+
+// AutoThemeEnabled reports whether the theme follows the host terminal's
+// background.
+func AutoThemeEnabled() bool {
+	return ThemeLight != "" || ThemeDark != ""
+}
+
+// AutoThemeName returns the theme for a host terminal with a dark or light
+// background. An empty name means no theme.
+func AutoThemeName(dark bool) string {
+	name := ThemeLight
+	if dark {
+		name = ThemeDark
+	}
+	if name == "" {
+		return baseTheme
+	}
+	return name
+}
+
 // HideWindowButtons controls whether to hide window control buttons
 // Set via --hide-window-buttons flag or appearance.hide_window_buttons config
 var HideWindowButtons = false
@@ -348,6 +413,14 @@ var HideClock = false
 // Set via --scrollback-lines flag or appearance.scrollback_lines config
 var ScrollbackLines = 10000
 
+// ScrollbackPersist enables the on-disk history tier behind the scrollback buffer
+// Set via --scrollback-persist flag or appearance.scrollback_persist config
+var ScrollbackPersist = false
+
+// ScrollbackHistoryDir overrides the directory for history files (empty means XDG default)
+// Set via appearance.scrollback_history_dir config
+var ScrollbackHistoryDir = ""
+
 // LeaderKey is the prefix key for commands (default: ctrl+b)
 // Set via appearance.leader_key config
 var LeaderKey = "ctrl+b"
@@ -392,6 +465,14 @@ func GetDockModeIconTiling() string {
 	return DockModeIconTiling
 }
 
+// GetDockModeIconZoom returns the appropriate zoom indicator based on UseASCIIOnly
+func GetDockModeIconZoom() string {
+	if UseASCIIOnly {
+		return DockModeIconZoomASCII
+	}
+	return DockModeIconZoom
+}
+
 // GetDockIconTerminalCount returns the appropriate terminal count icon based on UseASCIIOnly
 func GetDockIconTerminalCount() string {
 	if UseASCIIOnly {
@@ -631,6 +712,10 @@ const (
 	// MaxWorkspaces is the maximum number of workspaces supported
 	MaxWorkspaces = 9
 
+	// MaxDynamicWorkspaces is the most workspaces named or dynamic
+	// workspaces can add up to
+	MaxDynamicWorkspaces = 100
+
 	// CPUHistorySize is the number of CPU usage samples to keep
 	CPUHistorySize = 10
 
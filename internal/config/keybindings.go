@@ -24,6 +24,8 @@ func GetPrefixKeybindings(prefixType string, isDaemonSession ...bool) []Keybindi
 		return []Keybinding{
 			{"1-9", "Switch to workspace"},
 			{"Shift+1-9", "Move window to workspace"},
+			{"w", "Workspace picker"},
+			{"r", "Rename workspace"},
 			{"Esc", "Cancel"},
 		}
 	case "minimize":
@@ -41,6 +43,8 @@ func GetPrefixKeybindings(prefixType string, isDaemonSession ...bool) []Keybindi
 			{"Tab", "Next window"},
 			{"Shift+Tab", "Previous window"},
 			{"t", "Toggle tiling mode"},
+			{"l/L", "Next/previous layout"},
+			{"i/d", "More/fewer master windows"},
 			{"Esc", "Cancel"},
 		}
 	case "debug":
@@ -91,7 +95,12 @@ func GetPrefixKeybindings(prefixType string, isDaemonSession ...bool) []Keybindi
 
 		bindings = append(bindings,
 			Keybinding{"[", "Scrollback mode"},
+			Keybinding{"]", "Paste from register"},
+			Keybinding{"/", "Search all windows"},
 			Keybinding{"s", "Scrollback browser"},
+			Keybinding{"H", "History of closed windows"},
+			Keybinding{":", "Command palette"},
+			Keybinding{"f", "Find window"},
 			Keybinding{"?", "Toggle help"},
 		)
 
@@ -130,6 +139,7 @@ func GetKeybindings(registry *KeybindRegistry) []KeybindingSection {
 	addBinding(&windowMgmt, registry, "restore_all", "Restore all")
 	addBinding(&windowMgmt, registry, "next_window", "Next window")
 	addBinding(&windowMgmt, registry, "prev_window", "Previous window")
+	addBinding(&windowMgmt, registry, "window_switcher", "Window switcher")
 	if len(windowMgmt.Bindings) > 0 {
 		sections = append(sections, windowMgmt)
 	}
@@ -151,6 +161,10 @@ func GetKeybindings(registry *KeybindRegistry) []KeybindingSection {
 		descMove := fmt.Sprintf("Move to workspace %d and follow", i)
 		addBinding(&workspaces, registry, actionMove, descMove)
 	}
+	addBinding(&workspaces, registry, "workspace_picker", "Workspace picker")
+	workspaces.Bindings = append(workspaces.Bindings,
+		Keybinding{"Ctrl+B, w, w", "Workspace picker (prefix)"},
+		Keybinding{"Ctrl+B, w, r", "Rename workspace (prefix)"})
 	if len(workspaces.Bindings) > 0 {
 		sections = append(sections, workspaces)
 	}
@@ -162,7 +176,11 @@ func GetKeybindings(registry *KeybindRegistry) []KeybindingSection {
 	}
 	addBinding(&modes, registry, "enter_terminal_mode", "Insert mode")
 	addBinding(&modes, registry, "toggle_tiling", "Toggle tiling")
+	addBinding(&modes, registry, "next_layout", "Next tiling layout")
+	addBinding(&modes, registry, "prev_layout", "Previous tiling layout")
 	addBinding(&modes, registry, "toggle_help", "Toggle help")
+	addBinding(&modes, registry, "command_palette", "Command palette")
+	addBinding(&modes, registry, "theme_editor", "Theme editor")
 	if len(modes.Bindings) > 0 {
 		sections = append(sections, modes)
 	}
@@ -197,6 +215,7 @@ func getDefaultKeybindings() []KeybindingSection {
 				{"Tab", "Next window"},
 				{"Shift+Tab", "Previous window"},
 				{"1-9", "Select window"},
+				{"g", "Window switcher"},
 			},
 		},
 		{
@@ -206,6 +225,9 @@ func getDefaultKeybindings() []KeybindingSection {
 				{"%s+Shift+1-9", "Move window and follow"}, // %s will be replaced with modifier key
 				{"Ctrl+B, w, 1-9", "Switch workspace (prefix)"},
 				{"Ctrl+B, w, Shift+1-9", "Move window (prefix)"},
+				{"W", "Workspace picker"},
+				{"Ctrl+B, w, w", "Workspace picker (prefix)"},
+				{"Ctrl+B, w, r", "Rename workspace (prefix)"},
 			},
 		},
 		{
@@ -214,6 +236,8 @@ func getDefaultKeybindings() []KeybindingSection {
 				{"i, Enter", "Insert mode"},
 				{"t", "Toggle tiling"},
 				{"?", "Toggle help"},
+				{":", "Command palette"},
+				{"E", "Theme editor"},
 			},
 		},
 	}
@@ -233,9 +257,12 @@ func getStaticHelpSections() []KeybindingSection {
 				{"Shift+K/J, Ctrl+↑/↓", "Swap up/down"},
 				{"< / >", "Resize master width"},
 				{"{ / }", "Resize focused window height"},
+				{"Space / Shift+Space", "Next/previous layout"},
+				{"Shift+I / Shift+D", "More/fewer master windows"},
 				{"Ctrl+B, -", "Split horizontal"},
 				{"Ctrl+B, |/\\", "Split vertical"},
 				{"Ctrl+B, R", "Rotate split"},
+				{"Shift+U / Ctrl+R", "Undo/redo layout change"},
 			},
 		},
 		{
@@ -292,6 +319,11 @@ func getStaticHelpSections() []KeybindingSection {
 				{"d", "Detach (daemon) / Window mode (local)"},
 				{"Esc", "Window management mode"},
 				{"[", "Enter scrollback mode"},
+				{"]", "Paste from register"},
+				{"/", "Search all windows"},
+				{"H", "History of closed windows"},
+				{":", "Command palette"},
+				{"f", "Find window"},
 				{"q", "Quit"},
 				{"Ctrl+B", "Send literal Ctrl+B"},
 			},
@@ -304,6 +336,16 @@ func getStaticHelpSections() []KeybindingSection {
 				{"r", "Rename window"},
 				{"Tab/Shift+Tab", "Next/Previous window"},
 				{"t", "Toggle tiling mode"},
+				{"l/L", "Next/previous layout"},
+				{"i/d", "More/fewer master windows"},
+				{"f", "Toggle floating window"},
+				{"z", "Zoom window over the layout"},
+				{"y", "Toggle sticky window"},
+				{"b/v", "Toggle tabbed/stacked container"},
+				{"o/O", "Next/previous tab in container"},
+				{"a/A", "Join/leave neighbouring container"},
+				{"u/U", "Undo/redo layout change"},
+				{"s/p", "Save/load named layout"},
 			},
 		},
 		{
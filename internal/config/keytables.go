@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Gaurav-Gosain/tuios/internal/tape"
+)
+
+// KeyTable is a user-defined set of key bindings entered with a key after the
+// leader key, like the built-in workspace and window prefixes. Tables nest:
+// a binding can enter another table.
+type KeyTable struct {
+	Key         string            `toml:"key"`         // Key after the leader key that enters the table (empty: only entered from another table)
+	Description string            `toml:"description"` // Shown in the which-key popup (default: the table name)
+	Timeout     int               `toml:"timeout_ms"`  // Milliseconds without a key press before the table is left (0: no timeout)
+	Bindings    map[string]string `toml:"bindings"`    // Key to binding target: an action name, "table:<name>", "tape:<commands>", "run:<command>", "popup:<command>" or "send:<text>"
+}
+
+// TimeoutDuration returns how long the table waits for a key, or 0 if it
+// waits until a key is pressed.
+func (t KeyTable) TimeoutDuration() time.Duration {
+	return time.Duration(max(t.Timeout, 0)) * time.Millisecond
+}
+
+// BindingKind is what a key table or macro binding does.
+type BindingKind int
+
+const (
+	// BindingAction runs a named action
+	BindingAction BindingKind = iota
+	// BindingTable enters a key table
+	BindingTable
+	// BindingTape runs tape commands
+	BindingTape
+	// BindingRun runs a shell command in a new window
+	BindingRun
+	// BindingPopup runs a shell command in a floating window over the
+	// current workspace
+	BindingPopup
+	// BindingSend types text into the focused window
+	BindingSend
+)
+
+// bindingPrefixes maps binding target prefixes to their kinds
+var bindingPrefixes = []struct {
+	prefix string
+	kind   BindingKind
+}{
+	{"table:", BindingTable},
+	{"tape:", BindingTape},
+	{"run:", BindingRun},
+	{"popup:", BindingPopup},
+	{"send:", BindingSend},
+}
+
+// ParseBindingTarget splits a binding target into what it does and its
+// argument: the action name, table name, tape commands, shell command or
+// text to send. Text to send is kept exactly, including trailing new lines.
+func ParseBindingTarget(target string) (BindingKind, string) {
+	trimmed := strings.TrimLeft(target, " \t")
+	for _, p := range bindingPrefixes {
+		if arg, ok := strings.CutPrefix(trimmed, p.prefix); ok {
+			if p.kind == BindingSend {
+				return p.kind, arg
+			}
+			return p.kind, strings.TrimSpace(arg)
+		}
+	}
+	return BindingAction, strings.TrimSpace(target)
+}
+
+// validateBindingTarget checks a binding target that does not depend on the
+// rest of the config. Tape commands are parsed so mistakes show up when the
+// config is loaded rather than when the key is pressed.
+func validateBindingTarget(target string) error {
+	kind, arg := ParseBindingTarget(target)
+	if arg == "" {
+		return fmt.Errorf("binding '%s' has nothing to run", strings.TrimSpace(target))
+	}
+	if kind != BindingTape {
+		return nil
+	}
+	commands, errs := tape.ParseFile(arg)
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid tape commands: %s", strings.Join(errs, "; "))
+	}
+	if len(commands) == 0 {
+		return fmt.Errorf("tape binding has no commands")
+	}
+	return nil
+}
+
+// keyTableTitle returns the name shown for a table.
+func keyTableTitle(name string, table KeyTable) string {
+	if table.Description != "" {
+		return table.Description
+	}
+	return name
+}
+
+// bindingDescription describes a key table binding for the which-key popup.
+func (r *KeybindRegistry) bindingDescription(target string) string {
+	kind, arg := ParseBindingTarget(target)
+	switch kind {
+	case BindingTable:
+		if table, ok := r.config.Keybindings.Tables[arg]; ok {
+			return keyTableTitle(arg, table) + "..."
+		}
+		return arg + "..."
+	case BindingTape:
+		return "Tape: " + firstLine(arg)
+	case BindingRun:
+		return "Run: " + firstLine(arg)
+	case BindingPopup:
+		return "Popup: " + firstLine(arg)
+	case BindingSend:
+		return "Send: " + firstLine(arg)
+	default:
+		if desc, ok := ActionDescriptions[arg]; ok {
+			return desc
+		}
+		return arg
+	}
+}
+
+// firstLine returns the first line of s, marking that more lines follow.
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if first, _, ok := strings.Cut(s, "\n"); ok {
+		return strings.TrimSpace(first) + " ..."
+	}
+	return s
+}
+
+// sortKeybindings sorts bindings by key, ignoring case, so which-key lists
+// are stable.
+func sortKeybindings(bindings []Keybinding) {
+	sort.SliceStable(bindings, func(i, j int) bool {
+		a, b := strings.ToLower(bindings[i].Key), strings.ToLower(bindings[j].Key)
+		if a == b {
+			return bindings[i].Key < bindings[j].Key
+		}
+		return a < b
+	})
+}
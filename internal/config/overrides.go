@@ -1,6 +1,7 @@
 package config
 
 import (
+	"cmp"
 	"log"
 
 	"github.com/Gaurav-Gosain/tuios/internal/theme"
@@ -30,6 +31,9 @@ type Overrides struct {
 	// ScrollbackLines overrides the scrollback buffer size (0 means use default)
 	ScrollbackLines int
 
+	// ScrollbackPersist enables on-disk scrollback history
+	ScrollbackPersist bool
+
 	// NoAnimations disables UI animations
 	NoAnimations bool
 
@@ -37,9 +41,15 @@ type Overrides struct {
 	ThemeName string
 }
 
+// activeOverrides holds the CLI flag overrides from startup, so a reloaded
+// config is applied under the same flags.
+var activeOverrides Overrides
+
 // ApplyOverrides applies CLI flag overrides to global config, falling back to user config defaults.
 // If userConfig is nil, only CLI flag values (when set) are applied.
 func ApplyOverrides(overrides Overrides, userConfig *UserConfig) {
+	activeOverrides = overrides
+
 	// ASCII Only - simple flag override
 	if overrides.ASCIIOnly {
 		UseASCIIOnly = true
@@ -94,6 +104,16 @@ func ApplyOverrides(overrides Overrides, userConfig *UserConfig) {
 		ScrollbackLines = userConfig.Appearance.ScrollbackLines
 	}
 
+	// Scrollback Persist - OR of CLI flag and user config
+	if userConfig != nil {
+		ScrollbackPersist = overrides.ScrollbackPersist || userConfig.Appearance.ScrollbackPersist
+		if userConfig.Appearance.ScrollbackHistoryDir != "" {
+			ScrollbackHistoryDir = userConfig.Appearance.ScrollbackHistoryDir
+		}
+	} else {
+		ScrollbackPersist = overrides.ScrollbackPersist
+	}
+
 	// Leader Key - only from user config
 	if userConfig != nil && userConfig.Keybindings.LeaderKey != "" {
 		LeaderKey = userConfig.Keybindings.LeaderKey
@@ -104,14 +124,52 @@ func ApplyOverrides(overrides Overrides, userConfig *UserConfig) {
 		AnimationsEnabled = false
 	}
 
-	// Theme - CLI flag takes precedence, otherwise use user config
+	// Light and dark themes follow the host terminal, unless a theme is given by flag
+	ThemeLight, ThemeDark, baseTheme = "", "", ""
+	if overrides.ThemeName == "" && userConfig != nil {
+		ThemeLight = userConfig.Appearance.ThemeLight
+		ThemeDark = userConfig.Appearance.ThemeDark
+		baseTheme = userConfig.Appearance.Theme
+	}
+
+	// Theme - CLI flag takes precedence, otherwise use user config. With only
+	// light and dark themes, start dark until the terminal's background is known
 	themeName := overrides.ThemeName
 	if themeName == "" && userConfig != nil && userConfig.Appearance.Theme != "" {
 		themeName = userConfig.Appearance.Theme
 	}
+	if themeName == "" {
+		themeName = cmp.Or(ThemeDark, ThemeLight)
+	}
 	if themeName != "" {
 		if err := theme.Initialize(themeName); err != nil {
 			log.Printf("Warning: Failed to load theme '%s': %v", themeName, err)
 		}
 	}
 }
+
+// ReapplyUserConfig applies the appearance settings of a reloaded config to
+// global config. CLI flag overrides from startup still take precedence, and
+// settings removed from the config go back to their defaults.
+func ReapplyUserConfig(userConfig *UserConfig) {
+	AnimationsEnabled = userConfig.Appearance.AnimationsEnabled == nil || *userConfig.Appearance.AnimationsEnabled
+	WhichKeyEnabled = userConfig.Appearance.WhichKeyEnabled == nil || *userConfig.Appearance.WhichKeyEnabled
+	WhichKeyPosition = "bottom-right"
+	if userConfig.Appearance.WhichKeyPosition != "" {
+		WhichKeyPosition = userConfig.Appearance.WhichKeyPosition
+	}
+	defaults := DefaultConfig().Appearance
+	BorderStyle = defaults.BorderStyle
+	DockbarPosition = defaults.DockbarPosition
+	ScrollbackLines = defaults.ScrollbackLines
+	ScrollbackHistoryDir = ""
+	WindowTitlePosition = "bottom"
+	LeaderKey = "ctrl+b"
+
+	ApplyOverrides(activeOverrides, userConfig)
+
+	// An unset theme turns theming off unless a theme was given by flag
+	if activeOverrides.ThemeName == "" && userConfig.Appearance.Theme == "" && !AutoThemeEnabled() && theme.IsEnabled() {
+		_ = theme.Initialize("")
+	}
+}
@@ -0,0 +1,33 @@
+package config
+
+// PassthroughConfig controls which keys terminal mode hands to the program in
+// the focused window and which TUIOS keeps for itself.
+type PassthroughConfig struct {
+	Navigate map[string]string `toml:"navigate,omitempty"` // Key -> direction (left, right, up, down) moving focus between windows in terminal mode
+	Rules    []PassthroughRule `toml:"rule,omitempty"`     // Per-program overrides, the first matching rule applies
+}
+
+// PassthroughRule changes key handling in terminal mode while the focused
+// window runs a matching program. Matchers that are set must all match.
+type PassthroughRule struct {
+	Command       string   `toml:"command"`        // Name of the foreground process, e.g. nvim
+	AltScreen     bool     `toml:"alt_screen"`     // Only match while the program uses the alternate screen (full-screen apps)
+	DisableLeader bool     `toml:"disable_leader"` // Send the leader key to the program instead of starting a prefix
+	Leader        string   `toml:"leader"`         // Leader key to use instead while the rule matches
+	Keys          []string `toml:"keys"`           // Keys sent to the program even when TUIOS binds them
+}
+
+// navigateDirections are the directions a navigate key can move focus in.
+var navigateDirections = map[string]bool{"left": true, "right": true, "up": true, "down": true}
+
+// Matches reports whether a window whose foreground command and screen are
+// given matches the rule. A rule without matchers never matches.
+func (r *PassthroughRule) Matches(command string, altScreen bool) bool {
+	if r.Command == "" && !r.AltScreen {
+		return false
+	}
+	if r.Command != "" && r.Command != command {
+		return false
+	}
+	return !r.AltScreen || altScreen
+}
@@ -0,0 +1,374 @@
+package config
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// The effective config is config.toml with up to two overlays laid over it:
+// the profile picked with --profile (profiles/<name>.toml next to
+// config.toml) and the .tuios.toml of the project TUIOS is started in. An
+// overlay only sets what it changes: tables are merged key by key, lists of
+// tables such as [[scratchpad]] are added to, and any other value replaces
+// the one below it. A project overlay can run commands through scratchpads,
+// status segments and its startup tape, so it is only used once trusted.
+
+// Profile is the name of the profile laid over config.toml (set by --profile).
+var Profile string
+
+// ProjectConfigName is the file name of a per-project config overlay.
+const ProjectConfigName = ".tuios.toml"
+
+// Kinds of config layers, lowest first.
+const (
+	LayerConfig  = "config"
+	LayerProfile = "profile"
+	LayerProject = "project"
+)
+
+// ConfigLayer is one of the files that make up the effective config.
+type ConfigLayer struct {
+	Kind string
+	Path string
+}
+
+// ConfigSources maps the dotted key of every value set by a config file to
+// that file's path. Values not in the map are defaults.
+type ConfigSources map[string]string
+
+// profileNamePattern matches names that are safe to use as a file name.
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// GetProfilePath returns the file of a named profile.
+func GetProfilePath(name string) (string, error) {
+	if !profileNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid profile name %q", name)
+	}
+	rel := filepath.Join("tuios", "profiles", name+".toml")
+	path, err := xdg.SearchConfigFile(rel)
+	if err != nil {
+		return "", fmt.Errorf("profile %q not found, create it at %s", name, filepath.Join(xdg.ConfigHome, rel))
+	}
+	return path, nil
+}
+
+// FindProjectConfig returns the .tuios.toml in dir or the closest of its
+// parents, or "" if there is none.
+func FindProjectConfig(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, ProjectConfigName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ConfigLayers returns the config files in effect, lowest first. A project
+// overlay that is not trusted is left out and its path returned instead.
+func ConfigLayers() (layers []ConfigLayer, untrusted string, err error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return nil, "", err
+	}
+	layers = []ConfigLayer{{Kind: LayerConfig, Path: configPath}}
+
+	if Profile != "" {
+		path, err := GetProfilePath(Profile)
+		if err != nil {
+			return nil, "", err
+		}
+		layers = append(layers, ConfigLayer{Kind: LayerProfile, Path: path})
+	}
+
+	if dir, err := os.Getwd(); err == nil {
+		if path := FindProjectConfig(dir); path != "" {
+			if IsProjectConfigTrusted(path) {
+				layers = append(layers, ConfigLayer{Kind: LayerProject, Path: path})
+			} else {
+				untrusted = path
+			}
+		}
+	}
+	return layers, untrusted, nil
+}
+
+// LoadConfigLayers merges config files, lowest first, then fills in defaults
+// and validates the result like LoadUserConfigFile. It also reports which
+// file set each value.
+func LoadConfigLayers(layers []ConfigLayer) (*UserConfig, ConfigSources, error) {
+	cfg, validation, sources, err := loadConfigLayers(layers)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validationError(validation); err != nil {
+		return nil, nil, err
+	}
+	return cfg, sources, nil
+}
+
+// loadConfigLayers merges config files, lowest first, into a validated
+// config and the source of each value.
+func loadConfigLayers(layers []ConfigLayer) (*UserConfig, *ValidationResult, ConfigSources, error) {
+	merged := map[string]any{}
+	sources := ConfigSources{}
+	for _, layer := range layers {
+		// #nosec G304 - reading the user's config files is intentional
+		data, err := os.ReadFile(layer.Path)
+		if os.IsNotExist(err) && layer.Kind == LayerConfig {
+			continue
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to read %s: %w", layer.Path, err)
+		}
+		var table map[string]any
+		if err := toml.Unmarshal(data, &table); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to parse %s: %w", layer.Path, err)
+		}
+		mergeConfigTable(merged, table, "", layer.Path, sources)
+	}
+
+	data, err := toml.Marshal(merged)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to merge config files: %w", err)
+	}
+	cfg, validation, err := parseUserConfig(data)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	resolveStartupTape(cfg, sources[startupTapeKey])
+	return cfg, validation, sources, nil
+}
+
+// mergeConfigTable lays src over dst, recording the source of every value
+// it sets under prefix.
+func mergeConfigTable(dst, src map[string]any, prefix, source string, sources ConfigSources) {
+	for key, value := range src {
+		path := joinConfigKey(prefix, key)
+		switch value := value.(type) {
+		case map[string]any:
+			sub, ok := dst[key].(map[string]any)
+			if !ok {
+				sub = map[string]any{}
+				dst[key] = sub
+			}
+			mergeConfigTable(sub, value, path, source, sources)
+		case []any:
+			if !isTableList(value) {
+				dst[key] = value
+				sources[path] = source
+				continue
+			}
+			list, _ := dst[key].([]any)
+			for _, item := range value {
+				table := map[string]any{}
+				mergeConfigTable(table, item.(map[string]any), fmt.Sprintf("%s[%d]", path, len(list)), source, sources)
+				list = append(list, table)
+			}
+			dst[key] = list
+		default:
+			dst[key] = value
+			sources[path] = source
+		}
+	}
+}
+
+// isTableList reports whether a TOML array is a list of tables.
+func isTableList(list []any) bool {
+	if len(list) == 0 {
+		return false
+	}
+	for _, item := range list {
+		if _, ok := item.(map[string]any); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// bareKeyPattern matches keys TOML allows without quotes.
+var bareKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// joinConfigKey appends a key to a dotted key, quoting it when needed.
+func joinConfigKey(prefix, key string) string {
+	if !bareKeyPattern.MatchString(key) {
+		key = fmt.Sprintf("%q", key)
+	}
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// EffectiveValue is a config value with where it came from.
+type EffectiveValue struct {
+	Key    string // Dotted key, such as appearance.theme
+	Value  string // Value in TOML syntax
+	Source string // Path of the file that set it, or "" for a default
+}
+
+// EffectiveValues lists every value of a config in key order, with the file
+// that set it.
+func EffectiveValues(cfg *UserConfig, sources ConfigSources) ([]EffectiveValue, error) {
+	data, err := toml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	var table map[string]any
+	if err := toml.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	var values []EffectiveValue
+	var walk func(prefix string, table map[string]any) error
+	walk = func(prefix string, table map[string]any) error {
+		for key, value := range table {
+			path := joinConfigKey(prefix, key)
+			if sub, ok := value.(map[string]any); ok {
+				if err := walk(path, sub); err != nil {
+					return err
+				}
+				continue
+			}
+			if list, ok := value.([]any); ok && isTableList(list) {
+				for i, item := range list {
+					if err := walk(fmt.Sprintf("%s[%d]", path, i), item.(map[string]any)); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			encoded, err := toml.Marshal(map[string]any{"v": value})
+			if err != nil {
+				return fmt.Errorf("failed to encode %s: %w", path, err)
+			}
+			text := strings.TrimSpace(strings.TrimPrefix(string(encoded), "v = "))
+			values = append(values, EffectiveValue{Key: path, Value: text, Source: sources[path]})
+		}
+		return nil
+	}
+	if err := walk("", table); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(values, func(a, b EffectiveValue) int { return strings.Compare(a.Key, b.Key) })
+	return values, nil
+}
+
+// startupTapeKey is the dotted key of the startup tape.
+const startupTapeKey = "startup.tape"
+
+// resolveStartupTape makes a relative startup tape path relative to the
+// directory of the config file that set it.
+func resolveStartupTape(cfg *UserConfig, source string) {
+	tape := cfg.Startup.Tape
+	if tape == "" || source == "" || filepath.IsAbs(tape) {
+		return
+	}
+	cfg.Startup.Tape = filepath.Join(filepath.Dir(source), tape)
+}
+
+// projectTrustFile returns the file listing trusted project overlays.
+func projectTrustFile() (string, error) {
+	return xdg.DataFile("tuios/trusted_projects")
+}
+
+// IsProjectConfigTrusted reports whether a project overlay was trusted with
+// TrustProjectConfig and has not changed since.
+func IsProjectConfigTrusted(path string) bool {
+	store, err := projectTrustFile()
+	if err != nil {
+		return false
+	}
+	return isProjectTrusted(store, path)
+}
+
+// TrustProjectConfig trusts a project overlay as it is now. Editing the file
+// makes it untrusted again.
+func TrustProjectConfig(path string) error {
+	store, err := projectTrustFile()
+	if err != nil {
+		return fmt.Errorf("could not determine trust file: %w", err)
+	}
+	return trustProject(store, path)
+}
+
+// fileHash returns the SHA-256 of a file's contents.
+func fileHash(path string) (string, error) {
+	// #nosec G304 - hashing the project overlay being trusted is intentional
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// readTrustedProjects reads the trust file: one "<sha256> <path>" per line.
+func readTrustedProjects(store string) map[string]string {
+	trusted := make(map[string]string)
+	f, err := os.Open(store) // #nosec G304 - the trust file is in the data directory
+	if err != nil {
+		return trusted
+	}
+	defer func() { _ = f.Close() }()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if hash, path, ok := strings.Cut(scanner.Text(), " "); ok {
+			trusted[path] = hash
+		}
+	}
+	return trusted
+}
+
+func isProjectTrusted(store, path string) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	hash, err := fileHash(abs)
+	return err == nil && readTrustedProjects(store)[abs] == hash
+}
+
+func trustProject(store, path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	hash, err := fileHash(abs)
+	if err != nil {
+		return fmt.Errorf("failed to read project config: %w", err)
+	}
+	trusted := readTrustedProjects(store)
+	trusted[abs] = hash
+
+	var sb strings.Builder
+	for _, p := range slices.Sorted(maps.Keys(trusted)) {
+		fmt.Fprintf(&sb, "%s %s\n", trusted[p], p)
+	}
+	if err := os.MkdirAll(filepath.Dir(store), 0o700); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := os.WriteFile(store, []byte(sb.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write trust file: %w", err)
+	}
+	return nil
+}
@@ -93,6 +93,152 @@ func (r *KeybindRegistry) GetTapePrefixAction(key string) string {
 	return r.lookupKeyInSection(key, r.config.Keybindings.TapePrefix)
 }
 
+// WorkspaceNames returns the configured workspace names, the first naming
+// workspace 1
+func (r *KeybindRegistry) WorkspaceNames() []string {
+	return r.config.Workspaces.Names
+}
+
+// DynamicWorkspaces reports whether workspaces are created on demand
+func (r *KeybindRegistry) DynamicWorkspaces() bool {
+	return r.config.Workspaces.Dynamic
+}
+
+// Scratchpads returns the configured scratchpads
+func (r *KeybindRegistry) Scratchpads() []ScratchpadConfig {
+	return r.config.Scratchpads
+}
+
+// GetScratchpad returns the scratchpad with the given name
+func (r *KeybindRegistry) GetScratchpad(name string) (ScratchpadConfig, bool) {
+	for _, pad := range r.config.Scratchpads {
+		if pad.Name == name {
+			return pad, true
+		}
+	}
+	return ScratchpadConfig{}, false
+}
+
+// GetScratchpadForKey returns the name of the scratchpad toggled by a key
+func (r *KeybindRegistry) GetScratchpadForKey(key string) string {
+	section := make(map[string][]string)
+	for _, pad := range r.config.Scratchpads {
+		if pad.Key != "" {
+			section[pad.Name] = []string{pad.Key}
+		}
+	}
+	return r.lookupKeyInSection(key, section)
+}
+
+// GetKeyTable returns the user-defined key table with the given name
+func (r *KeybindRegistry) GetKeyTable(name string) (KeyTable, bool) {
+	table, ok := r.config.Keybindings.Tables[name]
+	return table, ok
+}
+
+// GetKeyTableForKey returns the name of the key table entered by a key after
+// the leader key
+func (r *KeybindRegistry) GetKeyTableForKey(key string) string {
+	section := make(map[string][]string)
+	for name, table := range r.config.Keybindings.Tables {
+		if table.Key != "" {
+			section[name] = []string{table.Key}
+		}
+	}
+	return r.lookupKeyInSection(key, section)
+}
+
+// GetKeyTableBinding returns what a key does in a key table: an action
+// name, "table:<name>" or "tape:<commands>". It returns "" for unbound keys.
+func (r *KeybindRegistry) GetKeyTableBinding(name, key string) string {
+	table, ok := r.config.Keybindings.Tables[name]
+	if !ok {
+		return ""
+	}
+	section := make(map[string][]string)
+	for k, target := range table.Bindings {
+		section[target] = append(section[target], k)
+	}
+	return r.lookupKeyInSection(key, section)
+}
+
+// GetMacroForKey returns the binding target of the macro bound to a key, or
+// "" if the key has no macro
+func (r *KeybindRegistry) GetMacroForKey(key string) string {
+	section := make(map[string][]string)
+	for k, target := range r.config.Keybindings.Macros {
+		section[target] = append(section[target], k)
+	}
+	return r.lookupKeyInSection(key, section)
+}
+
+// KeyTableTitle returns the title of a key table for the which-key popup
+func (r *KeybindRegistry) KeyTableTitle(name string) string {
+	return keyTableTitle(name, r.config.Keybindings.Tables[name])
+}
+
+// KeyTableKeybindings returns the bindings of a key table for the which-key
+// popup, sorted by key
+func (r *KeybindRegistry) KeyTableKeybindings(name string) []Keybinding {
+	table := r.config.Keybindings.Tables[name]
+	bindings := make([]Keybinding, 0, len(table.Bindings)+1)
+	for key, target := range table.Bindings {
+		bindings = append(bindings, Keybinding{Key: key, Description: r.bindingDescription(target)})
+	}
+	sortKeybindings(bindings)
+	return append(bindings, Keybinding{Key: "Esc", Description: "Cancel"})
+}
+
+// KeyTableEntries returns the keys that enter key tables after the leader
+// key, for the which-key popup
+func (r *KeybindRegistry) KeyTableEntries() []Keybinding {
+	var bindings []Keybinding
+	for name, table := range r.config.Keybindings.Tables {
+		if table.Key != "" {
+			bindings = append(bindings, Keybinding{Key: table.Key, Description: keyTableTitle(name, table) + "..."})
+		}
+	}
+	sortKeybindings(bindings)
+	return bindings
+}
+
+// WindowRules returns the configured window rules. The rules share storage
+// with the config so their compiled expressions are cached.
+func (r *KeybindRegistry) WindowRules() []WindowRule {
+	return r.config.WindowRules
+}
+
+// PassthroughRules returns the configured per-program passthrough rules
+func (r *KeybindRegistry) PassthroughRules() []PassthroughRule {
+	return r.config.Passthrough.Rules
+}
+
+// StatusBar returns the status bar configuration
+func (r *KeybindRegistry) StatusBar() StatusBarConfig {
+	return r.config.StatusBar
+}
+
+// GetNavigateDirection returns the direction a terminal mode navigate key
+// moves focus in, or "" if the key is not a navigate key
+func (r *KeybindRegistry) GetNavigateDirection(key string) string {
+	section := make(map[string][]string)
+	for k, direction := range r.config.Passthrough.Navigate {
+		section[direction] = append(section[direction], k)
+	}
+	return r.lookupKeyInSection(key, section)
+}
+
+// PassesKey reports whether a passthrough rule sends the key to the program
+func (r *KeybindRegistry) PassesKey(rule *PassthroughRule, key string) bool {
+	return r.lookupKeyInSection(key, map[string][]string{"pass": rule.Keys}) != ""
+}
+
+// IsKey reports whether a key press matches a configured key such as an
+// alternative leader
+func (r *KeybindRegistry) IsKey(key, configured string) bool {
+	return r.lookupKeyInSection(key, map[string][]string{"match": {configured}}) != ""
+}
+
 // lookupKeyInSection looks up a key in a specific config section
 func (r *KeybindRegistry) lookupKeyInSection(key string, section map[string][]string) string {
 	// Build a temporary map for this section
@@ -231,22 +377,24 @@ func (r *KeybindRegistry) IsActionExplicitlyConfigured(action string) bool {
 // ActionDescriptions maps action names to their descriptions for help menu generation.
 var ActionDescriptions = map[string]string{
 	// Window Management
-	"new_window":      "New window",
-	"close_window":    "Close window",
-	"rename_window":   "Rename window",
-	"minimize_window": "Minimize window",
-	"restore_all":     "Restore all minimized",
-	"next_window":     "Next window",
-	"prev_window":     "Previous window",
-	"select_window_1": "Select window 1",
-	"select_window_2": "Select window 2",
-	"select_window_3": "Select window 3",
-	"select_window_4": "Select window 4",
-	"select_window_5": "Select window 5",
-	"select_window_6": "Select window 6",
-	"select_window_7": "Select window 7",
-	"select_window_8": "Select window 8",
-	"select_window_9": "Select window 9",
+	"new_window":       "New window",
+	"close_window":     "Close window",
+	"rename_window":    "Rename window",
+	"minimize_window":  "Minimize window",
+	"restore_all":      "Restore all minimized",
+	"next_window":      "Next window",
+	"prev_window":      "Previous window",
+	"select_window_1":  "Select window 1",
+	"select_window_2":  "Select window 2",
+	"select_window_3":  "Select window 3",
+	"select_window_4":  "Select window 4",
+	"select_window_5":  "Select window 5",
+	"select_window_6":  "Select window 6",
+	"select_window_7":  "Select window 7",
+	"select_window_8":  "Select window 8",
+	"select_window_9":  "Select window 9",
+	"workspace_picker": "Pick a workspace by number or name",
+	"window_switcher":  "Find a window among thumbnails of all windows",
 
 	// Workspaces
 	"switch_workspace_1": "Switch to workspace 1",
@@ -301,14 +449,48 @@ var ActionDescriptions = map[string]string{
 	"preselect_up":     "Preselect up for next window",
 	"preselect_down":   "Preselect down for next window",
 
+	// Layout Engine
+	"next_layout":      "Switch to next tiling layout",
+	"prev_layout":      "Switch to previous tiling layout",
+	"increase_masters": "Add a window to the master area",
+	"decrease_masters": "Remove a window from the master area",
+
+	// Floating
+	"toggle_floating": "Toggle floating for focused window",
+	"toggle_zoom":     "Zoom focused window over the layout",
+	"toggle_sticky":   "Show focused window on every workspace",
+
+	// Containers
+	"toggle_tabbed":  "Toggle tabbed container for focused window",
+	"toggle_stacked": "Toggle stacked container for focused window",
+	"next_tab":       "Focus next window in container",
+	"prev_tab":       "Focus previous window in container",
+	"join_group":     "Move window into neighbouring container",
+	"leave_group":    "Move window out of its container",
+
+	// Layout history
+	"undo_layout": "Undo the last layout change",
+	"redo_layout": "Redo the last undone layout change",
+
+	// Named layouts
+	"save_layout": "Save workspace layout under a name",
+	"load_layout": "Load a saved layout onto the workspace",
+
 	// Mode Control
 	"enter_terminal_mode": "Enter terminal mode",
 	"enter_window_mode":   "Enter window management mode",
 	"toggle_help":         "Toggle help",
+	"command_palette":     "Command palette: run any action or command",
+	"theme_editor":        "Edit the colors of the current theme",
 	"quit":                "Quit",
 
 	// Clipboard
 	"paste_clipboard": "Paste from clipboard",
+	"paste_register":  "Paste from yank register",
+
+	// Search
+	"global_search":   "Search scrollback of all windows",
+	"history_browser": "Browse history of closed windows",
 
 	// System
 	"toggle_logs":        "Toggle log viewer",
@@ -337,6 +519,11 @@ var ActionDescriptions = map[string]string{
 	"prefix_window":           "Enter window prefix",
 	"prefix_detach":           "Detach (daemon: session keeps running)",
 	"prefix_selection":        "Enter copy/scrollback mode",
+	"prefix_paste_register":   "Paste from yank register",
+	"prefix_global_search":    "Search scrollback of all windows",
+	"prefix_history":          "Browse history of closed windows",
+	"prefix_command_palette":  "Open the command palette",
+	"prefix_window_switcher":  "Find a window among thumbnails of all windows",
 	"prefix_help":             "Toggle help",
 	"prefix_logs":             "Toggle log viewer",
 	"prefix_debug":            "Enter debug prefix",
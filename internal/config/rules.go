@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WindowRule places windows automatically when their title or foreground
+// command matches. Matchers that are set must all match; actions that are
+// set are applied once each time a window starts matching.
+type WindowRule struct {
+	Title       string `toml:"title"`        // Regular expression matched against the window title, optionally wrapped in slashes
+	Command     string `toml:"command"`      // Name of the foreground process, e.g. htop
+	Workspace   any    `toml:"workspace"`    // Move the window to this workspace, by number or name
+	Floating    *bool  `toml:"floating"`     // Float the window above the tiling layout, or tile it
+	Width       int    `toml:"width"`        // Width in cells for floating windows
+	Height      int    `toml:"height"`       // Height in cells for floating windows
+	Name        string `toml:"name"`         // Rename the window
+	BorderColor string `toml:"border_color"` // Border color as #rrggbb or an ANSI color number
+
+	titleRe *regexp.Regexp
+}
+
+// WorkspaceTarget returns the workspace the rule moves windows to, as a
+// number or name, or "" if it does not move them.
+func (r *WindowRule) WorkspaceTarget() string {
+	switch ws := r.Workspace.(type) {
+	case string:
+		return strings.TrimSpace(ws)
+	case int64:
+		if ws > 0 {
+			return strconv.FormatInt(ws, 10)
+		}
+	case int:
+		if ws > 0 {
+			return strconv.Itoa(ws)
+		}
+	}
+	return ""
+}
+
+// validateWorkspace checks the rule's workspace number against the
+// workspaces the config allows: the first nine and the named ones, or up to
+// MaxDynamicWorkspaces with dynamic workspaces. Names are resolved when the
+// rule applies, since workspaces can be renamed while running.
+func (r *WindowRule) validateWorkspace(workspaces WorkspacesConfig) error {
+	var n int64
+	switch ws := r.Workspace.(type) {
+	case nil:
+		return nil
+	case string:
+		if strings.TrimSpace(ws) == "" {
+			return fmt.Errorf("Workspace name must not be empty")
+		}
+		return nil
+	case int64:
+		n = ws
+	case int:
+		n = int64(ws)
+	default:
+		return fmt.Errorf("Workspace must be a number or name, got %v", ws)
+	}
+	limit := max(9, len(workspaces.Names))
+	if workspaces.Dynamic {
+		limit = MaxDynamicWorkspaces
+	}
+	if n < 0 || n > int64(limit) {
+		return fmt.Errorf("Workspace must be between 1 and %d, got %d", limit, n)
+	}
+	return nil
+}
+
+// titlePattern returns the title regular expression without the optional
+// surrounding slashes.
+func (r *WindowRule) titlePattern() string {
+	pattern := r.Title
+	if len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		pattern = pattern[1 : len(pattern)-1]
+	}
+	return pattern
+}
+
+// compile compiles the rule's title expression.
+func (r *WindowRule) compile() error {
+	if r.Title == "" || r.titleRe != nil {
+		return nil
+	}
+	re, err := regexp.Compile(r.titlePattern())
+	if err != nil {
+		return err
+	}
+	r.titleRe = re
+	return nil
+}
+
+// Matches reports whether a window with the given title and foreground
+// command matches the rule. A rule without matchers never matches.
+func (r *WindowRule) Matches(title, command string) bool {
+	if r.Title == "" && r.Command == "" {
+		return false
+	}
+	if r.Title != "" {
+		if r.compile() != nil || !r.titleRe.MatchString(title) {
+			return false
+		}
+	}
+	return r.Command == "" || r.Command == command
+}
+
+// borderColorPattern accepts the colors lipgloss understands in configs.
+var borderColorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6}|[0-9]{1,3})$`)
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// StatusBarConfig arranges the dock as a status line of segments in three
+// sections. A section that is not set keeps the default dock layout.
+type StatusBarConfig struct {
+	Left     []string        `toml:"left,omitempty"`    // Segments at the left edge (default: mode, workspace)
+	Center   []string        `toml:"center,omitempty"`  // Segments in the middle (default: windows)
+	Right    []string        `toml:"right,omitempty"`   // Segments at the right edge (default: cpu, ram)
+	Segments []StatusSegment `toml:"segment,omitempty"` // Custom segments filled by running a command
+}
+
+// StatusSegment is a custom status bar segment showing the first line a
+// command prints. The command runs in the background every interval and the
+// last output is shown until the next run finishes.
+type StatusSegment struct {
+	Name     string `toml:"name"`        // Name used in the left, center and right lists
+	Command  string `toml:"command"`     // Shell command to run
+	Interval int    `toml:"interval_ms"` // Milliseconds between runs (default: 5000)
+	Timeout  int    `toml:"timeout_ms"`  // Milliseconds before a run is killed (default: 1000)
+}
+
+// Default status segment timings.
+const (
+	DefaultStatusSegmentInterval = 5 * time.Second
+	DefaultStatusSegmentTimeout  = time.Second
+)
+
+// StatusBuiltinSegments lists the segments TUIOS provides, with a short
+// description each.
+var StatusBuiltinSegments = map[string]string{
+	"mode":       "Mode indicator (window, terminal, copy, tiling)",
+	"workspace":  "Current workspace with window and workspace counts",
+	"windows":    "Minimized windows (center section only)",
+	"cpu":        "CPU usage graph",
+	"ram":        "Memory usage",
+	"clock":      "Current time",
+	"session":    "Name of the attached daemon session",
+	"git_branch": "Git branch of the focused window's working directory",
+	"battery":    "Battery charge (Linux only)",
+	"load":       "One minute load average",
+	"hostname":   "Host name",
+	"prefix":     "Shown while the leader key is pending",
+	"recording":  "Shown while a tape is being recorded",
+}
+
+// Default status bar sections, matching the fixed dock layout.
+var (
+	defaultStatusLeft   = []string{"mode", "workspace"}
+	defaultStatusCenter = []string{"windows"}
+	defaultStatusRight  = []string{"cpu", "ram"}
+)
+
+// Sections returns the segments of the left, center and right sections,
+// using the default layout for sections that are not set.
+func (c StatusBarConfig) Sections() (left, center, right []string) {
+	left, center, right = c.Left, c.Center, c.Right
+	if left == nil {
+		left = defaultStatusLeft
+	}
+	if center == nil {
+		center = defaultStatusCenter
+	}
+	if right == nil {
+		right = defaultStatusRight
+	}
+	return left, center, right
+}
+
+// Segment returns the custom segment with the given name.
+func (c StatusBarConfig) Segment(name string) (StatusSegment, bool) {
+	for _, seg := range c.Segments {
+		if seg.Name == name {
+			return seg, true
+		}
+	}
+	return StatusSegment{}, false
+}
+
+// IntervalDuration returns how often the segment's command runs.
+func (s StatusSegment) IntervalDuration() time.Duration {
+	if s.Interval <= 0 {
+		return DefaultStatusSegmentInterval
+	}
+	return time.Duration(s.Interval) * time.Millisecond
+}
+
+// TimeoutDuration returns how long a run of the segment's command may take.
+func (s StatusSegment) TimeoutDuration() time.Duration {
+	if s.Timeout <= 0 {
+		return DefaultStatusSegmentTimeout
+	}
+	return time.Duration(s.Timeout) * time.Millisecond
+}
+
+// validateStatusBar checks segment names and custom segment definitions.
+func validateStatusBar(cfg *StatusBarConfig) []ValidationError {
+	var errs []ValidationError
+	addError := func(field, key, message string) {
+		errs = append(errs, ValidationError{Field: field, Key: key, Message: message})
+	}
+
+	custom := make(map[string]bool)
+	for i, seg := range cfg.Segments {
+		field := fmt.Sprintf("status_bar.segment.%d", i)
+		switch {
+		case strings.TrimSpace(seg.Name) == "":
+			addError(field, "name", "Segment has no name")
+		case StatusBuiltinSegments[seg.Name] != "":
+			addError(field, seg.Name, fmt.Sprintf("Segment '%s' is a built-in segment", seg.Name))
+		case custom[seg.Name]:
+			addError(field, seg.Name, fmt.Sprintf("Segment '%s' is defined more than once", seg.Name))
+		}
+		custom[seg.Name] = true
+		if strings.TrimSpace(seg.Command) == "" {
+			addError(field, "command", fmt.Sprintf("Segment '%s' has no command", seg.Name))
+		}
+		if seg.Interval < 0 || seg.Timeout < 0 {
+			addError(field, "interval_ms", "Interval and timeout must not be negative")
+		}
+	}
+
+	sections := map[string][]string{"left": cfg.Left, "center": cfg.Center, "right": cfg.Right}
+	for _, section := range slices.Sorted(maps.Keys(sections)) {
+		field := "status_bar." + section
+		for _, name := range sections[section] {
+			switch {
+			case name == "windows" && section != "center":
+				addError(field, name, "The windows segment can only be in the center section")
+			case StatusBuiltinSegments[name] == "" && !custom[name]:
+				addError(field, name, fmt.Sprintf("Unknown segment '%s'", name))
+			}
+		}
+	}
+	return errs
+}
@@ -14,9 +14,38 @@ import (
 
 // UserConfig represents the user's custom configuration
 type UserConfig struct {
-	Appearance  AppearanceConfig  `toml:"appearance"`
-	Keybindings KeybindingsConfig `toml:"keybindings"`
-	Daemon      DaemonConfig      `toml:"daemon"`
+	Appearance  AppearanceConfig   `toml:"appearance"`
+	Keybindings KeybindingsConfig  `toml:"keybindings"`
+	Daemon      DaemonConfig       `toml:"daemon"`
+	Workspaces  WorkspacesConfig   `toml:"workspaces"`
+	Scratchpads []ScratchpadConfig `toml:"scratchpad,omitempty"`
+	WindowRules []WindowRule       `toml:"rule,omitempty"`
+	Passthrough PassthroughConfig  `toml:"passthrough"`
+	StatusBar   StatusBarConfig    `toml:"status_bar"`
+	Startup     StartupConfig      `toml:"startup"`
+}
+
+// StartupConfig sets up the first workspace when TUIOS starts. It is mostly
+// useful in a profile or project config.
+type StartupConfig struct {
+	Layout string `toml:"layout,omitempty"` // Tiling layout for every workspace; tiling is turned on when set
+	Tape   string `toml:"tape,omitempty"`   // Tape script to play once started, relative to the config file setting it
+}
+
+// WorkspacesConfig names workspaces and controls how many there are.
+type WorkspacesConfig struct {
+	Names   []string `toml:"names,omitempty"` // Workspace names by number: the first names workspace 1 (empty: unnamed)
+	Dynamic bool     `toml:"dynamic"`         // Create workspaces on demand beyond the configured ones (default: false)
+}
+
+// ScratchpadConfig defines a named scratchpad: a persistent window that is
+// shown floating over the current workspace and hidden again by its key.
+type ScratchpadConfig struct {
+	Name    string  `toml:"name"`    // Unique scratchpad name
+	Command string  `toml:"command"` // Command run in the scratchpad's shell when first opened (empty: plain shell)
+	Key     string  `toml:"key"`     // Key that toggles the scratchpad in window and terminal mode
+	Width   float64 `toml:"width"`   // Fraction of the usable width, 0 < width <= 1 (default: 0.8)
+	Height  float64 `toml:"height"`  // Fraction of the usable height, 0 < height <= 1 (default: 0.8)
 }
 
 // DaemonConfig holds daemon-related settings
@@ -28,17 +57,21 @@ type DaemonConfig struct {
 
 // AppearanceConfig holds appearance-related settings
 type AppearanceConfig struct {
-	BorderStyle         string `toml:"border_style"`          // Border style: rounded, normal, thick, double, hidden, block, ascii, outer-half-block, inner-half-block (borderless mode not yet implemented)
-	HideWindowButtons   bool   `toml:"hide_window_buttons"`   // Hide window control buttons (minimize, maximize, close)
-	ScrollbackLines     int    `toml:"scrollback_lines"`      // Number of lines to keep in scrollback buffer (default: 10000, min: 100, max: 1000000)
-	DockbarPosition     string `toml:"dockbar_position"`      // Dockbar position: bottom, top, hidden
-	PreferredShell      string `toml:"preferred_shell"`       // Preferred shell: if empty, auto-detect based on platform.
-	AnimationsEnabled   *bool  `toml:"animations_enabled"`    // Enable UI animations (default: true). Set to false for instant transitions.
-	WhichKeyEnabled     *bool  `toml:"whichkey_enabled"`      // Show which-key popup after pressing leader key (default: true)
-	WhichKeyPosition    string `toml:"whichkey_position"`     // Which-key popup position: bottom-right, bottom-left, top-right, top-left, center (default: bottom-right)
-	WindowTitlePosition string `toml:"window_title_position"` // Window title position: bottom, top, hidden (default: bottom). Shows CustomName if set, else terminal title.
-	HideClock           bool   `toml:"hide_clock"`            // Hide the clock overlay (default: false)
-	Theme               string `toml:"theme"`                 // Color theme name (e.g., dracula, nord, my-custom-theme)
+	BorderStyle          string `toml:"border_style"`           // Border style: rounded, normal, thick, double, hidden, block, ascii, outer-half-block, inner-half-block (borderless mode not yet implemented)
+	HideWindowButtons    bool   `toml:"hide_window_buttons"`    // Hide window control buttons (minimize, maximize, close)
+	ScrollbackLines      int    `toml:"scrollback_lines"`       // Number of lines to keep in scrollback buffer (default: 10000, min: 100, max: 1000000)
+	ScrollbackPersist    bool   `toml:"scrollback_persist"`     // Spill trimmed scrollback to compressed per-window history files on disk (default: false)
+	ScrollbackHistoryDir string `toml:"scrollback_history_dir"` // Directory for history files (default: $XDG_STATE_HOME/tuios/history)
+	DockbarPosition      string `toml:"dockbar_position"`       // Dockbar position: bottom, top, hidden
+	PreferredShell       string `toml:"preferred_shell"`        // Preferred shell: if empty, auto-detect based on platform.
+	AnimationsEnabled    *bool  `toml:"animations_enabled"`     // Enable UI animations (default: true). Set to false for instant transitions.
+	WhichKeyEnabled      *bool  `toml:"whichkey_enabled"`       // Show which-key popup after pressing leader key (default: true)
+	WhichKeyPosition     string `toml:"whichkey_position"`      // Which-key popup position: bottom-right, bottom-left, top-right, top-left, center (default: bottom-right)
+	WindowTitlePosition  string `toml:"window_title_position"`  // Window title position: bottom, top, hidden (default: bottom). Shows CustomName if set, else terminal title.
+	HideClock            bool   `toml:"hide_clock"`             // Hide the clock overlay (default: false)
+	Theme                string `toml:"theme"`                  // Color theme name (e.g., dracula, nord, my-custom-theme)
+	ThemeLight           string `toml:"theme_light"`            // Theme used when the host terminal has a light background
+	ThemeDark            string `toml:"theme_dark"`             // Theme used when the host terminal has a dark background
 }
 
 // KeybindingsConfig holds all keybinding configurations
@@ -57,7 +90,9 @@ type KeybindingsConfig struct {
 	WorkspacePrefix  map[string][]string `toml:"workspace_prefix"`
 	DebugPrefix      map[string][]string `toml:"debug_prefix"`
 	TapePrefix       map[string][]string `toml:"tape_prefix"`
-	TerminalMode     map[string][]string `toml:"terminal_mode"` // Direct keybinds in terminal mode (no prefix required)
+	TerminalMode     map[string][]string `toml:"terminal_mode"`    // Direct keybinds in terminal mode (no prefix required)
+	Tables           map[string]KeyTable `toml:"tables,omitempty"` // User-defined key tables entered after the leader key
+	Macros           map[string]string   `toml:"macros,omitempty"` // Key to binding target, like a key table binding, in window and terminal mode
 }
 
 // DefaultConfig returns the default configuration
@@ -78,22 +113,24 @@ func DefaultConfig() *UserConfig {
 		Keybindings: KeybindingsConfig{
 			LeaderKey: "ctrl+b",
 			WindowManagement: map[string][]string{
-				"new_window":      {"n"},
-				"close_window":    {"w", "x"},
-				"rename_window":   {"r"},
-				"minimize_window": {"m"},
-				"restore_all":     {"M"},
-				"next_window":     {"tab"},
-				"prev_window":     {"shift+tab"},
-				"select_window_1": {"1"},
-				"select_window_2": {"2"},
-				"select_window_3": {"3"},
-				"select_window_4": {"4"},
-				"select_window_5": {"5"},
-				"select_window_6": {"6"},
-				"select_window_7": {"7"},
-				"select_window_8": {"8"},
-				"select_window_9": {"9"},
+				"new_window":       {"n"},
+				"close_window":     {"w", "x"},
+				"rename_window":    {"r"},
+				"minimize_window":  {"m"},
+				"restore_all":      {"M"},
+				"next_window":      {"tab"},
+				"prev_window":      {"shift+tab"},
+				"select_window_1":  {"1"},
+				"select_window_2":  {"2"},
+				"select_window_3":  {"3"},
+				"select_window_4":  {"4"},
+				"select_window_5":  {"5"},
+				"select_window_6":  {"6"},
+				"select_window_7":  {"7"},
+				"select_window_8":  {"8"},
+				"select_window_9":  {"9"},
+				"workspace_picker": {"W"},
+				"window_switcher":  {"g"},
 			},
 			Workspaces: getDefaultWorkspaceKeybinds(),
 			Layout:     getDefaultLayoutKeybinds(),
@@ -101,6 +138,8 @@ func DefaultConfig() *UserConfig {
 				"enter_terminal_mode": {"i", "enter"},
 				"enter_window_mode":   {"esc"},
 				"toggle_help":         {"?"},
+				"command_palette":     {":"},
+				"theme_editor":        {"E"},
 				"quit":                {"q"},
 			},
 			System: map[string][]string{
@@ -150,6 +189,11 @@ func DefaultConfig() *UserConfig {
 				"prefix_window":           {"t"},
 				"prefix_detach":           {"d", "esc"},
 				"prefix_selection":        {"["},
+				"prefix_paste_register":   {"]"},
+				"prefix_global_search":    {"/"},
+				"prefix_history":          {"H"},
+				"prefix_command_palette":  {":"},
+				"prefix_window_switcher":  {"f"},
 				"prefix_help":             {"?"},
 				"prefix_debug":            {"D"},
 				"prefix_tape":             {"T"},
@@ -162,13 +206,30 @@ func DefaultConfig() *UserConfig {
 			"prefix_scrollback":       {"s"},
 			},
 			WindowPrefix: map[string][]string{
-				"window_prefix_new":    {"n"},
-				"window_prefix_close":  {"x"},
-				"window_prefix_rename": {"r"},
-				"window_prefix_next":   {"tab"},
-				"window_prefix_prev":   {"shift+tab"},
-				"window_prefix_tiling": {"t"},
-				"window_prefix_cancel": {"esc"},
+				"window_prefix_new":         {"n"},
+				"window_prefix_close":       {"x"},
+				"window_prefix_rename":      {"r"},
+				"window_prefix_next":        {"tab"},
+				"window_prefix_prev":        {"shift+tab"},
+				"window_prefix_tiling":      {"t"},
+				"window_prefix_next_layout": {"l"},
+				"window_prefix_prev_layout": {"L"},
+				"window_prefix_inc_masters": {"i"},
+				"window_prefix_dec_masters": {"d"},
+				"window_prefix_floating":    {"f"},
+				"window_prefix_zoom":        {"z"},
+				"window_prefix_sticky":      {"y"},
+				"window_prefix_tabbed":      {"b"},
+				"window_prefix_stacked":     {"v"},
+				"window_prefix_next_tab":    {"o"},
+				"window_prefix_prev_tab":    {"O"},
+				"window_prefix_join_group":  {"a"},
+				"window_prefix_leave_group": {"A"},
+				"window_prefix_undo_layout": {"u"},
+				"window_prefix_redo_layout": {"U"},
+				"window_prefix_save_layout": {"s"},
+				"window_prefix_load_layout": {"p"},
+				"window_prefix_cancel":      {"esc"},
 			},
 			MinimizePrefix: map[string][]string{
 				"minimize_prefix_focused":     {"m"},
@@ -203,6 +264,8 @@ func DefaultConfig() *UserConfig {
 				"workspace_prefix_move_7":   {"&"},
 				"workspace_prefix_move_8":   {"*"},
 				"workspace_prefix_move_9":   {"("},
+				"workspace_prefix_picker":   {"w"},
+				"workspace_prefix_rename":   {"r"},
 				"workspace_prefix_cancel":   {"esc"},
 			},
 			DebugPrefix: map[string][]string{
@@ -326,6 +389,28 @@ func getDefaultLayoutKeybinds() map[string][]string {
 		"split_vertical":   {"|", "\\"},
 		"rotate_split":     {"R"},
 		"equalize_splits":  {"="},
+		// Layout engine
+		"next_layout":      {"space"},
+		"prev_layout":      {"shift+space"},
+		"increase_masters": {"I"},
+		"decrease_masters": {"D"},
+		// Floating windows
+		"toggle_floating": {"F"},
+		"toggle_zoom":     {"z"},
+		"toggle_sticky":   {"y"},
+		// Tabbed and stacked containers
+		"toggle_tabbed":  {"b"},
+		"toggle_stacked": {"v"},
+		"next_tab":       {"o"},
+		"prev_tab":       {"O"},
+		"join_group":     {"a"},
+		"leave_group":    {"A"},
+		// Layout history
+		"undo_layout": {"U"},
+		"redo_layout": {"ctrl+r"},
+		// Named layouts
+		"save_layout": {"S"},
+		"load_layout": {"P"},
 	}
 
 	// Add platform-specific BSP preselect bindings
@@ -355,35 +440,31 @@ func isMacOS() bool {
 		strings.Contains(strings.ToLower(os.Getenv("OSTYPE")), "darwin")
 }
 
-// LoadUserConfig loads the user configuration from XDG config directory
+// LoadUserConfig loads the user configuration from XDG config directory,
+// with the selected profile and a trusted project config laid over it
 func LoadUserConfig() (*UserConfig, error) {
-	// Try to find existing config file
-	configPath, err := xdg.SearchConfigFile("tuios/config.toml")
+	layers, untrusted, err := ConfigLayers()
 	if err != nil {
-		// Config doesn't exist, create default
-		return createDefaultConfig()
+		return nil, err
 	}
-
-	// Read and parse config file
-	// #nosec G304 - configPath is from XDG search, reading user config is intentional
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+	if untrusted != "" {
+		fmt.Fprintf(os.Stderr, "Ignoring untrusted project config %s, run 'tuios config trust' to use it\n", untrusted)
 	}
 
-	var cfg UserConfig
-	if err := toml.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	// Try to find existing config file
+	if _, err := xdg.SearchConfigFile("tuios/config.toml"); err != nil {
+		// Config doesn't exist, create default
+		cfg, err := createDefaultConfig()
+		if err != nil || len(layers) == 1 {
+			return cfg, err
+		}
 	}
 
-	// Validate and fill in missing sections with defaults
-	defaultCfg := DefaultConfig()
-	fillMissingAppearance(&cfg, defaultCfg)
-	fillMissingDaemon(&cfg, defaultCfg)
-	fillMissingKeybinds(&cfg, defaultCfg)
-
-	// Validate configuration
-	validation := ValidateConfig(&cfg)
+	cfg, validation, _, err := loadConfigLayers(layers)
+	if err != nil {
+		return nil, err
+	}
+	applyAppearanceGlobals(cfg)
 	if validation.HasErrors() {
 		// Log all errors
 		for _, err := range validation.Errors {
@@ -399,7 +480,68 @@ func LoadUserConfig() (*UserConfig, error) {
 		}
 	}
 
-	return &cfg, nil
+	return cfg, nil
+}
+
+// LoadUserConfigFile loads and validates the config file at path without
+// printing anything or creating a default file. It is used to reload the
+// config while TUIOS is running, so validation errors are returned in the
+// error instead of being written to stderr.
+func LoadUserConfigFile(path string) (*UserConfig, error) {
+	cfg, validation, err := readUserConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := validationError(validation); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// validationError returns the first validation error, or nil.
+func validationError(validation *ValidationResult) error {
+	if !validation.HasErrors() {
+		return nil
+	}
+	first := validation.Errors[0]
+	msg := fmt.Sprintf("[%s] %s: %s", first.Field, first.Key, first.Message)
+	if more := len(validation.Errors) - 1; more > 0 {
+		msg += fmt.Sprintf(" (and %d more)", more)
+	}
+	return fmt.Errorf("config error %s", msg)
+}
+
+// readUserConfig parses the config file at path, fills in missing sections
+// with defaults and validates the result.
+func readUserConfig(path string) (*UserConfig, *ValidationResult, error) {
+	// #nosec G304 - path is the user's config file, reading it is intentional
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	cfg, validation, err := parseUserConfig(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolveStartupTape(cfg, path)
+	return cfg, validation, nil
+}
+
+// parseUserConfig parses a config, fills in missing sections with defaults
+// and validates the result.
+func parseUserConfig(data []byte) (*UserConfig, *ValidationResult, error) {
+	var cfg UserConfig
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	// Fill in missing sections with defaults
+	defaultCfg := DefaultConfig()
+	fillMissingAppearance(&cfg, defaultCfg)
+	fillMissingDaemon(&cfg, defaultCfg)
+	fillMissingKeybinds(&cfg, defaultCfg)
+
+	return &cfg, ValidateConfig(&cfg), nil
 }
 
 // createDefaultConfig creates a default config file in the user's config directory
@@ -452,6 +594,15 @@ func createDefaultConfig() (*UserConfig, error) {
 	sb.WriteString("#   Range: 100 to 1000000\n")
 	sb.WriteString("#   Default: 10000\n")
 	sb.WriteString("#\n")
+	sb.WriteString("# scrollback_persist: Keep unlimited history by spilling lines trimmed from the\n")
+	sb.WriteString("#   scrollback buffer to a compressed file per window. Closed windows' history\n")
+	sb.WriteString("#   can be reopened read-only with the history browser (leader + H).\n")
+	sb.WriteString("#   Options: true, false\n")
+	sb.WriteString("#   Default: false\n")
+	sb.WriteString("#\n")
+	sb.WriteString("# scrollback_history_dir: Directory for history files\n")
+	sb.WriteString("#   Default: $XDG_STATE_HOME/tuios/history\n")
+	sb.WriteString("#\n")
 	sb.WriteString("# theme: Color theme name (e.g., dracula, nord, my-custom-theme)\n")
 	sb.WriteString("#   Leave empty to use standard terminal colors.\n")
 	sb.WriteString("#   CLI flag --theme overrides this. Custom themes: ~/.config/tuios/themes/*.json\n")
@@ -491,7 +642,11 @@ func fillMissingAppearance(cfg, defaultCfg *UserConfig) {
 	} else if cfg.Appearance.ScrollbackLines > 1000000 {
 		cfg.Appearance.ScrollbackLines = 1000000
 	}
+}
 
+// applyAppearanceGlobals sets the global appearance settings that are only
+// configured in the config file at startup
+func applyAppearanceGlobals(cfg *UserConfig) {
 	// AnimationsEnabled defaults to true (nil means use default)
 	// Only set global if explicitly configured
 	if cfg.Appearance.AnimationsEnabled != nil {
@@ -509,16 +664,25 @@ func fillMissingAppearance(cfg, defaultCfg *UserConfig) {
 	}
 
 	// WindowTitlePosition defaults to bottom
-	// Only apply from config if not already set via flag (run.go sets this before fillMissingAppearance is called)
+	// Only apply from config if not already set via flag (run.go sets this before applyAppearanceGlobals is called)
 	if cfg.Appearance.WindowTitlePosition != "" && WindowTitlePosition == "bottom" {
 		WindowTitlePosition = cfg.Appearance.WindowTitlePosition
 	}
 
 	// HideClock defaults to false
-	// Only apply from config if not already set via flag (run.go sets this before fillMissingAppearance is called)
+	// Only apply from config if not already set via flag (run.go sets this before applyAppearanceGlobals is called)
 	if !HideClock {
 		HideClock = cfg.Appearance.HideClock
 	}
+
+	// ScrollbackPersist defaults to false
+	// Only apply from config if not already set via flag
+	if !ScrollbackPersist {
+		ScrollbackPersist = cfg.Appearance.ScrollbackPersist
+	}
+	if cfg.Appearance.ScrollbackHistoryDir != "" {
+		ScrollbackHistoryDir = cfg.Appearance.ScrollbackHistoryDir
+	}
 }
 
 // fillMissingDaemon fills in any missing daemon settings with defaults
@@ -617,3 +781,24 @@ func GetConfigPath() (string, error) {
 	}
 	return path, nil
 }
+
+// GetHistoryDirectory returns the directory for on-disk scrollback history files.
+// It honors appearance.scrollback_history_dir, defaulting to $XDG_STATE_HOME/tuios/history.
+func GetHistoryDirectory() string {
+	if ScrollbackHistoryDir != "" {
+		return ScrollbackHistoryDir
+	}
+	return filepath.Join(xdg.StateHome, "tuios", "history")
+}
+
+// GetExportDirectory returns the directory where history exports started
+// from the scrollback browser are written ($XDG_DATA_HOME/tuios/exports).
+func GetExportDirectory() string {
+	return filepath.Join(xdg.DataHome, "tuios", "exports")
+}
+
+// GetLayoutDirectory returns the directory where named layouts saved with
+// SaveLayout are stored ($XDG_DATA_HOME/tuios/layouts).
+func GetLayoutDirectory() string {
+	return filepath.Join(xdg.DataHome, "tuios", "layouts")
+}
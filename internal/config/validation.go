@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -100,6 +101,201 @@ func ValidateConfig(cfg *UserConfig) *ValidationResult {
 		})
 	}
 
+	// Validate workspace names
+	if len(cfg.Workspaces.Names) > MaxDynamicWorkspaces {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "workspaces.names",
+			Key:     "names",
+			Message: fmt.Sprintf("At most %d workspaces can be named, got %d", MaxDynamicWorkspaces, len(cfg.Workspaces.Names)),
+		})
+	}
+	workspaceNames := make(map[string]bool)
+	for i, name := range cfg.Workspaces.Names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(name); err == nil {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   "workspaces.names",
+				Key:     name,
+				Message: fmt.Sprintf("Workspace %d name '%s' is a number and would hide workspace %s", i+1, name, name),
+			})
+		} else if workspaceNames[strings.ToLower(name)] {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   "workspaces.names",
+				Key:     name,
+				Message: fmt.Sprintf("Workspace name '%s' is used more than once", name),
+			})
+		}
+		workspaceNames[strings.ToLower(name)] = true
+	}
+
+	// Validate key table and macro bindings: keys, and that their targets
+	// exist or parse
+	validateBindings := func(field string, bindings map[string]string) {
+		for key, target := range bindings {
+			if valid, errMsg := normalizer.ValidateKey(key); !valid {
+				result.Errors = append(result.Errors, ValidationError{Field: field, Key: key, Message: errMsg})
+			}
+			if err := validateBindingTarget(target); err != nil {
+				result.Errors = append(result.Errors, ValidationError{Field: field, Key: key, Message: err.Error()})
+				continue
+			}
+			switch kind, arg := ParseBindingTarget(target); kind {
+			case BindingTable:
+				if _, ok := cfg.Keybindings.Tables[arg]; !ok {
+					result.Errors = append(result.Errors, ValidationError{
+						Field:   field,
+						Key:     key,
+						Message: fmt.Sprintf("Key table '%s' is not defined", arg),
+					})
+				}
+			case BindingAction:
+				if _, ok := ActionDescriptions[arg]; !ok {
+					result.Warnings = append(result.Warnings, ValidationError{
+						Field:   field,
+						Key:     key,
+						Message: fmt.Sprintf("Unknown action '%s'", arg),
+					})
+				}
+			}
+		}
+	}
+
+	// Validate key tables
+	for name, table := range cfg.Keybindings.Tables {
+		field := "keybindings.tables." + name
+		addError := func(key, message string) {
+			result.Errors = append(result.Errors, ValidationError{Field: field, Key: key, Message: message})
+		}
+		if table.Key != "" {
+			if valid, errMsg := normalizer.ValidateKey(table.Key); !valid {
+				addError("key", errMsg)
+			}
+		}
+		if table.Timeout < 0 {
+			addError("timeout_ms", fmt.Sprintf("Timeout must not be negative, got %d", table.Timeout))
+		}
+		validateBindings(field, table.Bindings)
+	}
+	validateBindings("keybindings.macros", cfg.Keybindings.Macros)
+
+	// Validate scratchpads
+	names := make(map[string]bool)
+	for i, pad := range cfg.Scratchpads {
+		field := fmt.Sprintf("scratchpad.%d", i)
+		if strings.TrimSpace(pad.Name) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Key:     "name",
+				Message: "Scratchpad has no name",
+			})
+		} else if names[pad.Name] {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Key:     pad.Name,
+				Message: fmt.Sprintf("Scratchpad '%s' is defined more than once", pad.Name),
+			})
+		}
+		names[pad.Name] = true
+
+		if pad.Key != "" {
+			if valid, errMsg := normalizer.ValidateKey(pad.Key); !valid {
+				result.Errors = append(result.Errors, ValidationError{
+					Field:   field,
+					Key:     pad.Key,
+					Message: errMsg,
+				})
+			}
+		}
+		checkFraction := func(key string, frac float64) {
+			if frac < 0 || frac > 1 {
+				result.Errors = append(result.Errors, ValidationError{
+					Field:   field,
+					Key:     key,
+					Message: fmt.Sprintf("Scratchpad '%s' %s must be between 0 and 1, got %g", pad.Name, key, frac),
+				})
+			}
+		}
+		checkFraction("width", pad.Width)
+		checkFraction("height", pad.Height)
+	}
+
+	// Validate window rules
+	for i := range cfg.WindowRules {
+		rule := &cfg.WindowRules[i]
+		field := fmt.Sprintf("rule.%d", i)
+		addError := func(key, message string) {
+			result.Errors = append(result.Errors, ValidationError{Field: field, Key: key, Message: message})
+		}
+
+		if rule.Title == "" && rule.Command == "" {
+			addError("title", "Rule needs a title or command to match")
+		}
+		if err := rule.compile(); err != nil {
+			addError("title", fmt.Sprintf("Invalid title expression '%s': %v", rule.Title, err))
+		}
+		if err := rule.validateWorkspace(cfg.Workspaces); err != nil {
+			addError("workspace", err.Error())
+		}
+		if rule.Width < 0 || rule.Height < 0 {
+			addError("width", "Width and height must not be negative")
+		}
+		if rule.BorderColor != "" && !borderColorPattern.MatchString(rule.BorderColor) {
+			addError("border_color", fmt.Sprintf("Invalid color '%s', use #rrggbb or an ANSI color number", rule.BorderColor))
+		}
+		if rule.WorkspaceTarget() == "" && rule.Floating == nil && rule.Width == 0 && rule.Height == 0 &&
+			rule.Name == "" && rule.BorderColor == "" {
+			result.Warnings = append(result.Warnings, ValidationError{
+				Field:   field,
+				Key:     rule.Title + rule.Command,
+				Message: "Rule has no actions",
+			})
+		}
+	}
+
+	// Validate key passthrough
+	for key, direction := range cfg.Passthrough.Navigate {
+		if valid, errMsg := normalizer.ValidateKey(key); !valid {
+			result.Errors = append(result.Errors, ValidationError{Field: "passthrough.navigate", Key: key, Message: errMsg})
+		}
+		if !navigateDirections[direction] {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   "passthrough.navigate",
+				Key:     key,
+				Message: fmt.Sprintf("Invalid direction '%s' (use: left, right, up, down)", direction),
+			})
+		}
+	}
+	for i := range cfg.Passthrough.Rules {
+		rule := &cfg.Passthrough.Rules[i]
+		field := fmt.Sprintf("passthrough.rule.%d", i)
+		addError := func(key, message string) {
+			result.Errors = append(result.Errors, ValidationError{Field: field, Key: key, Message: message})
+		}
+
+		if rule.Command == "" && !rule.AltScreen {
+			addError("command", "Rule needs a command or alt_screen to match")
+		}
+		if rule.DisableLeader && rule.Leader != "" {
+			addError("leader", "Rule cannot both disable the leader and set another one")
+		}
+		if rule.Leader != "" {
+			if valid, errMsg := normalizer.ValidateKey(rule.Leader); !valid {
+				addError("leader", errMsg)
+			}
+		}
+		for _, key := range rule.Keys {
+			if valid, errMsg := normalizer.ValidateKey(key); !valid {
+				addError(key, errMsg)
+			}
+		}
+	}
+
+	// Validate status bar segments
+	result.Errors = append(result.Errors, validateStatusBar(&cfg.StatusBar)...)
+
 	// Check for essential actions that should have keybindings
 	essentialActions := map[string]string{
 		"new_window":          "window_management",
@@ -167,7 +363,7 @@ func findConflicts(cfg *UserConfig, normalizer *KeyNormalizer) map[string][]stri
 	}
 
 	selectionModeActions := []string{
-		"toggle_selection", "toggle_selection_term", "copy_selection", "paste_clipboard", "clear_selection",
+		"toggle_selection", "toggle_selection_term", "copy_selection", "paste_clipboard", "paste_register", "clear_selection",
 	}
 
 	// Create sets for quick lookup
@@ -1,6 +1,8 @@
 package input
 
 import (
+	"fmt"
+	"sort"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
@@ -48,6 +50,10 @@ func (d *ActionDispatcher) registerHandlers() {
 		d.Register("switch_workspace_"+string(rune('0'+i)), makeSwitchWorkspaceHandler(i))
 		d.Register("move_and_follow_"+string(rune('0'+i)), makeMoveAndFollowHandler(i))
 	}
+	d.Register("workspace_picker", handleWorkspacePicker)
+	d.Register("command_palette", d.handleCommandPalette)
+	d.Register("window_switcher", handleWindowSwitcher)
+	d.Register("theme_editor", handleThemeEditor)
 
 	// Layout actions
 	d.Register("snap_left", handleSnapLeft)
@@ -82,6 +88,31 @@ func (d *ActionDispatcher) registerHandlers() {
 	d.Register("preselect_up", handlePreselectUp)
 	d.Register("preselect_down", handlePreselectDown)
 
+	// Layout engine actions
+	d.Register("next_layout", handleNextLayout)
+	d.Register("prev_layout", handlePrevLayout)
+	d.Register("increase_masters", handleIncreaseMasters)
+	d.Register("decrease_masters", handleDecreaseMasters)
+
+	// Floating window actions
+	d.Register("toggle_floating", handleToggleFloating)
+	d.Register("toggle_zoom", handleToggleZoom)
+	d.Register("toggle_sticky", handleToggleSticky)
+
+	// Tabbed and stacked container actions
+	d.Register("toggle_tabbed", handleToggleTabbed)
+	d.Register("toggle_stacked", handleToggleStacked)
+	d.Register("next_tab", handleNextTab)
+	d.Register("prev_tab", handlePrevTab)
+	d.Register("join_group", handleJoinGroup)
+	d.Register("leave_group", handleLeaveGroup)
+
+	// Layout history actions
+	d.Register("undo_layout", handleUndoLayout)
+	d.Register("redo_layout", handleRedoLayout)
+	d.Register("save_layout", handleSaveLayout)
+	d.Register("load_layout", handleLoadLayout)
+
 	// Mode control actions
 	d.Register("enter_terminal_mode", handleEnterTerminalMode)
 	d.Register("enter_window_mode", handleEnterWindowMode)
@@ -90,6 +121,11 @@ func (d *ActionDispatcher) registerHandlers() {
 
 	// Clipboard actions
 	d.Register("paste_clipboard", handlePasteClipboard)
+	d.Register("paste_register", handlePasteRegister)
+
+	// Search actions
+	d.Register("global_search", handleGlobalSearch)
+	d.Register("history_browser", handleHistoryBrowser)
 
 	// System actions
 	d.Register("toggle_logs", handleToggleLogs)
@@ -134,6 +170,16 @@ func (d *ActionDispatcher) Dispatch(action string, msg tea.KeyPressMsg, o *app.O
 	return o, nil
 }
 
+// Actions returns the names of all registered actions, sorted
+func (d *ActionDispatcher) Actions() []string {
+	actions := make([]string, 0, len(d.handlers))
+	for action := range d.handlers {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
 // HasAction checks if an action is registered
 func (d *ActionDispatcher) HasAction(action string) bool {
 	_, ok := d.handlers[action]
@@ -201,7 +247,7 @@ func handleMinimizeWindow(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 func handleRestoreAll(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	// Restore all minimized windows in current workspace
 	for i := range o.Windows {
-		if o.Windows[i].Minimized && o.Windows[i].Workspace == o.CurrentWorkspace {
+		if o.Windows[i].Minimized && o.Windows[i].Workspace == o.CurrentWorkspace && o.Windows[i].Scratchpad == "" {
 			o.RestoreWindow(i)
 		}
 	}
@@ -238,6 +284,11 @@ func makeSwitchWorkspaceHandler(workspace int) ActionHandler {
 	}
 }
 
+func handleWorkspacePicker(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	o.OpenWorkspacePicker()
+	return o, nil
+}
+
 func makeMoveAndFollowHandler(workspace int) ActionHandler {
 	return func(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 		if o.FocusedWindow >= 0 && o.FocusedWindow < len(o.Windows) {
@@ -251,29 +302,35 @@ func makeMoveAndFollowHandler(workspace int) ActionHandler {
 // Layout Action Handlers
 // ============================================================================
 
+// focusedMovesFreely reports whether the focused window can be snapped and
+// moved by hand: tiling is off or the window floats above the layout.
+func focusedMovesFreely(o *app.OS) bool {
+	return o.FocusedWindow >= 0 && o.FocusedWindow < len(o.Windows) && !o.IsTiledWindow(o.Windows[o.FocusedWindow])
+}
+
 func handleSnapLeft(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
-	if !o.AutoTiling && len(o.Windows) > 0 && o.FocusedWindow >= 0 {
+	if focusedMovesFreely(o) {
 		o.Snap(o.FocusedWindow, app.SnapLeft)
 	}
 	return o, nil
 }
 
 func handleSnapRight(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
-	if !o.AutoTiling && len(o.Windows) > 0 && o.FocusedWindow >= 0 {
+	if focusedMovesFreely(o) {
 		o.Snap(o.FocusedWindow, app.SnapRight)
 	}
 	return o, nil
 }
 
 func handleSnapFullscreen(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
-	if !o.AutoTiling && len(o.Windows) > 0 && o.FocusedWindow >= 0 {
+	if focusedMovesFreely(o) {
 		o.Snap(o.FocusedWindow, app.SnapFullScreen)
 	}
 	return o, nil
 }
 
 func handleUnsnap(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
-	if !o.AutoTiling && len(o.Windows) > 0 && o.FocusedWindow >= 0 {
+	if focusedMovesFreely(o) {
 		o.Snap(o.FocusedWindow, app.Unsnap)
 	}
 	return o, nil
@@ -281,7 +338,7 @@ func handleUnsnap(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 
 func makeSnapCornerHandler(corner app.SnapQuarter) ActionHandler {
 	return func(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
-		if !o.AutoTiling && len(o.Windows) > 0 && o.FocusedWindow >= 0 {
+		if focusedMovesFreely(o) {
 			o.Snap(o.FocusedWindow, corner)
 		}
 		return o, nil
@@ -299,6 +356,138 @@ func handleToggleTiling(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	return o, nil
 }
 
+func handleNextLayout(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	name := o.CycleLayout(1)
+	o.ShowNotification("Layout: "+name, "info", config.NotificationDuration)
+	return o, nil
+}
+
+func handlePrevLayout(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	name := o.CycleLayout(-1)
+	o.ShowNotification("Layout: "+name, "info", config.NotificationDuration)
+	return o, nil
+}
+
+func handleIncreaseMasters(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	n := o.AdjustMasterCount(1)
+	o.ShowNotification(fmt.Sprintf("Master windows: %d", n), "info", config.NotificationDuration)
+	return o, nil
+}
+
+func handleDecreaseMasters(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	n := o.AdjustMasterCount(-1)
+	o.ShowNotification(fmt.Sprintf("Master windows: %d", n), "info", config.NotificationDuration)
+	return o, nil
+}
+
+func handleToggleFloating(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	focused := o.GetFocusedWindow()
+	if focused == nil {
+		return o, nil
+	}
+	o.SetWindowFloating(focused, !focused.Floating)
+	if focused.Floating {
+		o.ShowNotification("Window floating", "info", config.NotificationDuration)
+	} else {
+		o.ShowNotification("Window tiled", "info", config.NotificationDuration)
+	}
+	return o, nil
+}
+
+func handleToggleZoom(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	if err := o.ToggleZoom(); err != nil {
+		o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+	}
+	return o, nil
+}
+
+func handleToggleSticky(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	if err := o.ToggleSticky(); err != nil {
+		o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+		return o, nil
+	}
+	if o.GetFocusedWindow().Sticky {
+		o.ShowNotification("Window sticky", "info", config.NotificationDuration)
+	} else {
+		o.ShowNotification("Window pinned to workspace", "info", config.NotificationDuration)
+	}
+	return o, nil
+}
+
+func handleToggleTabbed(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	return toggleContainer(o, layout.ContainerTabbed)
+}
+
+func handleToggleStacked(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	return toggleContainer(o, layout.ContainerStacked)
+}
+
+func toggleContainer(o *app.OS, mode layout.ContainerMode) (*app.OS, tea.Cmd) {
+	mode, err := o.ToggleFocusedContainer(mode)
+	if err != nil {
+		o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+		return o, nil
+	}
+	o.ShowNotification("Container: "+mode.String(), "info", config.NotificationDuration)
+	return o, nil
+}
+
+func handleNextTab(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	if err := o.CycleFocusedGroup(1); err != nil {
+		o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+	}
+	return o, nil
+}
+
+func handlePrevTab(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	if err := o.CycleFocusedGroup(-1); err != nil {
+		o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+	}
+	return o, nil
+}
+
+func handleJoinGroup(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	if err := o.JoinFocusedWindowGroup(); err != nil {
+		o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+	}
+	return o, nil
+}
+
+func handleLeaveGroup(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	if err := o.LeaveFocusedWindowGroup(); err != nil {
+		o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+	}
+	return o, nil
+}
+
+func handleUndoLayout(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	if err := o.UndoLayout(); err != nil {
+		o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+		return o, nil
+	}
+	o.ShowNotification("Layout undone", "info", config.NotificationDuration)
+	return o, nil
+}
+
+func handleRedoLayout(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	if err := o.RedoLayout(); err != nil {
+		o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+		return o, nil
+	}
+	o.ShowNotification("Layout redone", "info", config.NotificationDuration)
+	return o, nil
+}
+
+func handleSaveLayout(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	o.OpenLayoutPrompt("save")
+	return o, nil
+}
+
+func handleLoadLayout(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	o.OpenLayoutPrompt("load")
+	return o, nil
+}
+
 func handleSwapLeft(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	if o.AutoTiling && o.FocusedWindow >= 0 {
 		o.SwapWindowLeft()
@@ -328,14 +517,18 @@ func handleSwapDown(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 }
 
 func handleResizeMasterShrink(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
-	if o.AutoTiling {
+	if o.AutoTiling && o.UsingLayoutEngine() {
+		o.ResizeMasterWidth(-0.05) // Registered layouts size the master area by ratio
+	} else if o.AutoTiling {
 		o.ResizeFocusedWindowWidth(-4) // Shrink by 4 columns (split-line based)
 	}
 	return o, nil
 }
 
 func handleResizeMasterGrow(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
-	if o.AutoTiling {
+	if o.AutoTiling && o.UsingLayoutEngine() {
+		o.ResizeMasterWidth(0.05)
+	} else if o.AutoTiling {
 		o.ResizeFocusedWindowWidth(4) // Grow by 4 columns (split-line based)
 	}
 	return o, nil
@@ -560,6 +753,55 @@ func handlePasteClipboard(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	return o, nil
 }
 
+func handlePasteRegister(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	if o.GetFocusedWindow() != nil {
+		o.PendingRegisterPaste = true
+		o.ShowNotification("Paste register: \"", "info", 0)
+	}
+	return o, nil
+}
+
+// handlePendingRegisterPaste consumes the register name after paste_register
+func handlePendingRegisterPaste(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	o.PendingRegisterPaste = false
+	o.ShowNotification("", "info", 0)
+
+	keyStr := msg.String()
+	if len(keyStr) == 1 && app.IsValidRegister(rune(keyStr[0])) {
+		handleRegisterPaste(o, rune(keyStr[0]))
+	}
+	return o, nil
+}
+
+func handleGlobalSearch(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	OpenGlobalSearch(o)
+	return o, nil
+}
+
+func handleHistoryBrowser(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	OpenHistoryBrowser(o)
+	return o, nil
+}
+
+func handleWindowSwitcher(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	o.OpenWindowSwitcher()
+	return o, nil
+}
+
+func handleThemeEditor(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	if err := o.OpenThemeEditor(); err != nil {
+		o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+	}
+	return o, nil
+}
+
+// handleCommandPalette is a method so the palette lists this dispatcher's
+// actions without referring to the global dispatcher while it is built
+func (d *ActionDispatcher) handleCommandPalette(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	o.OpenCommandPalette(d.Actions())
+	return o, nil
+}
+
 // ============================================================================
 // Restore Minimized Window Handlers
 // ============================================================================
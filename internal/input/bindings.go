@@ -0,0 +1,56 @@
+package input
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/app"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+)
+
+// runBindingTarget runs what a key table binding or macro is bound to: an
+// action, a key table, tape commands, a shell command or text for the
+// focused window.
+func runBindingTarget(target string, msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	var err error
+	kind, arg := config.ParseBindingTarget(target)
+	switch kind {
+	case config.BindingTable:
+		o.EnterKeyTable(arg)
+		return o, nil
+	case config.BindingTape:
+		var cmd tea.Cmd
+		if cmd, err = o.RunTapeSnippet(arg); err == nil {
+			return o, cmd
+		}
+	case config.BindingRun, config.BindingPopup:
+		err = o.RunCommand(arg, kind == config.BindingPopup)
+	case config.BindingSend:
+		err = o.SendToFocusedWindow(arg)
+	default:
+		dispatcher := GetDispatcher()
+		if !dispatcher.HasAction(arg) {
+			err = fmt.Errorf("unknown action: %s", arg)
+			break
+		}
+		return dispatcher.Dispatch(arg, msg, o)
+	}
+	if err != nil {
+		o.ShowNotification(err.Error(), "error", config.NotificationDuration)
+	}
+	return o, nil
+}
+
+// handleMacroKey runs the macro bound to the pressed key, if any. It
+// reports whether the key had a macro.
+func handleMacroKey(msg tea.KeyPressMsg, o *app.OS) (bool, tea.Cmd) {
+	if o.KeybindRegistry == nil {
+		return false, nil
+	}
+	target := o.KeybindRegistry.GetMacroForKey(msg.String())
+	if target == "" {
+		return false, nil
+	}
+	_, cmd := runBindingTarget(target, msg, o)
+	return true, cmd
+}
@@ -0,0 +1,91 @@
+package input
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/app"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+)
+
+// OpenCommandPalette opens the command palette listing every registered
+// action.
+func OpenCommandPalette(o *app.OS) {
+	o.OpenCommandPalette(GetDispatcher().Actions())
+}
+
+// HandleCommandPaletteKey handles keyboard input when the command palette is
+// open. Printable keys edit the query, so the selection moves with the arrow
+// keys only.
+func HandleCommandPaletteKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	s := o.CommandPalette
+	if s == nil {
+		o.CloseCommandPalette()
+		return o, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		o.CloseCommandPalette()
+	case "up", "ctrl+p", "shift+tab":
+		if s.SelectedIndex > 0 {
+			s.SelectedIndex--
+		}
+	case "down", "ctrl+n":
+		if s.SelectedIndex < len(o.CommandPaletteItems())-1 {
+			s.SelectedIndex++
+		}
+	case "tab":
+		// Complete the selected command so its arguments can be typed
+		items := o.CommandPaletteItems()
+		if s.SelectedIndex < len(items) && items[s.SelectedIndex].Complete != "" {
+			s.Query = items[s.SelectedIndex].Complete
+			s.SelectedIndex = 0
+		}
+	case "enter":
+		return runPaletteItem(msg, o)
+	case "backspace":
+		if len(s.Query) > 0 {
+			s.Query = s.Query[:len(s.Query)-1]
+			s.SelectedIndex = 0
+		}
+	default:
+		if len(msg.String()) == 1 && msg.String()[0] >= 32 && msg.String()[0] < 127 {
+			s.Query += msg.String()
+			s.SelectedIndex = 0
+		} else if msg.String() == "space" {
+			s.Query += " "
+			s.SelectedIndex = 0
+		}
+	}
+	return o, nil
+}
+
+// runPaletteItem runs the selected palette entry and closes the palette,
+// unless the entry is a command that still needs its arguments.
+func runPaletteItem(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	items := o.CommandPaletteItems()
+	selected := o.CommandPalette.SelectedIndex
+	if selected < 0 || selected >= len(items) {
+		return o, nil
+	}
+	item := items[selected]
+	if item.Complete != "" {
+		o.CommandPalette.Query = item.Complete
+		o.CommandPalette.SelectedIndex = 0
+		return o, nil
+	}
+	o.CloseCommandPalette()
+
+	switch {
+	case item.Action != "":
+		return GetDispatcher().Dispatch(item.Action, msg, o)
+	case item.Tape != nil:
+		if err := o.PlayTapeFile(*item.Tape); err != nil {
+			o.ShowNotification(err.Error(), "error", config.NotificationDuration)
+		}
+	default:
+		if err := o.RunPaletteCommands(item.Commands); err != nil {
+			o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+		}
+	}
+	return o, nil
+}
@@ -23,7 +23,7 @@ func HandleCopyModeKey(msg tea.KeyPressMsg, o *app.OS, window *terminal.Window)
 	switch cm.State {
 	case terminal.CopyModeSearch:
 		return handleSearchInput(msg, cm, window, o)
-	case terminal.CopyModeVisualChar, terminal.CopyModeVisualLine:
+	case terminal.CopyModeVisualChar, terminal.CopyModeVisualLine, terminal.CopyModeVisualBlock:
 		return handleVisualInput(msg, cm, window, o)
 	case terminal.CopyModeNormal:
 		return handleNormalInput(msg, cm, window, o)
@@ -61,6 +61,19 @@ func handleNormalInput(msg tea.KeyPressMsg, cm *terminal.CopyMode, window *termi
 		return o, nil
 	}
 
+	// Handle pending register selection (" followed by register name)
+	if cm.PendingRegister {
+		selectRegister(keyStr, cm, o)
+		return o, nil
+	}
+
+	// Handle the second key of marks and prompt motions (ma, 'a, [[, ]])
+	if cm.PendingKey != "" {
+		handlePendingKey(keyStr, cm, window, o)
+		window.InvalidateCache()
+		return o, nil
+	}
+
 	// Handle digit keys for count prefix (1-9, 0 only if already has count)
 	if len(keyStr) == 1 && keyStr[0] >= '0' && keyStr[0] <= '9' {
 		digit := int(keyStr[0] - '0')
@@ -82,13 +95,10 @@ func handleNormalInput(msg tea.KeyPressMsg, cm *terminal.CopyMode, window *termi
 		count = 1
 	}
 
-	// Clear count after reading it (will be reset after command execution)
-	defer func() {
-		cm.PendingCount = 0
-		if o != nil {
-			o.ShowNotification("", "info", 0) // Clear count display
-		}
-	}()
+	// Clear count after reading it, before the command shows its own
+	// notification (register prompts, yank results)
+	cm.PendingCount = 0
+	o.ShowNotification("", "info", 0) // Clear count display
 
 	switch keyStr {
 	case "q", "esc":
@@ -324,6 +334,21 @@ func handleNormalInput(msg tea.KeyPressMsg, cm *terminal.CopyMode, window *termi
 		enterVisualLine(cm, window)
 		o.ShowNotification("VISUAL LINE", "info", 0)
 		return o, nil
+	case "ctrl+v":
+		enterVisualBlock(cm, window)
+		o.ShowNotification("VISUAL BLOCK", "info", 0)
+		return o, nil
+
+	// Register selection for the next yank
+	case "\"":
+		cm.PendingRegister = true
+		o.ShowNotification("\"", "info", 0)
+		return o, nil
+
+	// Marks and prompt motions wait for a second key
+	case "m", "'", "`", "[", "]":
+		cm.PendingKey = keyStr
+		return o, nil
 	}
 
 	window.InvalidateCache()
@@ -401,6 +426,21 @@ func handleVisualInput(msg tea.KeyPressMsg, cm *terminal.CopyMode, window *termi
 		return o, nil
 	}
 
+	// Handle pending register selection (" followed by register name)
+	if cm.PendingRegister {
+		selectRegister(keyStr, cm, o)
+		return o, nil
+	}
+
+	// Handle the second key of marks, prompt motions and text objects (io, ao)
+	if cm.PendingKey != "" {
+		if handlePendingKey(keyStr, cm, window, o) {
+			updateVisualEnd(cm, window)
+		}
+		window.InvalidateCache()
+		return o, nil
+	}
+
 	// Handle digit keys for count prefix in visual mode
 	if len(keyStr) == 1 && keyStr[0] >= '0' && keyStr[0] <= '9' {
 		digit := int(keyStr[0] - '0')
@@ -421,13 +461,10 @@ func handleVisualInput(msg tea.KeyPressMsg, cm *terminal.CopyMode, window *termi
 		count = 1
 	}
 
-	// Clear count after reading it
-	defer func() {
-		cm.PendingCount = 0
-		if o != nil {
-			o.ShowNotification("", "info", 0)
-		}
-	}()
+	// Clear count after reading it, before the command shows its own
+	// notification (register prompts, yank results)
+	cm.PendingCount = 0
+	o.ShowNotification("", "info", 0) // Clear count display
 
 	switch keyStr {
 	case "esc", "q":
@@ -436,9 +473,26 @@ func handleVisualInput(msg tea.KeyPressMsg, cm *terminal.CopyMode, window *termi
 	case "y", "c":
 		text := extractVisualText(cm, window)
 		cm.State = terminal.CopyModeNormal
-		o.ShowNotification(fmt.Sprintf("Yanked %d chars", len(text)), "success", config.NotificationDuration)
+		register := cm.Register
+		cm.Register = 0
+		o.SetRegister(register, text)
 		window.InvalidateCache()
+		if register != 0 && register != app.UnnamedRegister {
+			// Named registers don't touch the system clipboard (vim-style)
+			o.ShowNotification(fmt.Sprintf("Yanked %d chars into \"%c", len(text), register), "success", config.NotificationDuration)
+			return o, nil
+		}
+		o.ShowNotification(fmt.Sprintf("Yanked %d chars", len(text)), "success", config.NotificationDuration)
 		return o, tea.SetClipboard(text)
+	case "\"":
+		cm.PendingRegister = true
+		o.ShowNotification("\"", "info", 0)
+		return o, nil
+
+	// Marks, prompt motions and command output text objects wait for a second key
+	case "m", "'", "`", "[", "]", "i", "a":
+		cm.PendingKey = keyStr
+		return o, nil
 
 	// Movement in visual mode extends selection - basic
 	case "h", "left":
@@ -595,6 +649,17 @@ func handleVisualInput(msg tea.KeyPressMsg, cm *terminal.CopyMode, window *termi
 			enterVisualLine(cm, window)
 			o.ShowNotification("VISUAL LINE", "info", 0)
 		}
+	case "ctrl+v":
+		// Pressing Ctrl+V in visual block mode exits to normal mode,
+		// otherwise the current selection becomes a block selection
+		if cm.State == terminal.CopyModeVisualBlock {
+			cm.State = terminal.CopyModeNormal
+			o.ShowNotification("", "info", 0)
+		} else {
+			cm.State = terminal.CopyModeVisualBlock
+			updateVisualEnd(cm, window)
+			o.ShowNotification("VISUAL BLOCK", "info", 0)
+		}
 	}
 
 	window.InvalidateCache()
@@ -627,7 +692,7 @@ func HandleCopyModeMouseClick(cm *terminal.CopyMode, window *terminal.Window, cl
 	}
 
 	// If in visual mode, update selection end
-	if cm.State == terminal.CopyModeVisualChar || cm.State == terminal.CopyModeVisualLine {
+	if cm.InVisualMode() {
 		updateVisualEnd(cm, window)
 	}
 
@@ -647,7 +712,7 @@ func HandleCopyModeMouseDrag(cm *terminal.CopyMode, window *terminal.Window, sta
 
 	// Always exit visual mode first if we're in it, then start fresh
 	// This ensures each click-and-drag creates a new selection
-	if cm.State == terminal.CopyModeVisualChar || cm.State == terminal.CopyModeVisualLine {
+	if cm.InVisualMode() {
 		cm.State = terminal.CopyModeNormal
 	}
 
@@ -674,7 +739,7 @@ func HandleCopyModeMouseDrag(cm *terminal.CopyMode, window *terminal.Window, sta
 // HandleCopyModeMouseMotion handles mouse motion during drag in copy mode
 func HandleCopyModeMouseMotion(cm *terminal.CopyMode, window *terminal.Window, mouseX, mouseY int) {
 	// Only handle if in visual mode
-	if cm.State != terminal.CopyModeVisualChar && cm.State != terminal.CopyModeVisualLine {
+	if !cm.InVisualMode() {
 		return
 	}
 
@@ -706,3 +771,71 @@ func HandleCopyModeMouseMotion(cm *terminal.CopyMode, window *terminal.Window, m
 
 	window.InvalidateCache()
 }
+
+// selectRegister consumes the register name following " in copy mode
+func selectRegister(keyStr string, cm *terminal.CopyMode, o *app.OS) {
+	cm.PendingRegister = false
+	if len(keyStr) == 1 && app.IsValidRegister(rune(keyStr[0])) {
+		cm.Register = rune(keyStr[0])
+		o.ShowNotification(fmt.Sprintf("\"%c", cm.Register), "info", 0)
+		return
+	}
+	// Escape or invalid register name cancels the selection
+	cm.Register = 0
+	o.ShowNotification("", "info", 0)
+}
+
+// handlePendingKey completes a two-key copy mode command started with m, ', `,
+// [, ], i or a. Returns true if the cursor moved as a plain motion (the caller
+// extends a visual selection); text objects set the selection themselves.
+func handlePendingKey(keyStr string, cm *terminal.CopyMode, window *terminal.Window, o *app.OS) bool {
+	first := cm.PendingKey
+	cm.PendingKey = ""
+	if keyStr == "esc" {
+		return false
+	}
+
+	var name rune
+	if len([]rune(keyStr)) == 1 {
+		name = []rune(keyStr)[0]
+	}
+
+	switch first {
+	case "m":
+		if !isMarkName(name) {
+			return false
+		}
+		setMark(cm, window, name)
+		o.ShowNotification(fmt.Sprintf("Mark %c set", name), "info", config.NotificationDuration)
+	case "'", "`":
+		if !isMarkName(name) && name != previousPositionMark {
+			return false
+		}
+		if !jumpToMark(cm, window, name, first == "`") {
+			o.ShowNotification(fmt.Sprintf("Mark %c not set", name), "warning", config.NotificationDuration)
+			return false
+		}
+		return true
+	case "[", "]":
+		if keyStr != first {
+			return false
+		}
+		dir := 1
+		if first == "[" {
+			dir = -1
+		}
+		if !jumpToPrompt(cm, window, dir) {
+			o.ShowNotification("No more prompts (needs OSC 133 shell integration)", "warning", config.NotificationDuration)
+			return false
+		}
+		return true
+	case "i", "a":
+		if keyStr != "o" {
+			return false
+		}
+		if !selectCommandOutput(cm, window, first == "a") {
+			o.ShowNotification("No command output here (needs OSC 133 shell integration)", "warning", config.NotificationDuration)
+		}
+	}
+	return false
+}
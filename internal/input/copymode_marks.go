@@ -0,0 +1,193 @@
+// Package input implements vim-style copy mode for TUIOS.
+package input
+
+import (
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/Gaurav-Gosain/tuios/internal/vt"
+)
+
+// Marks and semantic prompt motions for copy mode (m, ', `, [[, ]], io, ao)
+
+// previousPositionMark is set automatically before jumps, so ' followed by ' returns there
+const previousPositionMark = '\''
+
+// isMarkName reports whether r can name a user mark
+func isMarkName(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// setMark records the cursor position under name.
+// The line is stored as absolute line + trimmed count so it stays valid
+// when the scrollback ring buffer drops old lines.
+func setMark(cm *terminal.CopyMode, window *terminal.Window, name rune) {
+	if cm.Marks == nil {
+		cm.Marks = make(map[rune]terminal.Position)
+	}
+	cm.Marks[name] = terminal.Position{
+		X: cm.CursorX,
+		Y: getAbsoluteY(cm, window) + window.ScrollbackTrimmed(),
+	}
+}
+
+// resolveMark returns the absolute position of a mark.
+// ok is false if the mark is unset or its line has been trimmed from scrollback.
+func resolveMark(cm *terminal.CopyMode, window *terminal.Window, name rune) (pos terminal.Position, ok bool) {
+	mark, exists := cm.Marks[name]
+	if !exists {
+		return terminal.Position{}, false
+	}
+	absY := mark.Y - window.ScrollbackTrimmed()
+	if absY < 0 {
+		return terminal.Position{}, false
+	}
+	return terminal.Position{X: mark.X, Y: absY}, true
+}
+
+// jumpToMark moves the cursor to a mark's line ('), or its exact position (`)
+func jumpToMark(cm *terminal.CopyMode, window *terminal.Window, name rune, exact bool) bool {
+	pos, ok := resolveMark(cm, window, name)
+	if !ok {
+		return false
+	}
+
+	x := 0
+	if exact {
+		x = pos.X
+	}
+	setMark(cm, window, previousPositionMark)
+	jumpToAbsoluteLine(cm, window, pos.Y, x)
+	return true
+}
+
+// promptLines returns the absolute lines of all shell prompts recorded via OSC 133.
+// Prompt start (A) markers are preferred; shells that only emit command start (B)
+// markers fall back to those.
+func promptLines(markers []vt.SemanticMarker) []int {
+	var lines []int
+	for _, markerType := range []vt.SemanticMarkerType{vt.MarkerPromptStart, vt.MarkerCommandStart} {
+		for _, m := range markers {
+			if m.Type == markerType && (len(lines) == 0 || m.AbsLine != lines[len(lines)-1]) {
+				lines = append(lines, m.AbsLine)
+			}
+		}
+		if len(lines) > 0 {
+			break
+		}
+	}
+	return lines
+}
+
+// jumpToPrompt moves the cursor to the previous (dir < 0) or next (dir > 0)
+// shell prompt. Returns false if there is no prompt in that direction.
+func jumpToPrompt(cm *terminal.CopyMode, window *terminal.Window, dir int) bool {
+	markerList := window.Terminal.SemanticMarkers()
+	if markerList == nil {
+		return false
+	}
+
+	lines := promptLines(markerList.Markers())
+	currentAbsY := getAbsoluteY(cm, window)
+
+	target := -1
+	if dir < 0 {
+		for i := len(lines) - 1; i >= 0; i-- {
+			if lines[i] < currentAbsY {
+				target = lines[i]
+				break
+			}
+		}
+	} else {
+		for _, line := range lines {
+			if line > currentAbsY {
+				target = line
+				break
+			}
+		}
+	}
+	if target < 0 {
+		return false
+	}
+
+	setMark(cm, window, previousPositionMark)
+	jumpToAbsoluteLine(cm, window, target, 0)
+	return true
+}
+
+// commandOutputRange returns the line range of the command containing absY.
+// With around=false the range covers only the command's output (C marker up to
+// the D marker); with around=true it starts at the prompt line instead.
+// lastLine bounds the range when the command has not finished yet.
+func commandOutputRange(markers []vt.SemanticMarker, absY, lastLine int, around bool) (start, end int, ok bool) {
+	prompts := promptLines(markers)
+
+	promptLine, nextPrompt := -1, -1
+	for _, line := range prompts {
+		if line <= absY {
+			promptLine = line
+		} else {
+			nextPrompt = line
+			break
+		}
+	}
+	if promptLine < 0 {
+		return 0, 0, false
+	}
+
+	limit := lastLine
+	if nextPrompt >= 0 {
+		limit = nextPrompt - 1
+	}
+
+	outputStart, outputEnd := -1, limit
+	for _, m := range markers {
+		if m.AbsLine < promptLine || m.AbsLine > limit {
+			continue
+		}
+		switch {
+		case m.Type == vt.MarkerCommandExecuted && outputStart < 0:
+			outputStart = m.AbsLine
+		case m.Type == vt.MarkerCommandFinished && outputStart >= 0:
+			// D is usually emitted at column 0 of the line after the output
+			outputEnd = m.AbsLine
+			if m.Col == 0 {
+				outputEnd--
+			}
+		}
+		if m.Type == vt.MarkerCommandFinished && outputStart >= 0 {
+			break
+		}
+	}
+
+	if around {
+		return promptLine, max(outputEnd, promptLine), true
+	}
+	if outputStart < 0 || outputEnd < outputStart {
+		return 0, 0, false
+	}
+	return outputStart, outputEnd, true
+}
+
+// selectCommandOutput selects the output of the command under the cursor
+// as a line-wise visual selection (io), or the whole command including its
+// prompt (ao). Returns false if no command output surrounds the cursor.
+func selectCommandOutput(cm *terminal.CopyMode, window *terminal.Window, around bool) bool {
+	markerList := window.Terminal.SemanticMarkers()
+	if markerList == nil {
+		return false
+	}
+
+	lastLine := window.ScrollbackLen() + window.Terminal.Height() - 1
+	start, end, ok := commandOutputRange(markerList.Markers(), getAbsoluteY(cm, window), lastLine, around)
+	if !ok {
+		return false
+	}
+
+	startX, _ := getLineContentBounds(cm, window, start)
+	_, endX := getLineContentBounds(cm, window, end)
+
+	cm.State = terminal.CopyModeVisualLine
+	cm.VisualStart = terminal.Position{X: startX, Y: start}
+	cm.VisualEnd = terminal.Position{X: endX, Y: end}
+	jumpToAbsoluteLine(cm, window, end, 0)
+	return true
+}
@@ -0,0 +1,125 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/Gaurav-Gosain/tuios/internal/vt"
+)
+
+func TestCommandOutputRange(t *testing.T) {
+	// Two commands: prompt on line 0 with output on 1-3, prompt on line 5
+	// still running with output from line 6.
+	markers := []vt.SemanticMarker{
+		{Type: vt.MarkerPromptStart, AbsLine: 0},
+		{Type: vt.MarkerCommandStart, AbsLine: 0, Col: 2},
+		{Type: vt.MarkerCommandExecuted, AbsLine: 1},
+		{Type: vt.MarkerCommandFinished, AbsLine: 4, Col: 0},
+		{Type: vt.MarkerPromptStart, AbsLine: 5},
+		{Type: vt.MarkerCommandStart, AbsLine: 5, Col: 2},
+		{Type: vt.MarkerCommandExecuted, AbsLine: 6},
+	}
+
+	tests := []struct {
+		name      string
+		absY      int
+		around    bool
+		wantStart int
+		wantEnd   int
+		wantOK    bool
+	}{
+		{"inner from output", 2, false, 1, 3, true},
+		{"inner from prompt", 0, false, 1, 3, true},
+		{"around includes prompt", 2, true, 0, 3, true},
+		{"unfinished command runs to last line", 7, false, 6, 9, true},
+		{"before first prompt", -1, false, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := commandOutputRange(markers, tt.absY, 9, tt.around)
+			if ok != tt.wantOK || (ok && (start != tt.wantStart || end != tt.wantEnd)) {
+				t.Errorf("commandOutputRange(%d, around=%v) = (%d, %d, %v), want (%d, %d, %v)",
+					tt.absY, tt.around, start, end, ok, tt.wantStart, tt.wantEnd, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCommandOutputRangeWithoutOutput(t *testing.T) {
+	// Prompt shown but no command executed yet
+	markers := []vt.SemanticMarker{
+		{Type: vt.MarkerPromptStart, AbsLine: 3},
+		{Type: vt.MarkerCommandStart, AbsLine: 3, Col: 2},
+	}
+	if _, _, ok := commandOutputRange(markers, 3, 5, false); ok {
+		t.Error("expected no output range for a command that has not run")
+	}
+	if start, end, ok := commandOutputRange(markers, 3, 5, true); !ok || start != 3 || end != 5 {
+		t.Errorf("around range = (%d, %d, %v), want (3, 5, true)", start, end, ok)
+	}
+}
+
+func TestPromptLinesFallsBackToCommandStart(t *testing.T) {
+	markers := []vt.SemanticMarker{
+		{Type: vt.MarkerCommandStart, AbsLine: 2},
+		{Type: vt.MarkerCommandExecuted, AbsLine: 3},
+		{Type: vt.MarkerCommandStart, AbsLine: 8},
+	}
+	got := promptLines(markers)
+	if len(got) != 2 || got[0] != 2 || got[1] != 8 {
+		t.Errorf("promptLines() = %v, want [2 8]", got)
+	}
+}
+
+func TestMarkSurvivesScrollbackTrim(t *testing.T) {
+	window := newTestCopyWindow(t, 20, 3, "")
+	window.Terminal.SetScrollbackMaxLines(5)
+
+	var b strings.Builder
+	for i := range 6 {
+		fmt.Fprintf(&b, "line %d\r\n", i)
+	}
+	if _, err := window.Terminal.Write([]byte(b.String())); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// Mark the first scrollback line ("line 0")
+	cm := &terminal.CopyMode{}
+	jumpToAbsoluteLine(cm, window, 0, 0)
+	setMark(cm, window, 'a')
+
+	// Push enough output to trim a few lines from the scrollback
+	b.Reset()
+	for i := 6; i < 10; i++ {
+		fmt.Fprintf(&b, "line %d\r\n", i)
+	}
+	if _, err := window.Terminal.Write([]byte(b.String())); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if window.ScrollbackTrimmed() == 0 {
+		t.Fatal("expected scrollback to be trimmed")
+	}
+
+	if _, ok := resolveMark(cm, window, 'a'); ok {
+		t.Error("mark on a trimmed line should not resolve")
+	}
+
+	// A mark set after trimming still points at the same text after more trimming
+	jumpToAbsoluteLine(cm, window, 2, 0)
+	want := getLineText(cm, window, 2)
+	setMark(cm, window, 'b')
+
+	if _, err := window.Terminal.Write([]byte("line 10\r\nline 11\r\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	pos, ok := resolveMark(cm, window, 'b')
+	if !ok {
+		t.Fatal("mark b should still resolve")
+	}
+	if got := getLineText(cm, window, pos.Y); got != want {
+		t.Errorf("mark b line = %q, want %q", got, want)
+	}
+}
@@ -2,140 +2,139 @@
 package input
 
 import (
-	"strings"
+	"regexp"
 	"time"
+	"unicode"
 
 	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	uv "github.com/charmbracelet/ultraviolet"
 )
 
 // Search-related functions for copy mode (/, ?, n, N, etc.)
 
-// executeSearch performs a search operation and updates matches
-func executeSearch(cm *terminal.CopyMode, window *terminal.Window) {
-	// Check cache
-	if cm.SearchQuery != "" && cm.SearchQuery == cm.SearchCache.Query && cm.SearchCache.Valid {
-		cm.SearchMatches = cm.SearchCache.Matches
-		if len(cm.SearchMatches) > 0 {
-			cm.CurrentMatch = 0
-			jumpToMatch(cm, window, 0)
-		}
-		return
-	}
+// maxSearchMatches caps the number of matches collected per window
+const maxSearchMatches = 1000
 
-	cm.SearchMatches = nil
-	if cm.SearchQuery == "" {
-		return
+// compileSearchPattern compiles a search query as an RE2 regular expression.
+// Smartcase applies: the search is case-insensitive unless the query contains
+// an uppercase letter. Queries that are not valid regexes (for example "foo("
+// while still typing) fall back to a literal match.
+func compileSearchPattern(query string) (re *regexp.Regexp, caseSensitive bool) {
+	if query == "" {
+		return nil, false
 	}
 
-	query := cm.SearchQuery
-	if !cm.CaseSensitive {
-		query = strings.ToLower(query)
+	caseSensitive = hasUppercase(query)
+	flags := ""
+	if !caseSensitive {
+		flags = "(?i)"
 	}
 
-	scrollbackLen := window.ScrollbackLen()
-	screenHeight := window.Terminal.Height()
+	re, err := regexp.Compile(flags + query)
+	if err != nil {
+		re = regexp.MustCompile(flags + regexp.QuoteMeta(query))
+	}
+	return re, caseSensitive
+}
 
-	// Search scrollback
-	for i := range scrollbackLen {
-		line := window.ScrollbackLine(i)
-		if line == nil {
+// hasUppercase reports whether a query contains an uppercase letter,
+// ignoring regex escapes such as \S or \W
+func hasUppercase(query string) bool {
+	escaped := false
+	for _, r := range query {
+		if escaped {
+			escaped = false
 			continue
 		}
-		lineText := extractLineTextFromCells(line)
-
-		if !cm.CaseSensitive {
-			lineText = strings.ToLower(lineText)
+		if r == '\\' {
+			escaped = true
+			continue
 		}
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
 
-		// Find all occurrences
-		// Note: strings.Index returns BYTE positions, not character positions
-		byteIdx := 0
-		queryCharLen := len([]rune(query)) // Character length, not byte length
-
-		for {
-			idx := strings.Index(lineText[byteIdx:], query)
-			if idx == -1 {
-				break
-			}
+// findLineMatches returns all matches of re in a single line.
+// lineText is the rendered line text and cells the line's cells, used to map
+// character offsets back to screen columns for wide characters.
+func findLineMatches(re *regexp.Regexp, lineText string, cells []uv.Cell, absLine int) []terminal.SearchMatch {
+	var matches []terminal.SearchMatch
+	// Note: FindAllStringIndex returns BYTE positions, not character positions
+	for _, loc := range re.FindAllStringIndex(lineText, -1) {
+		// Skip empty matches (e.g. "x*") - they can't be highlighted
+		if loc[0] == loc[1] {
+			continue
+		}
 
-			// Convert byte positions to character positions
-			bytePos := byteIdx + idx
-			charStart := byteIndexToCharIndex(lineText, bytePos)
-			charEnd := charStart + queryCharLen
+		charStart := byteIndexToCharIndex(lineText, loc[0])
+		charEnd := byteIndexToCharIndex(lineText, loc[1])
 
-			// Convert character indices to column positions
-			colStart := charIndexToColumn(line, charStart)
-			colEnd := charIndexToColumn(line, charEnd)
+		matches = append(matches, terminal.SearchMatch{
+			Line:   absLine,
+			StartX: charIndexToColumn(cells, charStart),
+			EndX:   charIndexToColumn(cells, charEnd),
+			Text:   lineText[loc[0]:loc[1]],
+		})
+	}
+	return matches
+}
 
-			match := terminal.SearchMatch{
-				Line:   i,
-				StartX: colStart,
-				EndX:   colEnd,
-			}
-			cm.SearchMatches = append(cm.SearchMatches, match)
+// searchWindow searches a window's scrollback and screen for re,
+// returning at most limit matches ordered by absolute line
+func searchWindow(window *terminal.Window, re *regexp.Regexp, limit int) []terminal.SearchMatch {
+	if re == nil || window.Terminal == nil {
+		return nil
+	}
 
-			// Move to next position (in bytes)
-			byteIdx = bytePos + len(query)
+	var matches []terminal.SearchMatch
+	scrollbackLen := window.ScrollbackLen()
 
-			// Limit matches
-			if len(cm.SearchMatches) >= 1000 {
-				break
-			}
-		}
-		if len(cm.SearchMatches) >= 1000 {
-			break
+	// Search scrollback
+	for i := 0; i < scrollbackLen && len(matches) < limit; i++ {
+		line := window.ScrollbackLine(i)
+		if line == nil {
+			continue
 		}
+		matches = append(matches, findLineMatches(re, extractLineTextFromCells(line), line, i)...)
 	}
 
 	// Search current screen
-	if len(cm.SearchMatches) < 1000 {
-		for y := range screenHeight {
-			lineText := extractScreenLineText(window.Terminal, y)
-
-			if !cm.CaseSensitive {
-				lineText = strings.ToLower(lineText)
-			}
-
-			// Note: strings.Index returns BYTE positions, not character positions
-			byteIdx := 0
-			queryCharLen := len([]rune(query)) // Character length, not byte length
-
-			for {
-				idx := strings.Index(lineText[byteIdx:], query)
-				if idx == -1 {
-					break
-				}
-
-				// Convert byte positions to character positions
-				bytePos := byteIdx + idx
-				charStart := byteIndexToCharIndex(lineText, bytePos)
-				charEnd := charStart + queryCharLen
-
-				// Get cells for this screen line to calculate columns
-				cells := getScreenLineCells(window.Terminal, y)
-				colStart := charIndexToColumn(cells, charStart)
-				colEnd := charIndexToColumn(cells, charEnd)
-
-				match := terminal.SearchMatch{
-					Line:   scrollbackLen + y,
-					StartX: colStart,
-					EndX:   colEnd,
-				}
-				cm.SearchMatches = append(cm.SearchMatches, match)
+	for y := 0; y < window.Terminal.Height() && len(matches) < limit; y++ {
+		lineText := extractScreenLineText(window.Terminal, y)
+		cells := getScreenLineCells(window.Terminal, y)
+		matches = append(matches, findLineMatches(re, lineText, cells, scrollbackLen+y)...)
+	}
 
-				// Move to next position (in bytes)
-				byteIdx = bytePos + len(query)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
 
-				if len(cm.SearchMatches) >= 1000 {
-					break
-				}
-			}
-			if len(cm.SearchMatches) >= 1000 {
-				break
-			}
+// executeSearch performs a search operation and updates matches
+func executeSearch(cm *terminal.CopyMode, window *terminal.Window) {
+	// Check cache
+	if cm.SearchQuery != "" && cm.SearchQuery == cm.SearchCache.Query && cm.SearchCache.Valid {
+		cm.SearchMatches = cm.SearchCache.Matches
+		if len(cm.SearchMatches) > 0 {
+			cm.CurrentMatch = 0
+			jumpToMatch(cm, window, 0)
 		}
+		return
 	}
 
+	cm.SearchMatches = nil
+	if cm.SearchQuery == "" {
+		return
+	}
+
+	re, caseSensitive := compileSearchPattern(cm.SearchQuery)
+	cm.CaseSensitive = caseSensitive
+	cm.SearchMatches = searchWindow(window, re, maxSearchMatches)
+
 	// Update cache
 	cm.SearchCache.Query = cm.SearchQuery
 	cm.SearchCache.Matches = cm.SearchMatches
@@ -217,20 +216,26 @@ func jumpToMatch(cm *terminal.CopyMode, window *terminal.Window, matchIdx int) {
 	}
 
 	match := cm.SearchMatches[matchIdx]
+	jumpToAbsoluteLine(cm, window, match.Line, match.StartX)
+}
+
+// jumpToAbsoluteLine moves the cursor to column x of absolute line absY,
+// scrolling into the scrollback if needed
+func jumpToAbsoluteLine(cm *terminal.CopyMode, window *terminal.Window, absY, x int) {
 	scrollbackLen := window.ScrollbackLen()
 
-	if match.Line < scrollbackLen {
-		// Match is in scrollback
-		cm.ScrollOffset = scrollbackLen - match.Line
+	if absY < scrollbackLen {
+		// Line is in scrollback
+		cm.ScrollOffset = scrollbackLen - absY
 		window.ScrollbackOffset = cm.ScrollOffset // Sync for rendering
 		cm.CursorY = 0
 	} else {
-		// Match is in current screen
-		screenLine := match.Line - scrollbackLen
+		// Line is in current screen
+		screenLine := absY - scrollbackLen
 		cm.ScrollOffset = 0
 		window.ScrollbackOffset = cm.ScrollOffset // Sync for rendering
 		cm.CursorY = min(screenLine, window.Height-3)
 	}
 
-	cm.CursorX = match.StartX
+	cm.CursorX = x
 }
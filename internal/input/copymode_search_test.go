@@ -0,0 +1,107 @@
+package input
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/app"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+func TestCompileSearchPatternSmartcase(t *testing.T) {
+	tests := []struct {
+		query         string
+		text          string
+		wantMatch     bool
+		wantSensitive bool
+	}{
+		{"error", "ERROR: disk full", true, false},
+		{"Error", "ERROR: disk full", false, true},
+		{"Error", "Error: disk full", true, true},
+		{`\Serror`, "xERROR", true, false},
+		{`err(or|no)`, "errno 2", true, false},
+		{"foo(", "call foo(bar)", true, false}, // invalid regex falls back to literal
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			re, sensitive := compileSearchPattern(tt.query)
+			if sensitive != tt.wantSensitive {
+				t.Errorf("caseSensitive = %v, want %v", sensitive, tt.wantSensitive)
+			}
+			if got := re.MatchString(tt.text); got != tt.wantMatch {
+				t.Errorf("MatchString(%q) = %v, want %v", tt.text, got, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestCompileSearchPatternEmpty(t *testing.T) {
+	if re, _ := compileSearchPattern(""); re != nil {
+		t.Error("compileSearchPattern(\"\") should return nil")
+	}
+}
+
+func TestSearchWindowRegex(t *testing.T) {
+	window := newTestCopyWindow(t, 30, 4, "pod-a Running\r\npod-b CrashLoop\r\npod-c Running")
+
+	re, _ := compileSearchPattern(`pod-[ab]`)
+	matches := searchWindow(window, re, maxSearchMatches)
+
+	want := []terminal.SearchMatch{
+		{Line: 0, StartX: 0, EndX: 5, Text: "pod-a"},
+		{Line: 1, StartX: 0, EndX: 5, Text: "pod-b"},
+	}
+	if len(matches) != len(want) {
+		t.Fatalf("got %d matches, want %d: %+v", len(matches), len(want), matches)
+	}
+	for i := range want {
+		if matches[i] != want[i] {
+			t.Errorf("match %d = %+v, want %+v", i, matches[i], want[i])
+		}
+	}
+}
+
+func TestSearchWindowSkipsEmptyMatches(t *testing.T) {
+	window := newTestCopyWindow(t, 10, 2, "abc")
+
+	re, _ := compileSearchPattern(`x*`)
+	if matches := searchWindow(window, re, maxSearchMatches); len(matches) != 0 {
+		t.Errorf("expected no matches for empty-matching pattern, got %+v", matches)
+	}
+}
+
+func TestSearchWindowLimit(t *testing.T) {
+	window := newTestCopyWindow(t, 20, 3, "aaaa\r\naaaa")
+
+	re, _ := compileSearchPattern(`a`)
+	if matches := searchWindow(window, re, 3); len(matches) != 3 {
+		t.Errorf("got %d matches, want 3", len(matches))
+	}
+}
+
+func TestGlobalSearchDropsStaleQueries(t *testing.T) {
+	window := newTestCopyWindow(t, 30, 4, "pod-a Running\r\npod-b CrashLoop")
+	o := &app.OS{Windows: []*terminal.Window{window}}
+	OpenGlobalSearch(o)
+
+	var cmds []tea.Cmd
+	for _, r := range "pod-b" {
+		_, cmd := HandleGlobalSearchKey(tea.KeyPressMsg{Code: r, Text: string(r)}, o)
+		cmds = append(cmds, cmd)
+	}
+	if len(o.GlobalSearch.Hits) != 0 {
+		t.Fatal("typing should not search before the query settles")
+	}
+
+	// Only the search scheduled by the last key runs
+	handleGlobalSearchMsg(app.GlobalSearchMsg{Generation: 1}, o)
+	if len(o.GlobalSearch.Hits) != 0 {
+		t.Error("a stale search should be dropped")
+	}
+	msg := cmds[len(cmds)-1]()
+	handleGlobalSearchMsg(msg.(app.GlobalSearchMsg), o)
+	if len(o.GlobalSearch.Hits) != 1 || o.GlobalSearch.Hits[0].Match.Text != "pod-b" {
+		t.Errorf("hits = %+v, want one match of pod-b", o.GlobalSearch.Hits)
+	}
+}
@@ -30,12 +30,20 @@ func enterVisualLine(cm *terminal.CopyMode, window *terminal.Window) {
 	cm.VisualEnd = terminal.Position{X: endX, Y: absY}
 }
 
+// enterVisualBlock enters visual block (rectangular) selection mode
+func enterVisualBlock(cm *terminal.CopyMode, window *terminal.Window) {
+	cm.State = terminal.CopyModeVisualBlock
+	absY := getAbsoluteY(cm, window)
+	cm.VisualStart = terminal.Position{X: cm.CursorX, Y: absY}
+	cm.VisualEnd = cm.VisualStart
+}
+
 // updateVisualEnd updates the visual selection end position
 func updateVisualEnd(cm *terminal.CopyMode, window *terminal.Window) {
 	absY := getAbsoluteY(cm, window)
 
 	switch cm.State {
-	case terminal.CopyModeVisualChar:
+	case terminal.CopyModeVisualChar, terminal.CopyModeVisualBlock:
 		cm.VisualEnd = terminal.Position{X: cm.CursorX, Y: absY}
 	case terminal.CopyModeVisualLine:
 		// For visual line mode, we need to select entire lines
@@ -72,6 +80,10 @@ func updateVisualEnd(cm *terminal.CopyMode, window *terminal.Window) {
 
 // extractVisualText extracts the text from the current visual selection
 func extractVisualText(cm *terminal.CopyMode, window *terminal.Window) string {
+	if cm.State == terminal.CopyModeVisualBlock {
+		return extractBlockText(cm, window)
+	}
+
 	start, end := cm.VisualStart, cm.VisualEnd
 
 	// Normalize selection
@@ -203,6 +215,44 @@ func extractVisualText(cm *terminal.CopyMode, window *terminal.Window) string {
 	return strings.TrimSpace(text.String())
 }
 
+// extractBlockText extracts the rectangle spanned by the visual selection.
+// Each line contributes the same column range; trailing blanks are trimmed per line
+// so columns copied out of tables don't carry padding.
+func extractBlockText(cm *terminal.CopyMode, window *terminal.Window) string {
+	startY, endY := min(cm.VisualStart.Y, cm.VisualEnd.Y), max(cm.VisualStart.Y, cm.VisualEnd.Y)
+	startX, endX := min(cm.VisualStart.X, cm.VisualEnd.X), max(cm.VisualStart.X, cm.VisualEnd.X)
+
+	lines := make([]string, 0, endY-startY+1)
+	for y := startY; y <= endY; y++ {
+		cells := getLineCells(window, y)
+
+		var line strings.Builder
+		for x := startX; x <= endX && x < len(cells); x++ {
+			// Skip continuation cells of wide characters
+			if cells[x].Width == 0 {
+				continue
+			}
+			if cells[x].Content == "" {
+				line.WriteRune(' ')
+			} else {
+				line.WriteString(cells[x].Content)
+			}
+		}
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// getLineCells returns the cells of an absolute line (scrollback or screen)
+func getLineCells(window *terminal.Window, absY int) []uv.Cell {
+	scrollbackLen := window.ScrollbackLen()
+	if absY < scrollbackLen {
+		return window.ScrollbackLine(absY)
+	}
+	return getScreenLineCells(window.Terminal, absY-scrollbackLen)
+}
+
 // getLineContentBounds returns the X positions of the first and last non-empty characters on a line
 func getLineContentBounds(_ *terminal.CopyMode, window *terminal.Window, absY int) (int, int) {
 	scrollbackLen := window.ScrollbackLen()
@@ -0,0 +1,73 @@
+package input
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/app"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+	"github.com/Gaurav-Gosain/tuios/internal/vt"
+)
+
+func newTestCopyWindow(t *testing.T, width, height int, content string) *terminal.Window {
+	t.Helper()
+	emu := vt.NewEmulator(width, height)
+	if _, err := emu.Write([]byte(content)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	return &terminal.Window{Width: width + 2, Height: height + 2, Terminal: emu}
+}
+
+func TestExtractBlockText(t *testing.T) {
+	window := newTestCopyWindow(t, 30, 5,
+		"NAME    READY   STATUS\r\npod-a   1/1     Running\r\npod-b   0/1     Pending")
+
+	cm := &terminal.CopyMode{
+		State:       terminal.CopyModeVisualBlock,
+		VisualStart: terminal.Position{X: 16, Y: 2},
+		VisualEnd:   terminal.Position{X: 22, Y: 0},
+	}
+
+	got := extractVisualText(cm, window)
+	want := "STATUS\nRunning\nPending"
+	if got != want {
+		t.Errorf("extractVisualText(block) = %q, want %q", got, want)
+	}
+}
+
+func TestExtractBlockTextTrimsTrailingBlanks(t *testing.T) {
+	window := newTestCopyWindow(t, 20, 3, "ab\r\nabcdef")
+
+	cm := &terminal.CopyMode{
+		State:       terminal.CopyModeVisualBlock,
+		VisualStart: terminal.Position{X: 1, Y: 0},
+		VisualEnd:   terminal.Position{X: 4, Y: 1},
+	}
+
+	got := extractVisualText(cm, window)
+	want := "b\nbcde"
+	if got != want {
+		t.Errorf("extractVisualText(block) = %q, want %q", got, want)
+	}
+}
+
+func TestVisualYankNotification(t *testing.T) {
+	window := newTestCopyWindow(t, 20, 3, "hello")
+	cm := &terminal.CopyMode{
+		State:       terminal.CopyModeVisualChar,
+		VisualStart: terminal.Position{X: 0, Y: 0},
+		VisualEnd:   terminal.Position{X: 4, Y: 0},
+	}
+	o := &app.OS{}
+
+	for _, key := range []rune{'"', 'a', 'y'} {
+		handleVisualInput(tea.KeyPressMsg{Code: key, Text: string(key)}, cm, window, o)
+	}
+	if got, _ := o.GetRegister('a'); got != "hello" {
+		t.Errorf("register a = %q, want hello", got)
+	}
+	last := o.Notifications[len(o.Notifications)-1]
+	if want := `Yanked 5 chars into "a`; last.Message != want {
+		t.Errorf("last notification = %q, want %q", last.Message, want)
+	}
+}
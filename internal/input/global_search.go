@@ -0,0 +1,170 @@
+package input
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/app"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// maxGlobalSearchHits caps the total number of hits listed in the overlay
+const maxGlobalSearchHits = 2000
+
+// OpenGlobalSearch opens the search-across-all-windows overlay.
+func OpenGlobalSearch(o *app.OS) {
+	if len(o.Windows) == 0 {
+		o.ShowNotification("No windows to search", "warning", config.NotificationDuration)
+		return
+	}
+	o.GlobalSearch = &app.GlobalSearchState{}
+	o.ShowGlobalSearch = true
+}
+
+// HandleGlobalSearchKey handles keyboard input when the global search overlay is open.
+func HandleGlobalSearchKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	s := o.GlobalSearch
+	if s == nil {
+		o.CloseGlobalSearch()
+		return o, nil
+	}
+
+	key := msg.Key()
+	switch msg.String() {
+	case "esc":
+		o.CloseGlobalSearch()
+	case "enter":
+		if s.SelectedIndex >= 0 && s.SelectedIndex < len(s.Hits) {
+			hit := s.Hits[s.SelectedIndex]
+			query := s.Query
+			o.CloseGlobalSearch()
+			jumpToGlobalSearchHit(o, hit, query)
+		}
+	case "up", "ctrl+p", "ctrl+k":
+		if s.SelectedIndex > 0 {
+			s.SelectedIndex--
+		}
+	case "down", "ctrl+n", "ctrl+j":
+		if s.SelectedIndex < len(s.Hits)-1 {
+			s.SelectedIndex++
+		}
+	case "backspace":
+		if len(s.Query) > 0 {
+			runes := []rune(s.Query)
+			s.Query = string(runes[:len(runes)-1])
+			return o, scheduleGlobalSearch(s)
+		}
+	default:
+		if key.Text != "" {
+			s.Query += key.Text
+			return o, scheduleGlobalSearch(s)
+		}
+	}
+	return o, nil
+}
+
+// scheduleGlobalSearch searches once the query has not changed for
+// GlobalSearchDelay, so typing a query scans the windows only once.
+func scheduleGlobalSearch(s *app.GlobalSearchState) tea.Cmd {
+	s.Generation++
+	generation := s.Generation
+	return tea.Tick(config.GlobalSearchDelay, func(time.Time) tea.Msg {
+		return app.GlobalSearchMsg{Generation: generation}
+	})
+}
+
+// handleGlobalSearchMsg runs a scheduled search unless the query changed
+// or the overlay closed since it was scheduled.
+func handleGlobalSearchMsg(msg app.GlobalSearchMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	if o.GlobalSearch != nil && o.GlobalSearch.Generation == msg.Generation {
+		runGlobalSearch(o)
+	}
+	return o, nil
+}
+
+// runGlobalSearch searches every window in every workspace for the current query
+func runGlobalSearch(o *app.OS) {
+	s := o.GlobalSearch
+	s.Hits = nil
+	s.SelectedIndex = 0
+	s.ScrollOffset = 0
+
+	re, _ := compileSearchPattern(s.Query)
+	if re == nil {
+		return
+	}
+
+	for _, window := range o.Windows {
+		remaining := maxGlobalSearchHits - len(s.Hits)
+		if remaining <= 0 {
+			break
+		}
+		for _, match := range searchWindow(window, re, min(remaining, maxSearchMatches)) {
+			s.Hits = append(s.Hits, app.GlobalSearchHit{
+				WindowID:    window.ID,
+				WindowTitle: windowDisplayName(window),
+				Workspace:   window.Workspace,
+				Match:       match,
+				LineText:    getLineText(nil, window, match.Line),
+			})
+		}
+	}
+}
+
+// jumpToGlobalSearchHit focuses the window containing hit (switching workspace
+// and restoring it if needed) and enters copy mode at the matching line with
+// all matches of query highlighted.
+func jumpToGlobalSearchHit(o *app.OS, hit app.GlobalSearchHit, query string) {
+	index := -1
+	for i, window := range o.Windows {
+		if window.ID == hit.WindowID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		o.ShowNotification("Window no longer exists", "warning", config.NotificationDuration)
+		return
+	}
+
+	window := o.Windows[index]
+	o.SwitchToWorkspace(window.Workspace)
+	if window.Minimized {
+		o.RestoreWindow(index)
+		if o.AutoTiling {
+			o.TileAllWindows()
+		}
+	}
+	o.FocusWindow(index)
+
+	window.EnterCopyMode()
+	cm := window.CopyMode
+	cm.SearchQuery = query
+
+	re, caseSensitive := compileSearchPattern(query)
+	cm.CaseSensitive = caseSensitive
+	cm.SearchMatches = searchWindow(window, re, maxSearchMatches)
+
+	// Scrollback may have changed since the search ran; fall back to the first match
+	cm.CurrentMatch = 0
+	for i, match := range cm.SearchMatches {
+		if match.Line == hit.Match.Line && match.StartX == hit.Match.StartX {
+			cm.CurrentMatch = i
+			break
+		}
+	}
+	jumpToMatch(cm, window, cm.CurrentMatch)
+	window.InvalidateCache()
+
+	o.ShowNotification(fmt.Sprintf("/%s (%d matches)", query, len(cm.SearchMatches)), "info", config.NotificationDuration)
+}
+
+// windowDisplayName returns the name shown for a window in overlays
+func windowDisplayName(window *terminal.Window) string {
+	if window.CustomName != "" {
+		return window.CustomName
+	}
+	return window.Title
+}
@@ -23,6 +23,8 @@ func HandleInput(msg tea.Msg, o *app.OS) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
 		result, cmd = HandleKeyPress(msg, o)
+	case app.GlobalSearchMsg:
+		return handleGlobalSearchMsg(msg, o)
 	case tea.PasteStartMsg:
 		return o, nil
 	case tea.PasteEndMsg:
@@ -160,7 +162,7 @@ func HandleKeyPress(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 
 	// Record keystrokes when recording is active (before any other handling)
 	// Only record in terminal mode - WM mode actions are recorded at dispatch time
-	if o.TapeRecorder != nil && o.TapeRecorder.IsRecording() && !o.ShowTapeManager {
+	if o.TapeRecorder != nil && o.TapeRecorder.IsRecording() && !o.ShowTapeManager && o.LayoutPrompt == "" && !o.ShowWorkspacePicker && !o.ShowCommandPalette && !o.ShowWindowSwitcher && !o.ShowThemeEditor {
 		if o.Mode == app.TerminalMode {
 			keyStr := msg.String()
 			// Skip workspace switch keys - they're recorded by SwitchToWorkspace
@@ -194,6 +196,46 @@ func HandleKeyPress(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 		return handleRenameMode(msg, o)
 	}
 
+	// Handle layout name prompt (save_layout / load_layout)
+	if o.LayoutPrompt != "" {
+		return handleLayoutPrompt(msg, o)
+	}
+
+	// Handle register name after paste_register (Ctrl+B ])
+	if o.PendingRegisterPaste {
+		return handlePendingRegisterPaste(msg, o)
+	}
+
+	// Handle search across all windows overlay
+	if o.ShowGlobalSearch {
+		return HandleGlobalSearchKey(msg, o)
+	}
+
+	// Handle saved history picker overlay
+	if o.ShowHistoryBrowser {
+		return HandleHistoryBrowserKey(msg, o)
+	}
+
+	// Handle workspace picker and rename prompt
+	if o.ShowWorkspacePicker {
+		return HandleWorkspacePickerKey(msg, o)
+	}
+
+	// Handle command palette
+	if o.ShowCommandPalette {
+		return HandleCommandPaletteKey(msg, o)
+	}
+
+	// Handle window switcher
+	if o.ShowWindowSwitcher {
+		return HandleWindowSwitcherKey(msg, o)
+	}
+
+	// Handle theme editor
+	if o.ShowThemeEditor {
+		return HandleThemeEditorKey(msg, o)
+	}
+
 	// Terminal mode handling
 	if o.Mode == app.TerminalMode {
 		return HandleTerminalModeKey(msg, o)
@@ -206,6 +248,11 @@ func HandleKeyPress(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 		return handlePrefixKey(msg, o)
 	}
 
+	// Handle user-defined key tables (Ctrl+B, <table key>, ...)
+	if o.ActiveKeyTable != "" {
+		return HandleKeyTableKey(msg, o)
+	}
+
 	// Handle workspace prefix commands (Ctrl+B, w, ...)
 	if o.WorkspacePrefixActive {
 		return HandleWorkspacePrefixCommand(msg, o)
@@ -276,11 +323,53 @@ func handleRenameMode(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	}
 }
 
+// handleLayoutPrompt handles input while asking for a layout name to save or load
+func handleLayoutPrompt(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		mode, name := o.LayoutPrompt, o.LayoutPromptBuffer
+		o.CloseLayoutPrompt()
+		if mode == "load" {
+			if err := o.LoadNamedLayout(name); err != nil {
+				o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+				return o, nil
+			}
+			o.ShowNotification("Loaded layout "+name, "info", config.NotificationDuration)
+			return o, nil
+		}
+		if err := o.SaveNamedLayout(name); err != nil {
+			o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+			return o, nil
+		}
+		o.ShowNotification("Saved layout "+name, "info", config.NotificationDuration)
+		return o, nil
+	case "esc":
+		o.CloseLayoutPrompt()
+		return o, nil
+	case "tab":
+		o.CompleteLayoutPrompt()
+		return o, nil
+	case "backspace":
+		if len(o.LayoutPromptBuffer) > 0 {
+			o.LayoutPromptBuffer = o.LayoutPromptBuffer[:len(o.LayoutPromptBuffer)-1]
+		}
+		return o, nil
+	default:
+		if len(msg.String()) == 1 && msg.String()[0] >= 32 && msg.String()[0] < 127 {
+			o.LayoutPromptBuffer += msg.String()
+		} else if msg.String() == "space" {
+			o.LayoutPromptBuffer += " "
+		}
+		return o, nil
+	}
+}
+
 // handlePrefixKey handles Ctrl+B prefix key activation
 func handlePrefixKey(_ tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	// If prefix is already active, deactivate it (double leader key cancels)
 	if o.PrefixActive {
 		o.PrefixActive = false
+		o.ActiveKeyTable = ""
 		return o, nil
 	}
 	// Activate prefix mode
@@ -294,6 +383,11 @@ func HandlePrefixCommand(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	// Deactivate prefix after handling command
 	o.PrefixActive = false
 
+	// User-defined key tables take precedence over the built-in commands
+	if enterKeyTableForKey(msg, o) {
+		return o, nil
+	}
+
 	switch msg.String() {
 	case "w":
 		// Activate workspace prefix mode
@@ -375,7 +469,7 @@ func HandlePrefixCommand(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 		return o, nil
 	case "z":
 		// Toggle fullscreen for current window
-		if !o.AutoTiling && len(o.Windows) > 0 && o.FocusedWindow >= 0 {
+		if focusedMovesFreely(o) {
 			o.Snap(o.FocusedWindow, app.SnapFullScreen)
 		}
 		return o, nil
@@ -416,6 +510,25 @@ func HandlePrefixCommand(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 			o.ShowNotification("COPY MODE (hjkl/q)", "info", 2*time.Second)
 		}
 		return o, nil
+	case "]":
+		// Paste from a yank register (prompts for register name)
+		return handlePasteRegister(msg, o)
+	case "/":
+		// Search scrollback of all windows
+		OpenGlobalSearch(o)
+		return o, nil
+	case "H":
+		// Browse saved history of closed windows
+		OpenHistoryBrowser(o)
+		return o, nil
+	case ":":
+		// Open the command palette
+		OpenCommandPalette(o)
+		return o, nil
+	case "f":
+		// Find a window in the window switcher
+		o.OpenWindowSwitcher()
+		return o, nil
 
 	// Help
 	case "?":
@@ -0,0 +1,95 @@
+package input
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/app"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/scrollback"
+	"github.com/Gaurav-Gosain/tuios/internal/terminal"
+)
+
+// OpenHistoryBrowser opens the picker listing saved scrollback histories of
+// closed windows.
+func OpenHistoryBrowser(o *app.OS) {
+	entries, err := terminal.ListHistory(config.GetHistoryDirectory())
+	if err != nil {
+		o.ShowNotification(fmt.Sprintf("Failed to list history: %v", err), "error", config.NotificationDuration)
+		return
+	}
+
+	// Windows that are still open are browsed directly, not from disk
+	open := make(map[string]bool, len(o.Windows))
+	for _, w := range o.Windows {
+		if path := w.HistoryPath(); path != "" {
+			open[path] = true
+		}
+	}
+	closed := entries[:0]
+	for _, entry := range entries {
+		if !open[entry.Path] {
+			closed = append(closed, entry)
+		}
+	}
+
+	o.HistoryBrowser = &app.HistoryBrowserState{Entries: closed}
+	o.ShowHistoryBrowser = true
+}
+
+// HandleHistoryBrowserKey handles keyboard input when the history picker is open.
+func HandleHistoryBrowserKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	s := o.HistoryBrowser
+	if s == nil {
+		o.CloseHistoryBrowser()
+		return o, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		o.CloseHistoryBrowser()
+	case "up", "k", "ctrl+p":
+		if s.SelectedIndex > 0 {
+			s.SelectedIndex--
+		}
+	case "down", "j", "ctrl+n":
+		if s.SelectedIndex < len(s.Entries)-1 {
+			s.SelectedIndex++
+		}
+	case "enter":
+		if s.SelectedIndex >= 0 && s.SelectedIndex < len(s.Entries) {
+			entry := s.Entries[s.SelectedIndex]
+			o.CloseHistoryBrowser()
+			openSavedHistory(o, entry)
+		}
+	case "d":
+		if s.SelectedIndex >= 0 && s.SelectedIndex < len(s.Entries) {
+			entry := s.Entries[s.SelectedIndex]
+			if err := terminal.DeleteHistory(entry); err != nil {
+				o.ShowNotification(err.Error(), "error", config.NotificationDuration)
+				return o, nil
+			}
+			s.Entries = append(s.Entries[:s.SelectedIndex], s.Entries[s.SelectedIndex+1:]...)
+			s.SelectedIndex = max(min(s.SelectedIndex, len(s.Entries)-1), 0)
+			o.ShowNotification(fmt.Sprintf("Deleted history of %s", entry.Title), "info", config.NotificationDuration)
+		}
+	}
+	return o, nil
+}
+
+// openSavedHistory opens a saved history read-only in the scrollback browser.
+// Histories without detectable prompts are shown as a single transcript block.
+func openSavedHistory(o *app.OS, entry terminal.HistoryInfo) {
+	term, h, err := terminal.OpenHistory(entry)
+	if err != nil {
+		o.ShowNotification(fmt.Sprintf("Failed to open history: %v", err), "error", config.NotificationDuration)
+		return
+	}
+	blocks := parseScrollbackBlocks(o, term)
+	if len(blocks) == 0 {
+		blocks = []scrollback.CommandBlock{scrollback.TranscriptBlock(term, entry.Title)}
+	}
+	// The file stays open while the browser is shown so blocks can be exported
+	browser := showScrollbackBrowser(o, term, entry.Title, blocks)
+	browser.SetOnClose(func() { _ = h.Close() })
+}
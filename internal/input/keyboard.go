@@ -151,15 +151,15 @@ func HandleTerminalModeKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	}
 
 	// Check for prefix key in terminal mode
-	msgStr := strings.ToLower(msg.String())
-	leaderKey := strings.ToLower(config.LeaderKey)
-	if msgStr == leaderKey {
+	// A passthrough rule for the focused program may disable or swap the leader
+	if o.IsLeaderKey(msg.String()) {
 		// If prefix is already active, send the leader key to terminal
 		if o.PrefixActive {
 			o.PrefixActive = false
+			o.ActiveKeyTable = ""
 			if focusedWindow != nil {
 				// Send literal leader key (default Ctrl+B = 0x02)
-				_ = focusedWindow.SendInput([]byte{0x02})
+				_ = focusedWindow.SendInput(getRawKeyBytes(msg))
 			}
 			return o, nil
 		}
@@ -169,6 +169,11 @@ func HandleTerminalModeKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 		return o, nil
 	}
 
+	// Handle user-defined key tables (Ctrl+B, <table key>, ...)
+	if o.ActiveKeyTable != "" {
+		return HandleKeyTableKey(msg, o)
+	}
+
 	// Handle workspace prefix commands (Ctrl+B, w, ...)
 	if o.WorkspacePrefixActive {
 		return handleTerminalWorkspacePrefix(msg, o)
@@ -199,6 +204,16 @@ func HandleTerminalModeKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 		return handleTerminalPrefixCommand(msg, o)
 	}
 
+	// Keys a passthrough rule claims go straight to the focused program
+	if o.PassesKeyThrough(msg.String()) {
+		return sendKeyToFocusedWindow(msg, o)
+	}
+
+	// Navigate keys move focus to the neighbouring window
+	if o.NavigateKey(msg.String()) {
+		return o, nil
+	}
+
 	// Handle Alt+1-9 workspace switching in terminal mode
 	// Don't send workspace switching keys to the PTY
 	handled := handleWorkspaceSwitch(msg, o)
@@ -216,6 +231,14 @@ func HandleTerminalModeKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 		return o, nil
 	}
 
+	// Handle scratchpad keys and macros before they reach the PTY
+	if handleScratchpadKey(msg, o) {
+		return o, nil
+	}
+	if handled, cmd := handleMacroKey(msg, o); handled {
+		return o, cmd
+	}
+
 	// Handle paste shortcuts - intercept and request clipboard via OSC 52
 	keyStr := msg.String()
 	if keyStr == "ctrl+v" || keyStr == "ctrl+shift+v" || keyStr == "super+v" || keyStr == "super+shift+v" {
@@ -228,6 +251,13 @@ func HandleTerminalModeKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	}
 
 	// Normal terminal mode - pass through all keys
+	return sendKeyToFocusedWindow(msg, o)
+}
+
+// sendKeyToFocusedWindow writes a key press to the focused window's PTY,
+// falling back to window mode if there is no terminal to send it to.
+func sendKeyToFocusedWindow(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	focusedWindow := o.GetFocusedWindow()
 	if focusedWindow != nil {
 		// Check if the terminal has DECCKM (application cursor keys) mode enabled
 		appCursorKeys := false
@@ -263,6 +293,15 @@ func handleTerminalWorkspacePrefix(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea
 		return o, nil
 	}
 
+	switch keyStr {
+	case "w":
+		o.OpenWorkspacePicker()
+		return o, nil
+	case "r":
+		o.OpenWorkspaceRename()
+		return o, nil
+	}
+
 	// Handle Shift+digit for moving window to workspace
 	if o.FocusedWindow >= 0 && o.FocusedWindow < len(o.Windows) {
 		workspace := 0
@@ -359,6 +398,40 @@ func handleTerminalTilingPrefix(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cm
 			o.TileAllWindows()
 		}
 		return o, nil
+	case "l":
+		return handleNextLayout(msg, o)
+	case "L":
+		return handlePrevLayout(msg, o)
+	case "i":
+		return handleIncreaseMasters(msg, o)
+	case "d":
+		return handleDecreaseMasters(msg, o)
+	case "f":
+		return handleToggleFloating(msg, o)
+	case "z":
+		return handleToggleZoom(msg, o)
+	case "y":
+		return handleToggleSticky(msg, o)
+	case "b":
+		return handleToggleTabbed(msg, o)
+	case "v":
+		return handleToggleStacked(msg, o)
+	case "o":
+		return handleNextTab(msg, o)
+	case "O":
+		return handlePrevTab(msg, o)
+	case "a":
+		return handleJoinGroup(msg, o)
+	case "A":
+		return handleLeaveGroup(msg, o)
+	case "u":
+		return handleUndoLayout(msg, o)
+	case "U":
+		return handleRedoLayout(msg, o)
+	case "s":
+		return handleSaveLayout(msg, o)
+	case "p":
+		return handleLoadLayout(msg, o)
 	case "esc":
 		// Cancel tiling prefix mode
 		return o, nil
@@ -383,6 +456,12 @@ func handleTerminalTapePrefix(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd)
 // handleTerminalPrefixCommand handles prefix commands in terminal mode
 func handleTerminalPrefixCommand(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	o.PrefixActive = false
+
+	// User-defined key tables take precedence over the built-in commands
+	if enterKeyTableForKey(msg, o) {
+		return o, nil
+	}
+
 	switch msg.String() {
 	case "w":
 		// Activate workspace prefix mode
@@ -503,7 +582,7 @@ func handleTerminalPrefixCommand(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.C
 		return o, nil
 	case "z":
 		// Toggle fullscreen for current window
-		if !o.AutoTiling && len(o.Windows) > 0 && o.FocusedWindow >= 0 {
+		if focusedMovesFreely(o) {
 			o.Snap(o.FocusedWindow, app.SnapFullScreen)
 		}
 		return o, nil
@@ -542,10 +621,29 @@ func handleTerminalPrefixCommand(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.C
 			o.ShowNotification("COPY MODE (hjkl/q)", "info", config.NotificationDuration*2)
 		}
 		return o, nil
+	case "]":
+		// Paste from a yank register (prompts for register name)
+		return handlePasteRegister(msg, o)
+	case "/":
+		// Search scrollback of all windows
+		OpenGlobalSearch(o)
+		return o, nil
 	case "s":
 		// Open scrollback browser
 		OpenScrollbackBrowser(o)
 		return o, nil
+	case "H":
+		// Browse saved history of closed windows
+		OpenHistoryBrowser(o)
+		return o, nil
+	case ":":
+		// Open the command palette
+		OpenCommandPalette(o)
+		return o, nil
+	case "f":
+		// Find a window in the window switcher
+		o.OpenWindowSwitcher()
+		return o, nil
 
 	// Help
 	case "?":
@@ -737,6 +835,14 @@ func HandleWindowManagementModeKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea
 		return o, nil
 	}
 
+	// Scratchpad keys and macros work the same in window and terminal mode
+	if handleScratchpadKey(msg, o) {
+		return o, nil
+	}
+	if handled, cmd := handleMacroKey(msg, o); handled {
+		return o, cmd
+	}
+
 	// Try config-based dispatch first (if registry is available)
 	if o.KeybindRegistry != nil {
 		action := o.KeybindRegistry.GetAction(key)
@@ -787,7 +893,7 @@ func HandleMinimizePrefixCommand(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.C
 	// Get list of minimized windows in current workspace
 	var minimizedWindows []int
 	for i, win := range o.Windows {
-		if win.Minimized && win.Workspace == o.CurrentWorkspace {
+		if win.Minimized && win.Workspace == o.CurrentWorkspace && win.Scratchpad == "" {
 			minimizedWindows = append(minimizedWindows, i)
 		}
 	}
@@ -883,6 +989,40 @@ func HandleTilingPrefixCommand(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd
 			o.ShowNotification("Tiling Mode Disabled", "info", config.NotificationDuration)
 		}
 		return o, nil
+	case "l":
+		return handleNextLayout(msg, o)
+	case "L":
+		return handlePrevLayout(msg, o)
+	case "i":
+		return handleIncreaseMasters(msg, o)
+	case "d":
+		return handleDecreaseMasters(msg, o)
+	case "f":
+		return handleToggleFloating(msg, o)
+	case "z":
+		return handleToggleZoom(msg, o)
+	case "y":
+		return handleToggleSticky(msg, o)
+	case "b":
+		return handleToggleTabbed(msg, o)
+	case "v":
+		return handleToggleStacked(msg, o)
+	case "o":
+		return handleNextTab(msg, o)
+	case "O":
+		return handlePrevTab(msg, o)
+	case "a":
+		return handleJoinGroup(msg, o)
+	case "A":
+		return handleLeaveGroup(msg, o)
+	case "u":
+		return handleUndoLayout(msg, o)
+	case "U":
+		return handleRedoLayout(msg, o)
+	case "s":
+		return handleSaveLayout(msg, o)
+	case "p":
+		return handleLoadLayout(msg, o)
 	case "esc":
 		// Cancel tiling prefix mode
 		return o, nil
@@ -1043,6 +1183,21 @@ func handleModeSwitch(msg tea.KeyPressMsg, o *app.OS) bool {
 	return false
 }
 
+// handleScratchpadKey toggles the scratchpad bound to the pressed key, if any.
+func handleScratchpadKey(msg tea.KeyPressMsg, o *app.OS) bool {
+	if o.KeybindRegistry == nil {
+		return false
+	}
+	name := o.KeybindRegistry.GetScratchpadForKey(msg.String())
+	if name == "" {
+		return false
+	}
+	if err := o.ToggleScratchpad(name); err != nil {
+		o.ShowNotification(err.Error(), "error", config.NotificationDuration)
+	}
+	return true
+}
+
 // handleWindowCycle handles Alt+Tab/Opt+Tab window cycling in terminal mode.
 // This allows cycling through windows without needing the prefix key.
 // On macOS, opt+tab produces ⇥ and opt+shift+tab produces ⇤.
@@ -0,0 +1,36 @@
+package input
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/app"
+)
+
+// enterKeyTableForKey enters the user-defined key table bound to a key after
+// the leader key. It reports whether the key enters a table.
+func enterKeyTableForKey(msg tea.KeyPressMsg, o *app.OS) bool {
+	if o.KeybindRegistry == nil {
+		return false
+	}
+	name := o.KeybindRegistry.GetKeyTableForKey(msg.String())
+	if name == "" {
+		return false
+	}
+	o.EnterKeyTable(name)
+	return true
+}
+
+// HandleKeyTableKey handles a key while a user-defined key table is active,
+// in window management and terminal mode.
+func HandleKeyTableKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	name := o.ActiveKeyTable
+	o.LeaveKeyTable()
+	if msg.String() == "esc" || o.KeybindRegistry == nil {
+		return o, nil
+	}
+
+	target := o.KeybindRegistry.GetKeyTableBinding(name, msg.String())
+	if target == "" {
+		return o, nil
+	}
+	return runBindingTarget(target, msg, o)
+}
@@ -0,0 +1,93 @@
+package input
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/app"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+)
+
+// TestKeyTables tests entering a user-defined key table after the leader
+// key, nesting tables and running actions and tape commands from them
+func TestKeyTables(t *testing.T) {
+	defer func() { config.AnimationsSuppressed = false }()
+
+	cfg := config.DefaultConfig()
+	cfg.Keybindings.Tables = map[string]config.KeyTable{
+		"git": {
+			Key: "g",
+			Bindings: map[string]string{
+				"s": "tape: Type 'git status'",
+				"b": "table:branch",
+			},
+		},
+		"branch": {
+			Bindings: map[string]string{"p": "workspace_picker"},
+		},
+	}
+	o := app.NewOS(app.OSOptions{KeybindRegistry: config.NewKeybindRegistry(cfg), Width: 120, Height: 40})
+	key := func(s string) tea.KeyPressMsg { return tea.KeyPressMsg{Code: rune(s[0]), Text: s} }
+
+	o.PrefixActive = true
+	HandlePrefixCommand(key("g"), o)
+	if o.ActiveKeyTable != "git" || !o.PrefixActive {
+		t.Fatalf("ctrl+b g should enter the git table, got %q", o.ActiveKeyTable)
+	}
+
+	HandleKeyTableKey(key("b"), o)
+	if o.ActiveKeyTable != "branch" {
+		t.Fatalf("b should enter the nested branch table, got %q", o.ActiveKeyTable)
+	}
+	HandleKeyTableKey(key("p"), o)
+	if o.ActiveKeyTable != "" || o.PrefixActive || !o.ShowWorkspacePicker {
+		t.Error("p should leave the tables and run the workspace picker action")
+	}
+	o.CloseWorkspacePicker()
+
+	o.EnterKeyTable("git")
+	if _, cmd := HandleKeyTableKey(key("s"), o); cmd == nil {
+		t.Error("a tape binding should start running its commands")
+	}
+
+	o.EnterKeyTable("git")
+	HandleKeyTableKey(tea.KeyPressMsg{Code: tea.KeyEscape}, o)
+	if o.ActiveKeyTable != "" || o.PrefixActive {
+		t.Error("esc should leave the table")
+	}
+
+	o.EnterKeyTable("git")
+	HandleKeyTableKey(key("z"), o)
+	if o.ActiveKeyTable != "" {
+		t.Error("an unbound key should leave the table")
+	}
+}
+
+// TestMacros tests that macro keys run their targets in window and terminal
+// mode before the keys reach normal dispatch or the focused window
+func TestMacros(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Keybindings.Tables = map[string]config.KeyTable{"git": {Bindings: map[string]string{"p": "workspace_picker"}}}
+	cfg.Keybindings.Macros = map[string]string{
+		"alt+g": "table:git",
+		"alt+k": "send:kubectl get pods\n",
+	}
+	o := app.NewOS(app.OSOptions{KeybindRegistry: config.NewKeybindRegistry(cfg), Width: 120, Height: 40})
+	alt := func(r rune) tea.KeyPressMsg { return tea.KeyPressMsg{Code: r, Mod: tea.ModAlt} }
+
+	HandleWindowManagementModeKey(alt('g'), o)
+	if o.ActiveKeyTable != "git" {
+		t.Fatalf("alt+g should enter the git table, got %q", o.ActiveKeyTable)
+	}
+	HandleKeyTableKey(tea.KeyPressMsg{Code: 'p', Text: "p"}, o)
+	if !o.ShowWorkspacePicker {
+		t.Error("the table binding should run after a macro entered the table")
+	}
+	o.CloseWorkspacePicker()
+
+	o.Mode = app.TerminalMode
+	HandleTerminalModeKey(alt('k'), o)
+	if len(o.Notifications) == 0 || o.Notifications[len(o.Notifications)-1].Type != "error" {
+		t.Error("sending text without a focused window should report an error")
+	}
+}
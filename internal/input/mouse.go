@@ -61,6 +61,16 @@ func handleMouseClick(msg tea.MouseClickMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	// Fast hit testing - find which window was clicked without expensive canvas generation
 	clickedWindowIndex := findClickedWindow(X, Y, o)
 
+	// Clicking a tab of a tabbed or stacked container switches to that window,
+	// unless a floating window covers the tab
+	if clickedWindowIndex == -1 || !o.Windows[clickedWindowIndex].Floating {
+		if tab := o.GroupTabAt(X, Y); tab != -1 {
+			o.FocusWindow(tab)
+			o.SyncStateToDaemon()
+			return o, nil
+		}
+	}
+
 	// Forward mouse events to terminal if in terminal mode and window has mouse tracking
 	if clickedWindowIndex != -1 && o.Mode == app.TerminalMode {
 		clickedWindow := o.Windows[clickedWindowIndex]
@@ -121,7 +131,7 @@ func handleMouseClick(msg tea.MouseClickMsg, o *app.OS) (*app.OS, tea.Cmd) {
 			return o, nil
 		}
 
-		if o.AutoTiling {
+		if o.IsTiledWindow(clickedWindow) {
 			// Tiling mode: minimize button
 			if mouse.Button == tea.MouseLeft && X >= leftMost-7 && X <= leftMost-5 && Y == titleBarY {
 				o.MinimizeWindow(clickedWindowIndex)
@@ -274,7 +284,7 @@ func handleMouseClick(msg tea.MouseClickMsg, o *app.OS) (*app.OS, tea.Cmd) {
 
 		// In tiling mode, complete ALL pending animations to avoid state conflicts
 		// This ensures all windows are in their final positions before starting a new drag
-		if o.AutoTiling {
+		if o.IsTiledWindow(clickedWindow) {
 			o.CompleteAllAnimations()
 
 			// Store current position (after completing all animations) for tiling mode swaps
@@ -500,7 +510,7 @@ func handleMouseMotion(msg tea.MouseMotionMsg, o *app.OS) (*app.OS, tea.Cmd) {
 		newHeight = min(newHeight, maxY-newY)
 
 		// In tiling mode, block resizing edges at screen boundaries
-		if o.AutoTiling {
+		if o.IsTiledWindow(focusedWindow) {
 			const edgeTolerance = 2 // Small tolerance for detecting screen edges
 
 			// Check which edges are at screen boundaries
@@ -616,7 +626,7 @@ func handleMouseRelease(msg tea.MouseReleaseMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	}
 
 	// Handle window drop in tiling mode
-	if o.Dragging && o.AutoTiling && o.DraggedWindowIndex >= 0 && o.DraggedWindowIndex < len(o.Windows) {
+	if o.Dragging && o.AutoTiling && o.DraggedWindowIndex >= 0 && o.DraggedWindowIndex < len(o.Windows) && !o.Windows[o.DraggedWindowIndex].Floating {
 		mouse := msg.Mouse()
 
 		// Calculate drag distance to determine if this was actually a drag or just a click
@@ -630,7 +640,7 @@ func handleMouseRelease(msg tea.MouseReleaseMsg, o *app.OS) (*app.OS, tea.Cmd) {
 			// Find which window is under the cursor (excluding the dragged window)
 			targetWindowIndex := -1
 			for i := range o.Windows {
-				if i == o.DraggedWindowIndex || o.Windows[i].Minimized || o.Windows[i].Minimizing {
+				if i == o.DraggedWindowIndex || o.Windows[i].Minimized || o.Windows[i].Minimizing || o.Windows[i].Floating {
 					continue
 				}
 				// Only consider windows in current workspace
@@ -675,7 +685,7 @@ func handleMouseRelease(msg tea.MouseReleaseMsg, o *app.OS) (*app.OS, tea.Cmd) {
 	}
 
 	// Handle window edge snapping in floating mode (non-tiling)
-	if o.Dragging && !o.AutoTiling && o.DraggedWindowIndex >= 0 && o.DraggedWindowIndex < len(o.Windows) {
+	if o.Dragging && o.DraggedWindowIndex >= 0 && o.DraggedWindowIndex < len(o.Windows) && !o.IsTiledWindow(o.Windows[o.DraggedWindowIndex]) {
 		mouse := msg.Mouse()
 		dragDistance := abs(mouse.X-o.DragStartX) + abs(mouse.Y-o.DragStartY)
 		const dragThreshold = 5
@@ -749,7 +759,7 @@ func handleMouseRelease(msg tea.MouseReleaseMsg, o *app.OS) (*app.OS, tea.Cmd) {
 		}
 
 		// Mark layout as custom if resizing in tiling mode
-		if wasResizing && o.AutoTiling {
+		if wasResizing && o.IsTiledWindow(o.GetFocusedWindow()) {
 			o.MarkLayoutCustom()
 			// Sync BSP tree ratios to match the new window positions after resize
 			o.SyncBSPTreeFromGeometry()
@@ -940,16 +950,16 @@ func findClickedWindow(x, y int, o *app.OS) int {
 		if window.Workspace != o.CurrentWorkspace {
 			continue
 		}
-		// Skip minimized windows
-		if window.Minimized {
+		// Skip minimized windows and windows behind a container's active tab
+		if window.Minimized || o.IsHiddenInGroup(window) {
 			continue
 		}
 		// Check if click is within window bounds
 		if x >= window.X && x < window.X+window.Width &&
 			y >= window.Y && y < window.Y+window.Height {
 			// This window contains the click - check if it's the topmost so far
-			if window.Z > topZ {
-				topZ = window.Z
+			if z := o.StackZ(window); z > topZ {
+				topZ = z
 				topWindow = i
 			}
 		}
@@ -8,6 +8,7 @@ import (
 	"github.com/Gaurav-Gosain/tuios/internal/app"
 	"github.com/Gaurav-Gosain/tuios/internal/config"
 	"github.com/Gaurav-Gosain/tuios/internal/scrollback"
+	"github.com/Gaurav-Gosain/tuios/internal/vt"
 )
 
 // HandleScrollbackBrowserKey handles keyboard input when the scrollback browser is open.
@@ -56,6 +57,15 @@ func HandleScrollbackBrowserKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cm
 		return o, nil
 	}
 
+	// Export: waiting for the format key
+	if browser.ExportPending {
+		browser.ExportPending = false
+		if format, ok := browserExportFormats[keyStr]; ok {
+			exportBrowserBlocks(o, browser, format)
+		}
+		return o, nil
+	}
+
 	// Output mode: navigate lines in right pane
 	if browser.OutputMode {
 		return handleBrowserOutputModeKey(keyStr, browser, o)
@@ -65,8 +75,7 @@ func HandleScrollbackBrowserKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cm
 	switch keyStr {
 	// Close
 	case "q", "esc":
-		o.ShowScrollbackBrowser = false
-		o.ScrollbackBrowser = nil
+		closeScrollbackBrowser(o)
 		return o, nil
 
 	// Navigation
@@ -151,6 +160,12 @@ func HandleScrollbackBrowserKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cm
 			return o, tea.SetClipboard(text)
 		}
 
+	// Export selected command blocks
+	case "e":
+		if browser.Mode == scrollback.ModeCommands && browser.Term != nil && len(browser.ExportBlocks()) > 0 {
+			browser.ExportPending = true
+		}
+
 	// Paste selected command back to terminal
 	case "enter":
 		text := browser.SelectedCommandText()
@@ -163,8 +178,7 @@ func HandleScrollbackBrowserKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cm
 		}
 
 		// Close browser first
-		o.ShowScrollbackBrowser = false
-		o.ScrollbackBrowser = nil
+		closeScrollbackBrowser(o)
 
 		// Send text to terminal (with bracketed paste if supported)
 		data := []byte(text)
@@ -190,8 +204,21 @@ func OpenScrollbackBrowser(o *app.OS) {
 		return
 	}
 
-	term := focusedWindow.Terminal
+	blocks := parseScrollbackBlocks(o, focusedWindow.Terminal)
+	if len(blocks) == 0 {
+		o.ShowNotification("No commands found in scrollback", "info", 2*time.Second)
+		return
+	}
+	title := focusedWindow.CustomName
+	if title == "" {
+		title = focusedWindow.Title
+	}
+	showScrollbackBrowser(o, focusedWindow.Terminal, title, blocks)
+}
 
+// parseScrollbackBlocks parses a terminal's history into command blocks,
+// logging parser diagnostics to the log viewer.
+func parseScrollbackBlocks(o *app.OS, term *vt.Emulator) []scrollback.CommandBlock {
 	// Log marker diagnostics
 	markers := term.SemanticMarkers()
 	if markers != nil {
@@ -206,20 +233,62 @@ func OpenScrollbackBrowser(o *app.OS) {
 	}
 	blocks := scrollback.ParseBlocks(term)
 	scrollback.DebugLogFunc = nil
-	if len(blocks) == 0 {
-		o.ShowNotification("No commands found in scrollback", "info", 2*time.Second)
-		return
+	if len(blocks) > 0 {
+		o.Log("info", "Scrollback browser: %d command blocks parsed (method=%s)", len(blocks), blocks[0].Method)
 	}
+	return blocks
+}
 
-	o.Log("info", "Scrollback browser: %d command blocks parsed (method=%s)", len(blocks), blocks[0].Method)
-
+// showScrollbackBrowser opens the scrollback browser overlay on parsed blocks.
+func showScrollbackBrowser(o *app.OS, term *vt.Emulator, title string, blocks []scrollback.CommandBlock) *scrollback.Browser {
+	closeScrollbackBrowser(o)
 	browser := scrollback.NewBrowser(blocks)
+	browser.Term = term
+	browser.Title = title
 	browser.ParseMethod = blocks[0].Method
-	if markers != nil {
+	if markers := term.SemanticMarkers(); markers != nil {
 		browser.MarkerCount = markers.Len()
 	}
 	o.ScrollbackBrowser = browser
 	o.ShowScrollbackBrowser = true
+	return browser
+}
+
+// closeScrollbackBrowser hides the scrollback browser and releases its resources.
+func closeScrollbackBrowser(o *app.OS) {
+	if browser, ok := o.ScrollbackBrowser.(*scrollback.Browser); ok && browser != nil {
+		browser.Close()
+	}
+	o.ShowScrollbackBrowser = false
+	o.ScrollbackBrowser = nil
+}
+
+// browserExportFormats maps the key pressed after "e" to an export format.
+var browserExportFormats = map[string]scrollback.ExportFormat{
+	"h": scrollback.ExportHTML,
+	"s": scrollback.ExportSVG,
+	"a": scrollback.ExportANSI,
+	"t": scrollback.ExportText,
+	"c": scrollback.ExportCast,
+}
+
+// exportBrowserBlocks writes the selected command blocks, with their styling
+// and hyperlinks, to a file in the export directory.
+func exportBrowserBlocks(o *app.OS, browser *scrollback.Browser, format scrollback.ExportFormat) {
+	blocks := browser.ExportBlocks()
+	if len(blocks) == 0 || browser.Term == nil {
+		o.ShowNotification("Nothing to export", "warning", config.NotificationDuration)
+		return
+	}
+
+	lines := scrollback.BlockLines(browser.Term, blocks)
+	opts := app.HistoryExportOptions(browser.Title, browser.Term)
+	path, err := app.WriteHistoryExport(config.GetExportDirectory(), lines, format, opts)
+	if err != nil {
+		o.ShowNotification(fmt.Sprintf("Export failed: %v", err), "error", config.NotificationDuration)
+		return
+	}
+	o.ShowNotification(fmt.Sprintf("Exported %d command(s) to %s", len(blocks), path), "success", config.NotificationDuration)
 }
 
 func handleBrowserOutputModeKey(keyStr string, browser *scrollback.Browser, o *app.OS) (*app.OS, tea.Cmd) {
@@ -148,17 +148,33 @@ func extractSelectedText(window *terminal.Window, _ *app.OS) string {
 
 // handleClipboardPaste processes clipboard content and sends it to the focused terminal
 func handleClipboardPaste(o *app.OS) {
-	if o.FocusedWindow < 0 || o.FocusedWindow >= len(o.Windows) {
+	if o.GetFocusedWindow() == nil {
 		return
 	}
 
-	focusedWindow := o.GetFocusedWindow()
-	if focusedWindow == nil {
+	if o.ClipboardContent == "" {
+		o.ShowNotification("Clipboard is empty", "warning", config.NotificationDuration)
 		return
 	}
 
-	if o.ClipboardContent == "" {
-		o.ShowNotification("Clipboard is empty", "warning", config.NotificationDuration)
+	pasteToFocusedWindow(o, o.ClipboardContent)
+}
+
+// handleRegisterPaste pastes the contents of a yank register into the focused terminal
+func handleRegisterPaste(o *app.OS, name rune) {
+	text, ok := o.GetRegister(name)
+	if !ok || text == "" {
+		o.ShowNotification(fmt.Sprintf("Register \"%c is empty", name), "warning", config.NotificationDuration)
+		return
+	}
+
+	pasteToFocusedWindow(o, text)
+}
+
+// pasteToFocusedWindow sends text to the focused terminal as a paste
+func pasteToFocusedWindow(o *app.OS, text string) {
+	focusedWindow := o.GetFocusedWindow()
+	if focusedWindow == nil {
 		return
 	}
 
@@ -167,7 +183,7 @@ func handleClipboardPaste(o *app.OS) {
 	// Terminal.Paste() writes to an internal pipe that gets drained by
 	// StartDaemonResponseReader() - the data never reaches the PTY.
 	// SendInput() properly routes through DaemonWriteFunc in daemon mode.
-	pasteContent := o.ClipboardContent
+	pasteContent := text
 	if focusedWindow.Terminal != nil && focusedWindow.Terminal.BracketedPasteEnabled() {
 		pasteContent = "\x1b[200~" + pasteContent + "\x1b[201~"
 	}
@@ -177,5 +193,5 @@ func handleClipboardPaste(o *app.OS) {
 		return
 	}
 
-	o.ShowNotification(fmt.Sprintf("Pasted %d characters", len(o.ClipboardContent)), "success", config.NotificationDuration)
+	o.ShowNotification(fmt.Sprintf("Pasted %d characters", len(text)), "success", config.NotificationDuration)
 }
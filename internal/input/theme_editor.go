@@ -0,0 +1,98 @@
+package input
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/app"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/theme"
+)
+
+// HandleThemeEditorKey handles keyboard input when the theme editor is open.
+// Colors are applied as soon as a complete hex value is typed, so the
+// windows preview it.
+func HandleThemeEditorKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	s := o.ThemeEditor
+	if s == nil {
+		o.CloseThemeEditor()
+		return o, nil
+	}
+	key := msg.String()
+
+	if s.EditingName {
+		switch key {
+		case "esc":
+			s.EditingName = false
+		case "enter":
+			if name := theme.ThemeID(s.Input); name != "" {
+				s.Name = name
+			}
+			s.EditingName = false
+		case "backspace":
+			if len(s.Input) > 0 {
+				s.Input = s.Input[:len(s.Input)-1]
+			}
+		default:
+			if len(key) == 1 && key[0] >= 32 && key[0] < 127 {
+				s.Input += key
+			}
+		}
+		return o, nil
+	}
+
+	if s.Editing {
+		switch key {
+		case "esc", "enter":
+			s.Editing = false
+			if key == "enter" {
+				if err := o.SetThemeEditorColor(s.Input); err != nil {
+					o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+				}
+			}
+		case "backspace":
+			if len(s.Input) > 1 {
+				s.Input = s.Input[:len(s.Input)-1]
+			}
+		default:
+			if len(key) == 1 && strings.ContainsAny(key, "0123456789abcdefABCDEF") && len(s.Input) < 7 {
+				s.Input += strings.ToLower(key)
+				if len(s.Input) == 7 {
+					_ = o.SetThemeEditorColor(s.Input)
+				}
+			}
+		}
+		return o, nil
+	}
+
+	switch key {
+	case "esc", "q":
+		o.CloseThemeEditor()
+	case "up", "k":
+		o.MoveThemeEditorSelection(-1)
+	case "down", "j":
+		o.MoveThemeEditorSelection(1)
+	case "pgup":
+		o.MoveThemeEditorSelection(-10)
+	case "pgdown":
+		o.MoveThemeEditorSelection(10)
+	case "enter", "e":
+		if k, ok := o.SelectedThemeColor(); ok {
+			s.Input = theme.ColorToString(k.Color())
+			s.Editing = true
+		}
+	case "r":
+		o.ResetThemeEditorColor()
+	case "n":
+		s.Input = s.Name
+		s.EditingName = true
+	case "s", "ctrl+s":
+		path, err := o.SaveEditedTheme()
+		if err != nil {
+			o.ShowNotification("Theme not saved: "+err.Error(), "error", config.NotificationDuration)
+		} else {
+			o.ShowNotification("Saved theme "+s.Name+" to "+path, "success", config.NotificationDuration)
+		}
+	}
+	return o, nil
+}
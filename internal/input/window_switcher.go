@@ -0,0 +1,59 @@
+package input
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/app"
+)
+
+// HandleWindowSwitcherKey handles keyboard input when the window switcher is
+// open. Printable keys filter the windows, so the window actions are bound
+// to control and alt keys.
+func HandleWindowSwitcherKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	s := o.WindowSwitcher
+	if s == nil {
+		o.CloseWindowSwitcher()
+		return o, nil
+	}
+
+	key := msg.String()
+	switch key {
+	case "esc":
+		o.CloseWindowSwitcher()
+	case "left", "shift+tab", "ctrl+p":
+		o.MoveSwitcherSelection(-1)
+	case "right", "tab", "ctrl+n":
+		o.MoveSwitcherSelection(1)
+	case "up":
+		o.MoveSwitcherSelection(-s.Columns)
+	case "down":
+		o.MoveSwitcherSelection(s.Columns)
+	case "enter":
+		w := o.SelectedSwitcherWindow()
+		o.CloseWindowSwitcher()
+		if w != nil {
+			o.JumpToWindow(w)
+		}
+	case "ctrl+x":
+		o.SwitcherCloseSelected()
+	case "ctrl+d":
+		o.SwitcherToggleMinimizeSelected()
+	case "backspace":
+		if len(s.Query) > 0 {
+			s.Query = s.Query[:len(s.Query)-1]
+			s.SelectedIndex = 0
+		}
+	default:
+		if isWorkspaceSwitchKey(key) {
+			o.SwitcherMoveSelected(int(key[strings.LastIndex(key, "+")+1] - '0'))
+		} else if len(key) == 1 && key[0] >= 32 && key[0] < 127 {
+			s.Query += key
+			s.SelectedIndex = 0
+		} else if key == "space" {
+			s.Query += " "
+			s.SelectedIndex = 0
+		}
+	}
+	return o, nil
+}
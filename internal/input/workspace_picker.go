@@ -0,0 +1,83 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/Gaurav-Gosain/tuios/internal/app"
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+)
+
+// HandleWorkspacePickerKey handles keyboard input when the workspace picker
+// or the workspace rename prompt is open. Printable keys edit the query, so
+// the selection moves with the arrow keys only.
+func HandleWorkspacePickerKey(msg tea.KeyPressMsg, o *app.OS) (*app.OS, tea.Cmd) {
+	s := o.WorkspacePicker
+	if s == nil {
+		o.CloseWorkspacePicker()
+		return o, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		o.CloseWorkspacePicker()
+	case "up", "ctrl+p", "shift+tab":
+		if s.SelectedIndex > 0 {
+			s.SelectedIndex--
+		}
+	case "down", "ctrl+n", "tab":
+		if s.SelectedIndex < len(o.WorkspacePickerEntries())-1 {
+			s.SelectedIndex++
+		}
+	case "enter":
+		if s.Renaming > 0 {
+			workspace, name := s.Renaming, s.Query
+			o.CloseWorkspacePicker()
+			if err := o.RenameWorkspace(workspace, name); err != nil {
+				o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+			}
+			return o, nil
+		}
+		pickWorkspace(o)
+	case "backspace":
+		if len(s.Query) > 0 {
+			s.Query = s.Query[:len(s.Query)-1]
+			s.SelectedIndex = 0
+		}
+	default:
+		if len(msg.String()) == 1 && msg.String()[0] >= 32 && msg.String()[0] < 127 {
+			s.Query += msg.String()
+			s.SelectedIndex = 0
+		} else if msg.String() == "space" {
+			s.Query += " "
+			s.SelectedIndex = 0
+		}
+	}
+	return o, nil
+}
+
+// pickWorkspace switches to the selected workspace. When nothing matches
+// and workspaces are dynamic, the query names a new workspace.
+func pickWorkspace(o *app.OS) {
+	entries := o.WorkspacePickerEntries()
+	query := strings.TrimSpace(o.WorkspacePicker.Query)
+	selected := o.WorkspacePicker.SelectedIndex
+	o.CloseWorkspacePicker()
+
+	if selected >= 0 && selected < len(entries) {
+		o.SwitchToWorkspace(entries[selected].Workspace)
+		return
+	}
+	if query == "" || !o.DynamicWorkspaces() {
+		o.ShowNotification("No matching workspace", "warning", config.NotificationDuration)
+		return
+	}
+	workspace, err := o.ResolveWorkspace(query)
+	if err != nil {
+		o.ShowNotification(err.Error(), "warning", config.NotificationDuration)
+		return
+	}
+	o.SwitchToWorkspace(workspace)
+	o.ShowNotification(fmt.Sprintf("Created workspace %s", o.WorkspaceLabel(workspace)), "info", config.NotificationDuration)
+}
@@ -2,6 +2,7 @@
 package layout
 
 import (
+	"slices"
 	"sync/atomic"
 
 	"github.com/Gaurav-Gosain/tuios/internal/config"
@@ -98,6 +99,11 @@ type TileNode struct {
 	WindowID   int       // Window ID (-1 for internal nodes)
 	SplitType  SplitType // How this node splits its space
 	SplitRatio float64   // Position of split (0.0-1.0), 0.5 = middle
+
+	// Tabbed/stacked containers. A leaf with a Group holds several windows
+	// in one frame; WindowID is the active (visible) one.
+	Group     []int         // Window IDs in tab order (nil for single-window leaves)
+	Container ContainerMode // How the group is displayed
 }
 
 // newNodeID generates a unique node ID
@@ -253,7 +259,7 @@ func (t *BSPTree) InsertWindow(windowID int, focusedWindowID int, direction Spli
 
 	// Create new internal node that replaces the target
 	// The target becomes the left child, new window becomes right child
-	oldLeaf := NewLeafNode(targetNode.WindowID)
+	oldLeaf := t.relocateLeaf(targetNode)
 	internalNode := NewInternalNode(direction, ratio, oldLeaf, newLeaf)
 
 	// Replace target in tree
@@ -271,7 +277,6 @@ func (t *BSPTree) InsertWindow(windowID int, focusedWindowID int, direction Spli
 	}
 
 	// Update window-to-node mapping
-	t.WindowToNode[targetNode.WindowID] = oldLeaf
 	t.WindowToNode[windowID] = newLeaf
 }
 
@@ -326,7 +331,7 @@ func (t *BSPTree) InsertWindowWithPreselection(windowID int, focusedWindowID int
 	}
 
 	// Create the split with correct ordering
-	oldLeaf := NewLeafNode(targetNode.WindowID)
+	oldLeaf := t.relocateLeaf(targetNode)
 	var internalNode *TileNode
 	if newWindowIsLeft {
 		internalNode = NewInternalNode(direction, t.DefaultRatio, newLeaf, oldLeaf)
@@ -347,7 +352,6 @@ func (t *BSPTree) InsertWindowWithPreselection(windowID int, focusedWindowID int
 	}
 
 	// Update mappings
-	t.WindowToNode[targetNode.WindowID] = oldLeaf
 	t.WindowToNode[windowID] = newLeaf
 }
 
@@ -361,6 +365,13 @@ func (t *BSPTree) RemoveWindow(windowID int) {
 
 	delete(t.WindowToNode, windowID)
 
+	// Windows in a group leave the container; the leaf stays while it
+	// still holds other windows
+	if len(node.Group) > 1 {
+		node.removeFromGroup(windowID)
+		return
+	}
+
 	// If this is the only window, tree becomes empty
 	if node.Parent == nil {
 		t.Root = nil
@@ -404,18 +415,22 @@ func (t *BSPTree) applyLayoutRecursive(node *TileNode, bounds Rect, result map[i
 		return
 	}
 
-	// Leaf node - this is a window
+	// Leaf node - this is a window (or a container of windows sharing the
+	// space below its tab strip)
 	if node.IsLeaf() {
+		strip := node.StripHeight()
 		// Enforce minimum sizes
 		w := bounds.W
-		h := bounds.H
+		h := bounds.H - strip
 		if w < config.DefaultWindowWidth {
 			w = config.DefaultWindowWidth
 		}
 		if h < config.DefaultWindowHeight {
 			h = config.DefaultWindowHeight
 		}
-		result[node.WindowID] = Rect{X: bounds.X, Y: bounds.Y, W: w, H: h}
+		for _, id := range node.Windows() {
+			result[id] = Rect{X: bounds.X, Y: bounds.Y + strip, W: w, H: h}
+		}
 		return
 	}
 
@@ -583,8 +598,16 @@ func (t *BSPTree) SwapWindows(windowID1, windowID2 int) {
 		return
 	}
 
+	// Windows in the same container just trade tab positions
+	if node1 == node2 {
+		i, j := slices.Index(node1.Group, windowID1), slices.Index(node1.Group, windowID2)
+		node1.Group[i], node1.Group[j] = node1.Group[j], node1.Group[i]
+		return
+	}
+
 	// Swap window IDs in the nodes
-	node1.WindowID, node2.WindowID = node2.WindowID, node1.WindowID
+	node1.replaceWindow(windowID1, windowID2)
+	node2.replaceWindow(windowID2, windowID1)
 
 	// Update the lookup map
 	t.WindowToNode[windowID1] = node2
@@ -632,7 +655,7 @@ func collectWindowIDs(node *TileNode, ids *[]int) {
 		return
 	}
 	if node.IsLeaf() {
-		*ids = append(*ids, node.WindowID)
+		*ids = append(*ids, node.Windows()...)
 		return
 	}
 	collectWindowIDs(node.Left, ids)
@@ -812,10 +835,14 @@ func cloneNode(node *TileNode, parent *TileNode, windowMap map[int]*TileNode) *T
 		WindowID:   node.WindowID,
 		SplitType:  node.SplitType,
 		SplitRatio: node.SplitRatio,
+		Group:      slices.Clone(node.Group),
+		Container:  node.Container,
 	}
 
 	if node.IsLeaf() {
-		windowMap[node.WindowID] = newNode
+		for _, id := range newNode.Windows() {
+			windowMap[id] = newNode
+		}
 	} else {
 		newNode.Left = cloneNode(node.Left, newNode, windowMap)
 		newNode.Right = cloneNode(node.Right, newNode, windowMap)
@@ -826,11 +853,13 @@ func cloneNode(node *TileNode, parent *TileNode, windowMap map[int]*TileNode) *T
 
 // SerializedNode represents a BSP tree node in a serializable format
 type SerializedNode struct {
-	WindowID   int             `json:"window_id"`       // -1 for internal nodes
-	SplitType  int             `json:"split_type"`      // 0=none, 1=vertical, 2=horizontal
-	SplitRatio float64         `json:"split_ratio"`     // Position of split (0.0-1.0)
-	Left       *SerializedNode `json:"left,omitempty"`  // Left/Top child
-	Right      *SerializedNode `json:"right,omitempty"` // Right/Bottom child
+	WindowID   int             `json:"window_id"`           // -1 for internal nodes
+	SplitType  int             `json:"split_type"`          // 0=none, 1=vertical, 2=horizontal
+	SplitRatio float64         `json:"split_ratio"`         // Position of split (0.0-1.0)
+	Group      []int           `json:"group,omitempty"`     // Windows of a tabbed/stacked leaf
+	Container  int             `json:"container,omitempty"` // 0=split, 1=tabbed, 2=stacked
+	Left       *SerializedNode `json:"left,omitempty"`      // Left/Top child
+	Right      *SerializedNode `json:"right,omitempty"`     // Right/Bottom child
 }
 
 // SerializedBSPTree represents a BSP tree in a serializable format
@@ -860,6 +889,8 @@ func serializeNode(node *TileNode) *SerializedNode {
 		WindowID:   node.WindowID,
 		SplitType:  int(node.SplitType),
 		SplitRatio: node.SplitRatio,
+		Group:      slices.Clone(node.Group),
+		Container:  int(node.Container),
 		Left:       serializeNode(node.Left),
 		Right:      serializeNode(node.Right),
 	}
@@ -889,11 +920,47 @@ func deserializeNode(s *SerializedNode, parent *TileNode, windowMap map[int]*Til
 		WindowID:   s.WindowID,
 		SplitType:  SplitType(s.SplitType),
 		SplitRatio: s.SplitRatio,
+		Group:      slices.Clone(s.Group),
+		Container:  ContainerMode(s.Container),
 	}
 	if node.IsLeaf() && node.WindowID >= 0 {
-		windowMap[node.WindowID] = node
+		for _, id := range node.Windows() {
+			windowMap[id] = node
+		}
 	}
 	node.Left = deserializeNode(s.Left, node, windowMap)
 	node.Right = deserializeNode(s.Right, node, windowMap)
 	return node
 }
+
+// MapWindowIDs returns a copy of the serialized tree with every window ID,
+// including those in tabbed/stacked groups, replaced by f(id).
+func (s *SerializedBSPTree) MapWindowIDs(f func(int) int) *SerializedBSPTree {
+	if s == nil {
+		return nil
+	}
+	return &SerializedBSPTree{
+		Root:         mapNodeWindowIDs(s.Root, f),
+		AutoScheme:   s.AutoScheme,
+		DefaultRatio: s.DefaultRatio,
+	}
+}
+
+func mapNodeWindowIDs(s *SerializedNode, f func(int) int) *SerializedNode {
+	if s == nil {
+		return nil
+	}
+	node := *s
+	if node.SplitType == int(SplitNone) {
+		node.WindowID = f(node.WindowID)
+	}
+	if len(s.Group) > 0 {
+		node.Group = make([]int, len(s.Group))
+		for i, id := range s.Group {
+			node.Group[i] = f(id)
+		}
+	}
+	node.Left = mapNodeWindowIDs(s.Left, f)
+	node.Right = mapNodeWindowIDs(s.Right, f)
+	return &node
+}
@@ -0,0 +1,241 @@
+package layout
+
+import "slices"
+
+// ContainerMode controls how a BSP leaf holding several windows is shown.
+type ContainerMode int
+
+const (
+	// ContainerSplit is a plain leaf showing a single window
+	ContainerSplit ContainerMode = iota
+	// ContainerTabbed shows one row of tabs above the active window
+	ContainerTabbed
+	// ContainerStacked shows one title row per window above the active window
+	ContainerStacked
+)
+
+// String returns the string representation of the container mode
+func (c ContainerMode) String() string {
+	switch c {
+	case ContainerTabbed:
+		return "tabbed"
+	case ContainerStacked:
+		return "stacked"
+	default:
+		return "split"
+	}
+}
+
+// IsContainer returns true if this leaf shows its windows as tabs or a stack
+func (n *TileNode) IsContainer() bool {
+	return n.IsLeaf() && n.Container != ContainerSplit
+}
+
+// Windows returns the windows held by a leaf in tab order
+func (n *TileNode) Windows() []int {
+	if len(n.Group) > 0 {
+		return n.Group
+	}
+	return []int{n.WindowID}
+}
+
+// StripHeight returns the number of rows reserved above the active window
+// for the container's tabs or titles
+func (n *TileNode) StripHeight() int {
+	switch {
+	case !n.IsContainer():
+		return 0
+	case n.Container == ContainerStacked:
+		return len(n.Windows())
+	default:
+		return 1
+	}
+}
+
+// removeFromGroup drops a window from the leaf's group, activating its
+// neighbour if it was the active window
+func (n *TileNode) removeFromGroup(windowID int) {
+	idx := slices.Index(n.Group, windowID)
+	if idx < 0 {
+		return
+	}
+	n.Group = slices.Delete(n.Group, idx, idx+1)
+	if n.WindowID == windowID && len(n.Group) > 0 {
+		n.WindowID = n.Group[min(idx, len(n.Group)-1)]
+	}
+}
+
+// replaceWindow substitutes newID for oldID in the leaf
+func (n *TileNode) replaceWindow(oldID, newID int) {
+	if n.WindowID == oldID {
+		n.WindowID = newID
+	}
+	if idx := slices.Index(n.Group, oldID); idx >= 0 {
+		n.Group[idx] = newID
+	}
+}
+
+// relocateLeaf returns a copy of a leaf for use elsewhere in the tree and
+// points all of its windows at the copy
+func (t *BSPTree) relocateLeaf(leaf *TileNode) *TileNode {
+	moved := NewLeafNode(leaf.WindowID)
+	moved.Group = leaf.Group
+	moved.Container = leaf.Container
+	for _, id := range moved.Windows() {
+		t.WindowToNode[id] = moved
+	}
+	return moved
+}
+
+// AddToGroup puts a window into the leaf of targetWindowID, turning that
+// leaf into a container with the given mode if it isn't one yet. The new
+// window becomes the active tab.
+func (t *BSPTree) AddToGroup(windowID, targetWindowID int, mode ContainerMode) bool {
+	target := t.WindowToNode[targetWindowID]
+	if target == nil || t.HasWindow(windowID) {
+		return false
+	}
+
+	if len(target.Group) == 0 {
+		target.Group = []int{target.WindowID}
+	}
+	if target.Container == ContainerSplit {
+		if mode == ContainerSplit {
+			mode = ContainerTabbed
+		}
+		target.Container = mode
+	}
+
+	idx := slices.Index(target.Group, target.WindowID)
+	target.Group = slices.Insert(target.Group, idx+1, windowID)
+	target.WindowID = windowID
+	t.WindowToNode[windowID] = target
+	return true
+}
+
+// JoinSibling moves a window into the leaf next to it in the tree (the
+// closest leaf of its sibling subtree), grouping the two.
+func (t *BSPTree) JoinSibling(windowID int) bool {
+	node := t.WindowToNode[windowID]
+	if node == nil || node.Parent == nil {
+		return false
+	}
+
+	var target *TileNode
+	if node.IsLeftChild() {
+		target = findLeafInSubtree(node.Sibling())
+	} else {
+		target = findLastLeafInSubtree(node.Sibling())
+	}
+	if target == nil {
+		return false
+	}
+
+	mode := target.Container
+	if mode == ContainerSplit {
+		mode = node.Container
+	}
+	t.RemoveWindow(windowID)
+	return t.AddToGroup(windowID, target.WindowID, mode)
+}
+
+// LeaveGroup takes a window out of its container and gives it its own leaf
+// next to the container.
+func (t *BSPTree) LeaveGroup(windowID int, bounds Rect) bool {
+	node := t.WindowToNode[windowID]
+	if node == nil || len(node.Group) < 2 {
+		return false
+	}
+
+	node.removeFromGroup(windowID)
+	delete(t.WindowToNode, windowID)
+	t.InsertWindow(windowID, node.WindowID, SplitNone, 0, bounds)
+	return true
+}
+
+// SetContainerMode changes how the leaf holding windowID is displayed.
+// Switching a container back to ContainerSplit gives each of its windows a
+// leaf of its own again.
+func (t *BSPTree) SetContainerMode(windowID int, mode ContainerMode, bounds Rect) bool {
+	node := t.WindowToNode[windowID]
+	if node == nil {
+		return false
+	}
+
+	if mode != ContainerSplit {
+		if len(node.Group) == 0 {
+			node.Group = []int{node.WindowID}
+		}
+		node.Container = mode
+		return true
+	}
+
+	members := node.Group
+	node.Group = nil
+	node.Container = ContainerSplit
+	prev := node.WindowID
+	for _, id := range members {
+		if id == node.WindowID {
+			continue
+		}
+		delete(t.WindowToNode, id)
+		t.InsertWindow(id, prev, SplitNone, 0, bounds)
+		prev = id
+	}
+	return true
+}
+
+// ActivateWindow makes windowID the visible window of its container.
+func (t *BSPTree) ActivateWindow(windowID int) bool {
+	node := t.WindowToNode[windowID]
+	if node == nil || !slices.Contains(node.Group, windowID) {
+		return false
+	}
+	node.WindowID = windowID
+	return true
+}
+
+// CycleGroup activates the window delta tabs away from the active window of
+// windowID's container and returns it. Windows outside a group are returned
+// unchanged.
+func (t *BSPTree) CycleGroup(windowID, delta int) int {
+	node := t.WindowToNode[windowID]
+	if node == nil || len(node.Group) < 2 {
+		return windowID
+	}
+	n := len(node.Group)
+	idx := slices.Index(node.Group, node.WindowID)
+	node.WindowID = node.Group[((idx+delta)%n+n)%n]
+	return node.WindowID
+}
+
+// Containers returns all tabbed or stacked leaves in the tree
+func (t *BSPTree) Containers() []*TileNode {
+	var result []*TileNode
+	var walk func(*TileNode)
+	walk = func(node *TileNode) {
+		if node == nil {
+			return
+		}
+		if node.IsContainer() {
+			result = append(result, node)
+		}
+		walk(node.Left)
+		walk(node.Right)
+	}
+	walk(t.Root)
+	return result
+}
+
+func findLastLeafInSubtree(node *TileNode) *TileNode {
+	if node == nil {
+		return nil
+	}
+	if node.IsLeaf() {
+		return node
+	}
+	if leaf := findLastLeafInSubtree(node.Right); leaf != nil {
+		return leaf
+	}
+	return findLastLeafInSubtree(node.Left)
+}
@@ -0,0 +1,185 @@
+package layout
+
+import (
+	"slices"
+	"testing"
+)
+
+func newTestTree(ids ...int) (*BSPTree, Rect) {
+	bounds := Rect{X: 0, Y: 0, W: 200, H: 100}
+	tree := NewBSPTree()
+	prev := 0
+	for _, id := range ids {
+		tree.InsertWindow(id, prev, SplitNone, 0.5, bounds)
+		prev = id
+	}
+	return tree, bounds
+}
+
+func TestBSPTree_AddToGroup(t *testing.T) {
+	tree, bounds := newTestTree(1, 2)
+
+	if !tree.AddToGroup(3, 2, ContainerTabbed) {
+		t.Fatal("AddToGroup failed")
+	}
+	node := tree.FindNode(3)
+	if node != tree.FindNode(2) {
+		t.Fatal("windows 2 and 3 should share a leaf")
+	}
+	if node.WindowID != 3 || !slices.Equal(node.Group, []int{2, 3}) || node.Container != ContainerTabbed {
+		t.Errorf("unexpected container %+v", node)
+	}
+
+	layout := tree.ApplyLayout(bounds)
+	if layout[2] != layout[3] {
+		t.Errorf("group members should share a rect: %v vs %v", layout[2], layout[3])
+	}
+	if layout[3].Y != 1 || layout[3].H != 99 {
+		t.Errorf("tabbed container should reserve one row, got %+v", layout[3])
+	}
+	if got := tree.GetAllWindowIDs(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("GetAllWindowIDs() = %v", got)
+	}
+}
+
+func TestBSPTree_StackedStripHeight(t *testing.T) {
+	tree, bounds := newTestTree(1)
+	tree.AddToGroup(2, 1, ContainerStacked)
+	tree.AddToGroup(3, 1, ContainerStacked)
+
+	if got := tree.ApplyLayout(bounds)[1]; got.Y != 3 {
+		t.Errorf("stacked container with 3 windows should reserve 3 rows, got %+v", got)
+	}
+}
+
+func TestBSPTree_CycleGroup(t *testing.T) {
+	tree, _ := newTestTree(1)
+	tree.AddToGroup(2, 1, ContainerTabbed)
+	tree.AddToGroup(3, 2, ContainerTabbed)
+
+	if got := tree.CycleGroup(3, 1); got != 1 {
+		t.Errorf("CycleGroup(+1) = %d, want 1 (wrap)", got)
+	}
+	if got := tree.CycleGroup(1, -1); got != 3 {
+		t.Errorf("CycleGroup(-1) = %d, want 3", got)
+	}
+	if !tree.ActivateWindow(2) || tree.FindNode(1).WindowID != 2 {
+		t.Error("ActivateWindow should make 2 the active tab")
+	}
+}
+
+func TestBSPTree_RemoveFromGroup(t *testing.T) {
+	tree, _ := newTestTree(1, 2)
+	tree.AddToGroup(3, 2, ContainerTabbed)
+
+	tree.RemoveWindow(3)
+	node := tree.FindNode(2)
+	if node == nil || node.WindowID != 2 || !slices.Equal(node.Group, []int{2}) {
+		t.Fatalf("removing the active tab should activate its neighbour: %+v", node)
+	}
+	if !node.IsContainer() {
+		t.Error("container should survive with one window")
+	}
+
+	tree.RemoveWindow(2)
+	if tree.WindowCount() != 1 || tree.Root.WindowID != 1 {
+		t.Error("removing the last window should remove the leaf")
+	}
+}
+
+func TestBSPTree_JoinAndLeave(t *testing.T) {
+	tree, bounds := newTestTree(1, 2, 3)
+
+	if !tree.JoinSibling(3) {
+		t.Fatal("JoinSibling failed")
+	}
+	if tree.FindNode(3) != tree.FindNode(2) {
+		t.Fatal("window 3 should have joined window 2's leaf")
+	}
+
+	if !tree.LeaveGroup(3, bounds) {
+		t.Fatal("LeaveGroup failed")
+	}
+	if tree.FindNode(3) == tree.FindNode(2) {
+		t.Error("window 3 should have its own leaf again")
+	}
+	if tree.WindowCount() != 3 {
+		t.Errorf("WindowCount() = %d, want 3", tree.WindowCount())
+	}
+	if tree.LeaveGroup(1, bounds) {
+		t.Error("LeaveGroup should fail for windows outside a group")
+	}
+}
+
+func TestBSPTree_SplitContainerBack(t *testing.T) {
+	tree, bounds := newTestTree(1)
+	tree.AddToGroup(2, 1, ContainerTabbed)
+	tree.AddToGroup(3, 2, ContainerTabbed)
+
+	tree.SetContainerMode(3, ContainerSplit, bounds)
+	layout := tree.ApplyLayout(bounds)
+	if len(layout) != 3 || layout[1] == layout[2] || layout[2] == layout[3] {
+		t.Errorf("windows should be split apart again: %v", layout)
+	}
+	if len(tree.Containers()) != 0 {
+		t.Error("no containers should remain")
+	}
+}
+
+func TestBSPTree_InsertKeepsGroup(t *testing.T) {
+	tree, bounds := newTestTree(1)
+	tree.AddToGroup(2, 1, ContainerTabbed)
+	tree.InsertWindow(3, 2, SplitVertical, 0.5, bounds)
+
+	node := tree.FindNode(1)
+	if node != tree.FindNode(2) || !slices.Equal(node.Group, []int{1, 2}) {
+		t.Errorf("splitting a container should keep its group: %+v", node)
+	}
+}
+
+func TestBSPTree_SerializeGroups(t *testing.T) {
+	tree, _ := newTestTree(1, 2)
+	tree.AddToGroup(3, 2, ContainerStacked)
+
+	restored := tree.Serialize().Deserialize()
+	node := restored.FindNode(2)
+	if node == nil || node != restored.FindNode(3) || node.Container != ContainerStacked || node.WindowID != 3 {
+		t.Errorf("group lost in serialization: %+v", node)
+	}
+
+	clone := tree.Clone()
+	if clone.FindNode(2) != clone.FindNode(3) {
+		t.Error("group lost in Clone")
+	}
+}
+
+func TestBSPTree_SwapWithinGroup(t *testing.T) {
+	tree, _ := newTestTree(1)
+	tree.AddToGroup(2, 1, ContainerTabbed)
+
+	tree.SwapWindows(1, 2)
+	node := tree.FindNode(1)
+	if !slices.Equal(node.Group, []int{2, 1}) || node.WindowID != 2 {
+		t.Errorf("swap within a group should reorder tabs only: %+v", node)
+	}
+}
+
+func TestSerializedBSPTree_MapWindowIDs(t *testing.T) {
+	tree, _ := newTestTree(1, 2)
+	tree.AddToGroup(3, 2, ContainerTabbed)
+	tree.Root.SplitRatio = 0.7
+
+	mapped := tree.Serialize().MapWindowIDs(func(id int) int { return id * 10 }).Deserialize()
+	if got := mapped.GetAllWindowIDs(); !slices.Equal(got, []int{10, 20, 30}) {
+		t.Errorf("mapped IDs = %v, want [10 20 30]", got)
+	}
+	if node := mapped.WindowToNode[30]; node == nil || !slices.Equal(node.Group, []int{20, 30}) {
+		t.Error("group members should be mapped")
+	}
+	if mapped.Root.WindowID != -1 || mapped.Root.SplitRatio != 0.7 {
+		t.Error("internal nodes should keep their marker and ratio")
+	}
+	if !tree.HasWindow(1) {
+		t.Error("mapping should not change the original tree")
+	}
+}
@@ -0,0 +1,280 @@
+package layout
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+)
+
+// LayoutBSP is the name of the built-in binary space partition layout. It is
+// not a registered Layout because the BSP tree keeps per-window state; callers
+// check IsBSP and fall back to BSPTree for it.
+const LayoutBSP = "bsp"
+
+// Params carries the user-adjustable knobs shared by all layouts.
+type Params struct {
+	MasterRatio float64 // Fraction of the area given to the master area (0.1-0.9)
+	Masters     int     // Number of windows in the master area
+}
+
+// Layout arranges n windows inside an area. Implementations are stateless and
+// must return exactly n rectangles, ordered like the windows they are for.
+type Layout interface {
+	Name() string
+	Arrange(n int, area Rect, p Params) []Rect
+}
+
+var (
+	registry      = map[string]Layout{}
+	registryOrder []string
+)
+
+// Register adds a layout to the registry. It panics if the name is taken.
+func Register(l Layout) {
+	name := l.Name()
+	if name == LayoutBSP {
+		panic("layout: name " + LayoutBSP + " is reserved")
+	}
+	if _, ok := registry[name]; ok {
+		panic("layout: duplicate layout " + name)
+	}
+	registry[name] = l
+	registryOrder = append(registryOrder, name)
+}
+
+// Get returns the registered layout with the given name.
+func Get(name string) (Layout, bool) {
+	l, ok := registry[name]
+	return l, ok
+}
+
+// Names returns all selectable layout names, starting with bsp.
+func Names() []string {
+	return append([]string{LayoutBSP}, registryOrder...)
+}
+
+// IsBSP reports whether name selects the BSP tree layout (the default).
+func IsBSP(name string) bool {
+	return name == "" || name == LayoutBSP
+}
+
+// Validate returns an error if name is not a selectable layout.
+func Validate(name string) error {
+	if IsBSP(name) {
+		return nil
+	}
+	if _, ok := registry[name]; !ok {
+		return fmt.Errorf("unknown layout %q (available: %v)", name, Names())
+	}
+	return nil
+}
+
+// NextName returns the layout delta steps away from current in Names order,
+// wrapping around at either end.
+func NextName(current string, delta int) string {
+	names := Names()
+	idx := 0
+	for i, name := range names {
+		if name == current {
+			idx = i
+			break
+		}
+	}
+	idx = ((idx+delta)%len(names) + len(names)) % len(names)
+	return names[idx]
+}
+
+// Apply runs l and enforces the minimum window size on the result.
+func Apply(l Layout, n int, area Rect, p Params) []Rect {
+	if n <= 0 {
+		return nil
+	}
+	p.MasterRatio = clampRatio(p.MasterRatio)
+	p.Masters = max(p.Masters, 0)
+
+	rects := l.Arrange(n, area, p)
+	for i := range rects {
+		rects[i].W = max(rects[i].W, config.DefaultWindowWidth)
+		rects[i].H = max(rects[i].H, config.DefaultWindowHeight)
+	}
+	return rects
+}
+
+func clampRatio(r float64) float64 {
+	if r <= 0 {
+		return 0.5
+	}
+	return math.Min(math.Max(r, 0.1), 0.9)
+}
+
+func init() {
+	Register(masterStack{})
+	Register(centeredMaster{})
+	Register(columns{})
+	Register(rows{})
+	Register(fibonacci{spiral: true})
+	Register(fibonacci{spiral: false})
+	Register(monocle{})
+	Register(grid{})
+}
+
+// splitEven divides area into n equal parts, stacked top to bottom when
+// stacked is set and side by side otherwise. The last part absorbs rounding.
+func splitEven(n int, area Rect, stacked bool) []Rect {
+	rects := make([]Rect, 0, n)
+	for i := range n {
+		if stacked {
+			y := area.Y + area.H*i/n
+			rects = append(rects, Rect{X: area.X, Y: y, W: area.W, H: area.Y + area.H*(i+1)/n - y})
+		} else {
+			x := area.X + area.W*i/n
+			rects = append(rects, Rect{X: x, Y: area.Y, W: area.X + area.W*(i+1)/n - x, H: area.H})
+		}
+	}
+	return rects
+}
+
+// masterStack puts the masters in a left column and stacks the rest on the
+// right.
+type masterStack struct{}
+
+func (masterStack) Name() string { return "master-stack" }
+
+func (masterStack) Arrange(n int, area Rect, p Params) []Rect {
+	masters := min(p.Masters, n)
+	if masters == 0 || masters == n {
+		return splitEven(n, area, true)
+	}
+	mw := int(float64(area.W) * p.MasterRatio)
+	rects := splitEven(masters, Rect{X: area.X, Y: area.Y, W: mw, H: area.H}, true)
+	return append(rects, splitEven(n-masters, Rect{X: area.X + mw, Y: area.Y, W: area.W - mw, H: area.H}, true)...)
+}
+
+// centeredMaster puts the masters in a center column and alternates the
+// stack between the right and left columns.
+type centeredMaster struct{}
+
+func (centeredMaster) Name() string { return "centered-master" }
+
+func (centeredMaster) Arrange(n int, area Rect, p Params) []Rect {
+	masters := min(p.Masters, n)
+	if masters == 0 || n-masters < 2 {
+		return masterStack{}.Arrange(n, area, p)
+	}
+	mw := int(float64(area.W) * p.MasterRatio)
+	lw := (area.W - mw) / 2
+	rw := area.W - mw - lw
+
+	stack := n - masters
+	rightCount := (stack + 1) / 2
+	right := splitEven(rightCount, Rect{X: area.X + lw + mw, Y: area.Y, W: rw, H: area.H}, true)
+	left := splitEven(stack-rightCount, Rect{X: area.X, Y: area.Y, W: lw, H: area.H}, true)
+
+	rects := splitEven(masters, Rect{X: area.X + lw, Y: area.Y, W: mw, H: area.H}, true)
+	for i := range stack {
+		if i%2 == 0 {
+			rects = append(rects, right[i/2])
+		} else {
+			rects = append(rects, left[i/2])
+		}
+	}
+	return rects
+}
+
+// columns gives every window an equal-width column.
+type columns struct{}
+
+func (columns) Name() string { return "columns" }
+
+func (columns) Arrange(n int, area Rect, _ Params) []Rect { return splitEven(n, area, false) }
+
+// rows gives every window an equal-height row.
+type rows struct{}
+
+func (rows) Name() string { return "rows" }
+
+func (rows) Arrange(n int, area Rect, _ Params) []Rect { return splitEven(n, area, true) }
+
+// fibonacci halves the remaining space for each window, alternating between
+// vertical and horizontal splits. With spiral set the remainder rotates
+// around the screen (right, bottom, left, top); otherwise it always moves
+// towards the bottom-right corner ("dwindle").
+type fibonacci struct{ spiral bool }
+
+func (f fibonacci) Name() string {
+	if f.spiral {
+		return "fibonacci"
+	}
+	return "dwindle"
+}
+
+func (f fibonacci) Arrange(n int, area Rect, p Params) []Rect {
+	rects := make([]Rect, 0, n)
+	rest := area
+	for i := range n - 1 {
+		ratio := 0.5
+		if i == 0 {
+			ratio = p.MasterRatio
+		}
+		// Every second pair of splits places the window on the far side so
+		// the remainder turns back around the screen.
+		flip := f.spiral && i%4 >= 2
+		if i%2 == 0 {
+			w := int(float64(rest.W) * ratio)
+			if flip {
+				w = rest.W - w
+				rects = append(rects, Rect{X: rest.X + rest.W - w, Y: rest.Y, W: w, H: rest.H})
+				rest.W -= w
+			} else {
+				rects = append(rects, Rect{X: rest.X, Y: rest.Y, W: w, H: rest.H})
+				rest.X += w
+				rest.W -= w
+			}
+		} else {
+			h := int(float64(rest.H) * ratio)
+			if flip {
+				h = rest.H - h
+				rects = append(rects, Rect{X: rest.X, Y: rest.Y + rest.H - h, W: rest.W, H: h})
+				rest.H -= h
+			} else {
+				rects = append(rects, Rect{X: rest.X, Y: rest.Y, W: rest.W, H: h})
+				rest.Y += h
+				rest.H -= h
+			}
+		}
+	}
+	return append(rects, rest)
+}
+
+// monocle gives every window the whole area; only the focused one is seen.
+type monocle struct{}
+
+func (monocle) Name() string { return "monocle" }
+
+func (monocle) Arrange(n int, area Rect, _ Params) []Rect {
+	rects := make([]Rect, n)
+	for i := range rects {
+		rects[i] = area
+	}
+	return rects
+}
+
+// grid arranges windows in a near-square grid; the last row stretches to
+// fill the width.
+type grid struct{}
+
+func (grid) Name() string { return "grid" }
+
+func (grid) Arrange(n int, area Rect, _ Params) []Rect {
+	cols := int(math.Ceil(math.Sqrt(float64(n))))
+	nrows := (n + cols - 1) / cols
+	rowRects := splitEven(nrows, area, true)
+
+	rects := make([]Rect, 0, n)
+	for r, row := range rowRects {
+		count := min(cols, n-r*cols)
+		rects = append(rects, splitEven(count, row, false)...)
+	}
+	return rects
+}
@@ -0,0 +1,120 @@
+package layout
+
+import (
+	"slices"
+	"testing"
+)
+
+func coveredArea(rects []Rect) int {
+	total := 0
+	for _, r := range rects {
+		total += r.W * r.H
+	}
+	return total
+}
+
+func TestRegisteredLayoutsTileArea(t *testing.T) {
+	area := Rect{X: 0, Y: 1, W: 240, H: 120}
+	for _, name := range Names() {
+		if IsBSP(name) {
+			continue
+		}
+		l, ok := Get(name)
+		if !ok {
+			t.Fatalf("layout %q listed but not registered", name)
+		}
+		for n := 1; n <= 7; n++ {
+			rects := Apply(l, n, area, Params{MasterRatio: 0.5, Masters: 1})
+			if len(rects) != n {
+				t.Fatalf("%s: got %d rects for %d windows", name, len(rects), n)
+			}
+			for _, r := range rects {
+				if r.X < area.X || r.Y < area.Y || r.X+r.W > area.X+area.W || r.Y+r.H > area.Y+area.H {
+					t.Errorf("%s/%d: rect %+v outside area", name, n, r)
+				}
+			}
+			if name == "monocle" {
+				continue
+			}
+			if got := coveredArea(rects); got != area.W*area.H {
+				t.Errorf("%s/%d: rects cover %d cells, want %d", name, n, got, area.W*area.H)
+			}
+		}
+	}
+}
+
+func TestMasterStack(t *testing.T) {
+	l, _ := Get("master-stack")
+	rects := Apply(l, 4, Rect{W: 200, H: 90}, Params{MasterRatio: 0.6, Masters: 2})
+	want := []Rect{
+		{X: 0, Y: 0, W: 120, H: 45},
+		{X: 0, Y: 45, W: 120, H: 45},
+		{X: 120, Y: 0, W: 80, H: 45},
+		{X: 120, Y: 45, W: 80, H: 45},
+	}
+	if !slices.Equal(rects, want) {
+		t.Errorf("master-stack = %v, want %v", rects, want)
+	}
+}
+
+func TestCenteredMaster(t *testing.T) {
+	l, _ := Get("centered-master")
+	rects := Apply(l, 3, Rect{W: 200, H: 60}, Params{MasterRatio: 0.5, Masters: 1})
+	want := []Rect{
+		{X: 50, Y: 0, W: 100, H: 60},
+		{X: 150, Y: 0, W: 50, H: 60},
+		{X: 0, Y: 0, W: 50, H: 60},
+	}
+	if !slices.Equal(rects, want) {
+		t.Errorf("centered-master = %v, want %v", rects, want)
+	}
+}
+
+func TestFibonacciSpirals(t *testing.T) {
+	l, _ := Get("fibonacci")
+	rects := Apply(l, 4, Rect{W: 200, H: 80}, Params{MasterRatio: 0.5})
+	want := []Rect{
+		{X: 0, Y: 0, W: 100, H: 80},
+		{X: 100, Y: 0, W: 100, H: 40},
+		{X: 150, Y: 40, W: 50, H: 40},
+		{X: 100, Y: 40, W: 50, H: 40},
+	}
+	if !slices.Equal(rects, want) {
+		t.Errorf("fibonacci = %v, want %v", rects, want)
+	}
+}
+
+func TestGridStretchesLastRow(t *testing.T) {
+	l, _ := Get("grid")
+	rects := Apply(l, 5, Rect{W: 120, H: 60}, Params{})
+	if rects[3].W != 60 || rects[4].W != 60 || rects[0].W != 40 {
+		t.Errorf("grid = %v", rects)
+	}
+}
+
+func TestNextName(t *testing.T) {
+	names := Names()
+	if names[0] != LayoutBSP {
+		t.Fatalf("Names()[0] = %q, want bsp", names[0])
+	}
+	if got := NextName(LayoutBSP, 1); got != names[1] {
+		t.Errorf("NextName(bsp, 1) = %q", got)
+	}
+	if got := NextName(LayoutBSP, -1); got != names[len(names)-1] {
+		t.Errorf("NextName(bsp, -1) = %q", got)
+	}
+	if got := NextName("unknown", 1); got != names[1] {
+		t.Errorf("NextName(unknown, 1) = %q", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, name := range []string{"", "bsp", "centered-master", "monocle"} {
+		if err := Validate(name); err != nil {
+			t.Errorf("Validate(%q) = %v", name, err)
+		}
+	}
+	if err := Validate("spiral"); err == nil {
+		t.Error("Validate(spiral) should fail")
+	}
+}
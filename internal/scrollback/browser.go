@@ -1,6 +1,11 @@
 package scrollback
 
-import "strings"
+import (
+	"slices"
+	"strings"
+
+	"github.com/Gaurav-Gosain/tuios/internal/vt"
+)
 
 // BrowserMode selects what the browser displays.
 type BrowserMode int
@@ -45,6 +50,12 @@ type Browser struct {
 	// Help overlay
 	ShowHelp bool // show keybinding help modal
 
+	// Export
+	Term          *vt.Emulator // terminal the blocks were parsed from (used for styled export)
+	Title         string       // window title, used to name exports
+	ExportPending bool         // waiting for the export format key
+	onClose       func()       // releases resources held for Term
+
 	// Layout geometry populated each render for mouse input mapping.
 	LayoutLeftW  int // left pane width
 	LayoutRightW int // right pane width
@@ -67,6 +78,41 @@ func NewBrowser(blocks []CommandBlock) *Browser {
 	return b
 }
 
+// SetOnClose registers a function called when the browser is closed,
+// for example to close a saved history file backing Term.
+func (b *Browser) SetOnClose(fn func()) {
+	b.onClose = fn
+}
+
+// Close releases resources held by the browser.
+func (b *Browser) Close() {
+	if b.onClose != nil {
+		b.onClose()
+		b.onClose = nil
+	}
+}
+
+// ExportBlocks returns the command blocks to export: the multi-selected
+// blocks if any, otherwise the selected block.
+func (b *Browser) ExportBlocks() []CommandBlock {
+	if len(b.MultiSelect) > 0 {
+		indices := make([]int, 0, len(b.MultiSelect))
+		for idx := range b.MultiSelect {
+			indices = append(indices, idx)
+		}
+		slices.Sort(indices)
+		blocks := make([]CommandBlock, 0, len(indices))
+		for _, idx := range indices {
+			blocks = append(blocks, b.Blocks[idx])
+		}
+		return blocks
+	}
+	if cmd := b.SelectedCommand(); cmd != nil {
+		return []CommandBlock{*cmd}
+	}
+	return nil
+}
+
 func (b *Browser) extractContent() {
 	// Aggregate all output for JSON/path extraction
 	var allOutput strings.Builder
@@ -0,0 +1,494 @@
+package scrollback
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Gaurav-Gosain/tuios/internal/vt"
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ExportFormat is an output format for exported terminal history.
+type ExportFormat string
+
+// Supported export formats.
+const (
+	ExportHTML ExportFormat = "html" // standalone HTML page
+	ExportSVG  ExportFormat = "svg"  // SVG image
+	ExportANSI ExportFormat = "ansi" // text with ANSI escape sequences
+	ExportText ExportFormat = "txt"  // plain text
+	ExportCast ExportFormat = "cast" // asciicast v2 recording
+)
+
+// ExportFormats lists the supported export formats.
+var ExportFormats = []ExportFormat{ExportHTML, ExportSVG, ExportANSI, ExportText, ExportCast}
+
+// ParseExportFormat parses a format name, accepting a few common aliases.
+func ParseExportFormat(name string) (ExportFormat, error) {
+	switch strings.ToLower(strings.TrimPrefix(name, ".")) {
+	case "html", "htm":
+		return ExportHTML, nil
+	case "svg":
+		return ExportSVG, nil
+	case "ansi", "ans":
+		return ExportANSI, nil
+	case "txt", "text":
+		return ExportText, nil
+	case "cast", "asciicast":
+		return ExportCast, nil
+	}
+	return "", fmt.Errorf("unknown export format %q (expected html, svg, ansi, txt or cast)", name)
+}
+
+// Extension returns the file extension for the format, including the dot.
+func (f ExportFormat) Extension() string {
+	return "." + string(f)
+}
+
+// ExportOptions controls how exported history is rendered.
+type ExportOptions struct {
+	Title      string          // document title (HTML, SVG, asciicast)
+	Width      int             // columns; 0 uses the widest line
+	Height     int             // rows of the asciicast terminal; 0 uses the line count
+	Foreground color.Color     // default foreground; nil for light gray
+	Background color.Color     // default background; nil for black
+	Palette    [16]color.Color // colors for ANSI 0-15; nil entries use the xterm defaults
+}
+
+// Geometry of the SVG output.
+const (
+	svgFontSize   = 14
+	svgCellWidth  = 8.4
+	svgLineHeight = 17
+	svgPadding    = 10
+)
+
+// castLineIntervalMs is the delay between lines in asciicast output, so
+// playback scrolls through the history instead of appearing all at once.
+const castLineIntervalMs = 5
+
+// HistoryLines returns copies of the terminal's lines from..to (absolute
+// line indices, inclusive), clamped to the scrollback and screen.
+func HistoryLines(term *vt.Emulator, from, to int) []uv.Line {
+	if term == nil {
+		return nil
+	}
+	sbLen := term.ScrollbackLen()
+	from = max(from, 0)
+	to = min(to, sbLen+term.Height()-1)
+
+	var lines []uv.Line
+	for y := from; y <= to; y++ {
+		if y < sbLen {
+			lines = append(lines, slices.Clone(term.ScrollbackLine(y)))
+			continue
+		}
+		line := make(uv.Line, term.Width())
+		for x := range line {
+			if cell := term.CellAt(x, y-sbLen); cell != nil {
+				line[x] = *cell
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	// Drop blank lines below the content of the screen
+	for len(lines) > 0 && lineToText(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// BlockLines returns the lines of the given command blocks in scrollback
+// order, from each prompt through the end of its output.
+func BlockLines(term *vt.Emulator, blocks []CommandBlock) []uv.Line {
+	sorted := slices.Clone(blocks)
+	slices.SortFunc(sorted, func(a, b CommandBlock) int { return a.StartLine - b.StartLine })
+
+	var lines []uv.Line
+	for _, b := range sorted {
+		lines = append(lines, HistoryLines(term, b.StartLine, b.EndLine)...)
+	}
+	return lines
+}
+
+// Export renders lines in the given format.
+func Export(w io.Writer, lines []uv.Line, format ExportFormat, opts ExportOptions) error {
+	if opts.Width <= 0 {
+		for _, line := range lines {
+			opts.Width = max(opts.Width, lineWidth(line))
+		}
+		opts.Width = max(opts.Width, 1)
+	}
+
+	var out string
+	switch format {
+	case ExportHTML:
+		out = exportHTML(lines, opts)
+	case ExportSVG:
+		out = exportSVG(lines, opts)
+	case ExportANSI:
+		out = exportANSI(lines)
+	case ExportText:
+		out = exportText(lines)
+	case ExportCast:
+		var err error
+		if out, err = exportCast(lines, opts); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+
+	if _, err := io.WriteString(w, out); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// cellRun is a sequence of adjacent cells sharing a style and hyperlink.
+type cellRun struct {
+	col   int // starting column
+	text  string
+	style uv.Style
+	link  uv.Link
+}
+
+// lineRuns splits a line into styled runs, dropping trailing blank cells.
+func lineRuns(line uv.Line) []cellRun {
+	var runs []cellRun
+	col := 0
+	for _, c := range line {
+		// Continuation cells of wide characters have no content and no width
+		if c.Content == "" && c.Width == 0 {
+			continue
+		}
+		text := c.Content
+		if text == "" {
+			text = " "
+		}
+		if n := len(runs); n > 0 && runs[n-1].style.Equal(&c.Style) && runs[n-1].link == c.Link {
+			runs[n-1].text += text
+		} else {
+			runs = append(runs, cellRun{col: col, text: text, style: c.Style, link: c.Link})
+		}
+		col += max(c.Width, 1)
+	}
+
+	// Trailing spaces only matter when they paint a background
+	for len(runs) > 0 {
+		last := &runs[len(runs)-1]
+		if last.style.Bg != nil || last.style.Attrs&uv.AttrReverse != 0 {
+			break
+		}
+		last.text = strings.TrimRight(last.text, " ")
+		if last.text != "" {
+			break
+		}
+		runs = runs[:len(runs)-1]
+	}
+	return runs
+}
+
+// lineWidth returns the number of columns used by a line's content.
+func lineWidth(line uv.Line) int {
+	runs := lineRuns(line)
+	if len(runs) == 0 {
+		return 0
+	}
+	last := runs[len(runs)-1]
+	return last.col + ansi.StringWidth(last.text)
+}
+
+// colors returns the foreground and background of a style as hex strings,
+// resolving palette colors and reverse video. bg is "" for the default background.
+func (o ExportOptions) colors(s uv.Style) (fg, bg string) {
+	fgColor := o.resolve(s.Fg)
+	bgColor := o.resolve(s.Bg)
+	if s.Attrs&uv.AttrReverse != 0 {
+		fgColor, bgColor = bgColor, fgColor
+		if fgColor == nil {
+			fgColor = o.background()
+		}
+		if bgColor == nil {
+			bgColor = o.foreground()
+		}
+	}
+	if fgColor == nil {
+		fgColor = o.foreground()
+	}
+	if bgColor != nil {
+		bg = hexColor(bgColor)
+	}
+	return hexColor(fgColor), bg
+}
+
+// resolve maps ANSI palette colors to the configured palette.
+func (o ExportOptions) resolve(c color.Color) color.Color {
+	switch c := c.(type) {
+	case ansi.BasicColor:
+		if int(c) < len(o.Palette) && o.Palette[c] != nil {
+			return o.Palette[c]
+		}
+	case ansi.IndexedColor:
+		if int(c) < len(o.Palette) && o.Palette[c] != nil {
+			return o.Palette[c]
+		}
+	}
+	return c
+}
+
+func (o ExportOptions) foreground() color.Color {
+	if o.Foreground != nil {
+		return o.Foreground
+	}
+	return color.RGBA{R: 0xe5, G: 0xe5, B: 0xe5, A: 0xff}
+}
+
+func (o ExportOptions) background() color.Color {
+	if o.Background != nil {
+		return o.Background
+	}
+	return color.RGBA{A: 0xff}
+}
+
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+// cssStyle returns the inline CSS for a run, or "" if it has the default style.
+func (o ExportOptions) cssStyle(s uv.Style) string {
+	var props []string
+	if s.Fg != nil || s.Attrs&uv.AttrReverse != 0 {
+		fg, _ := o.colors(s)
+		props = append(props, "color:"+fg)
+	}
+	if _, bg := o.colors(s); bg != "" {
+		props = append(props, "background-color:"+bg)
+	}
+	if s.Attrs&uv.AttrBold != 0 {
+		props = append(props, "font-weight:bold")
+	}
+	if s.Attrs&uv.AttrFaint != 0 {
+		props = append(props, "opacity:0.6")
+	}
+	if s.Attrs&uv.AttrItalic != 0 {
+		props = append(props, "font-style:italic")
+	}
+	if s.Attrs&uv.AttrConceal != 0 {
+		props = append(props, "visibility:hidden")
+	}
+
+	var decorations []string
+	if s.Underline != uv.UnderlineNone {
+		decorations = append(decorations, "underline")
+	}
+	if s.Attrs&uv.AttrStrikethrough != 0 {
+		decorations = append(decorations, "line-through")
+	}
+	if len(decorations) > 0 {
+		props = append(props, "text-decoration:"+strings.Join(decorations, " "))
+		switch s.Underline {
+		case uv.UnderlineDouble:
+			props = append(props, "text-decoration-style:double")
+		case uv.UnderlineCurly:
+			props = append(props, "text-decoration-style:wavy")
+		case uv.UnderlineDotted:
+			props = append(props, "text-decoration-style:dotted")
+		case uv.UnderlineDashed:
+			props = append(props, "text-decoration-style:dashed")
+		}
+		if s.UnderlineColor != nil {
+			props = append(props, "text-decoration-color:"+hexColor(o.resolve(s.UnderlineColor)))
+		}
+	}
+	return strings.Join(props, ";")
+}
+
+// linkSchemes are the hyperlink schemes kept as links in HTML and SVG
+// exports. Any program can print a hyperlink, so others such as javascript:
+// are exported as plain text.
+var linkSchemes = []string{"http", "https", "mailto", "file"}
+
+// safeLink reports whether a hyperlink target can be a link in an export.
+func safeLink(target string) bool {
+	if target == "" {
+		return false
+	}
+	u, err := url.Parse(target)
+	return err == nil && slices.Contains(linkSchemes, strings.ToLower(u.Scheme))
+}
+
+func exportHTML(lines []uv.Line, opts ExportOptions) string {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&sb, "<title>%s</title>\n", html.EscapeString(opts.Title))
+	sb.WriteString("<style>\n")
+	fmt.Fprintf(&sb, "body { margin: 0; background: %s; }\n", hexColor(opts.background()))
+	fmt.Fprintf(&sb, "pre { margin: 0; padding: 1em; color: %s; font-family: ui-monospace, Menlo, Consolas, monospace; font-size: 14px; line-height: 1.2; }\n", hexColor(opts.foreground()))
+	sb.WriteString("a { color: inherit; }\n")
+	sb.WriteString("</style>\n</head>\n<body>\n<pre>")
+
+	for i, line := range lines {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		for _, run := range lineRuns(line) {
+			text := html.EscapeString(run.text)
+			if css := opts.cssStyle(run.style); css != "" {
+				text = fmt.Sprintf("<span style=\"%s\">%s</span>", css, text)
+			}
+			if safeLink(run.link.URL) {
+				text = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(run.link.URL), text)
+			}
+			sb.WriteString(text)
+		}
+	}
+
+	sb.WriteString("</pre>\n</body>\n</html>\n")
+	return sb.String()
+}
+
+func exportSVG(lines []uv.Line, opts ExportOptions) string {
+	width := float64(opts.Width)*svgCellWidth + 2*svgPadding
+	height := len(lines)*svgLineHeight + 2*svgPadding
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%.0f\" height=\"%d\" viewBox=\"0 0 %.0f %d\">\n", width, height, width, height)
+	if opts.Title != "" {
+		fmt.Fprintf(&sb, "<title>%s</title>\n", html.EscapeString(opts.Title))
+	}
+	fmt.Fprintf(&sb, "<rect width=\"100%%\" height=\"100%%\" fill=\"%s\"/>\n", hexColor(opts.background()))
+	fmt.Fprintf(&sb, "<g font-family=\"ui-monospace, Menlo, Consolas, monospace\" font-size=\"%d\" fill=\"%s\" xml:space=\"preserve\">\n", svgFontSize, hexColor(opts.foreground()))
+
+	for i, line := range lines {
+		top := svgPadding + i*svgLineHeight
+		baseline := top + svgLineHeight - 4
+		runs := lineRuns(line)
+
+		// Backgrounds first so text is drawn on top
+		for _, run := range runs {
+			if _, bg := opts.colors(run.style); bg != "" {
+				x := svgPadding + float64(run.col)*svgCellWidth
+				w := float64(ansi.StringWidth(run.text)) * svgCellWidth
+				fmt.Fprintf(&sb, "<rect x=\"%.1f\" y=\"%d\" width=\"%.1f\" height=\"%d\" fill=\"%s\"/>\n", x, top, w, svgLineHeight, bg)
+			}
+		}
+
+		var text strings.Builder
+		for _, run := range runs {
+			if strings.TrimSpace(run.text) == "" || run.style.Attrs&uv.AttrConceal != 0 {
+				continue
+			}
+			x := svgPadding + float64(run.col)*svgCellWidth
+			span := fmt.Sprintf("<tspan x=\"%.1f\"%s>%s</tspan>", x, opts.svgAttrs(run.style), html.EscapeString(run.text))
+			if safeLink(run.link.URL) {
+				span = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(run.link.URL), span)
+			}
+			text.WriteString(span)
+		}
+		if text.Len() > 0 {
+			fmt.Fprintf(&sb, "<text y=\"%d\">%s</text>\n", baseline, text.String())
+		}
+	}
+
+	sb.WriteString("</g>\n</svg>\n")
+	return sb.String()
+}
+
+// svgAttrs returns the presentation attributes for a run's style.
+func (o ExportOptions) svgAttrs(s uv.Style) string {
+	var sb strings.Builder
+	if s.Fg != nil || s.Attrs&uv.AttrReverse != 0 {
+		fg, _ := o.colors(s)
+		fmt.Fprintf(&sb, " fill=\"%s\"", fg)
+	}
+	if s.Attrs&uv.AttrBold != 0 {
+		sb.WriteString(" font-weight=\"bold\"")
+	}
+	if s.Attrs&uv.AttrFaint != 0 {
+		sb.WriteString(" fill-opacity=\"0.6\"")
+	}
+	if s.Attrs&uv.AttrItalic != 0 {
+		sb.WriteString(" font-style=\"italic\"")
+	}
+	var decorations []string
+	if s.Underline != uv.UnderlineNone {
+		decorations = append(decorations, "underline")
+	}
+	if s.Attrs&uv.AttrStrikethrough != 0 {
+		decorations = append(decorations, "line-through")
+	}
+	if len(decorations) > 0 {
+		fmt.Fprintf(&sb, " text-decoration=\"%s\"", strings.Join(decorations, " "))
+	}
+	return sb.String()
+}
+
+func exportANSI(lines []uv.Line) string {
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(line.Render())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func exportText(lines []uv.Line) string {
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(lineToText(line))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// castHeader is the first line of an asciicast v2 file.
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+func exportCast(lines []uv.Line, opts ExportOptions) (string, error) {
+	height := opts.Height
+	if height <= 0 {
+		height = max(len(lines), 1)
+	}
+	header, err := json.Marshal(castHeader{
+		Version:   2,
+		Width:     opts.Width,
+		Height:    height,
+		Timestamp: time.Now().Unix(),
+		Title:     opts.Title,
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode asciicast header: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.Write(header)
+	sb.WriteByte('\n')
+	for i, line := range lines {
+		event, err := json.Marshal([]any{float64(i*castLineIntervalMs) / 1000, "o", line.Render() + "\r\n"})
+		if err != nil {
+			return "", fmt.Errorf("failed to encode asciicast event: %w", err)
+		}
+		sb.Write(event)
+		sb.WriteByte('\n')
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,174 @@
+package scrollback
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/Gaurav-Gosain/tuios/internal/vt"
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func exportTestLines() []uv.Line {
+	return []uv.Line{
+		{
+			{Content: "$", Width: 1, Style: uv.Style{Fg: ansi.BasicColor(2), Attrs: uv.AttrBold}},
+			{Content: " ", Width: 1},
+			{Content: "l", Width: 1, Style: uv.Style{Attrs: uv.AttrItalic}},
+			{Content: "s", Width: 1, Style: uv.Style{Attrs: uv.AttrItalic}},
+			{Content: " ", Width: 1},
+			{Content: " ", Width: 1},
+		},
+		{
+			{Content: "<", Width: 1, Link: uv.Link{URL: "https://example.com/?a=1&b=2"}},
+			{Content: ">", Width: 1, Link: uv.Link{URL: "https://example.com/?a=1&b=2"}},
+			{Content: "界", Width: 2, Style: uv.Style{Bg: color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff}}},
+			{Content: "", Width: 0},
+		},
+	}
+}
+
+func exportString(t *testing.T, format ExportFormat, opts ExportOptions) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Export(&buf, exportTestLines(), format, opts); err != nil {
+		t.Fatalf("Export(%s): %v", format, err)
+	}
+	return buf.String()
+}
+
+func TestParseExportFormat(t *testing.T) {
+	for name, want := range map[string]ExportFormat{
+		"html": ExportHTML, "HTML": ExportHTML, ".svg": ExportSVG, "ansi": ExportANSI,
+		"text": ExportText, "txt": ExportText, "asciicast": ExportCast, "cast": ExportCast,
+	} {
+		got, err := ParseExportFormat(name)
+		if err != nil || got != want {
+			t.Errorf("ParseExportFormat(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseExportFormat("pdf"); err == nil {
+		t.Error("ParseExportFormat(pdf) should fail")
+	}
+}
+
+func TestExportText(t *testing.T) {
+	got := exportString(t, ExportText, ExportOptions{})
+	if want := "$ ls\n<>界\n"; got != want {
+		t.Errorf("txt export = %q, want %q", got, want)
+	}
+}
+
+func TestExportHTML(t *testing.T) {
+	var palette [16]color.Color
+	palette[2] = color.RGBA{R: 0x11, G: 0x22, B: 0x33, A: 0xff}
+	got := exportString(t, ExportHTML, ExportOptions{Title: "a <b>", Palette: palette})
+
+	for _, want := range []string{
+		"<title>a &lt;b&gt;</title>",
+		`<span style="color:#112233;font-weight:bold">$</span>`,
+		`<span style="font-style:italic">ls</span>`,
+		`<a href="https://example.com/?a=1&amp;b=2">&lt;&gt;</a>`,
+		`<span style="background-color:#123456">界</span>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("html export missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestExportSVG(t *testing.T) {
+	got := exportString(t, ExportSVG, ExportOptions{})
+
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg"`,
+		`font-weight="bold">$</tspan>`,
+		`font-style="italic">ls</tspan>`,
+		`<a href="https://example.com/?a=1&amp;b=2"><tspan x="10.0">&lt;&gt;</tspan></a>`,
+		`fill="#123456"/>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("svg export missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestExportUnsafeLinks(t *testing.T) {
+	lines := []uv.Line{{
+		{Content: "x", Width: 1, Link: uv.Link{URL: "javascript:alert(1)"}},
+		{Content: "y", Width: 1, Link: uv.Link{URL: " JavaScript:alert(2)"}},
+		{Content: "z", Width: 1, Link: uv.Link{URL: "data:text/html,<script>"}},
+		{Content: "m", Width: 1, Link: uv.Link{URL: "mailto:a@example.com"}},
+	}}
+	for _, format := range []ExportFormat{ExportHTML, ExportSVG} {
+		var buf bytes.Buffer
+		if err := Export(&buf, lines, format, ExportOptions{}); err != nil {
+			t.Fatalf("Export(%s): %v", format, err)
+		}
+		got := buf.String()
+		if strings.Contains(strings.ToLower(got), "javascript:") || strings.Contains(got, "data:") {
+			t.Errorf("%s export should render unsafe links as plain text:\n%s", format, got)
+		}
+		if !strings.Contains(got, `href="mailto:a@example.com"`) {
+			t.Errorf("%s export should keep mailto links:\n%s", format, got)
+		}
+	}
+}
+
+func TestExportANSIKeepsHyperlinks(t *testing.T) {
+	got := exportString(t, ExportANSI, ExportOptions{})
+	if !strings.Contains(got, ansi.SetHyperlink("https://example.com/?a=1&b=2")) {
+		t.Errorf("ansi export lost hyperlink: %q", got)
+	}
+	if plain := ansi.Strip(got); plain != "$ ls\n<>界\n" {
+		t.Errorf("ansi export text = %q", plain)
+	}
+}
+
+func TestExportCast(t *testing.T) {
+	got := exportString(t, ExportCast, ExportOptions{Title: "demo"})
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("cast export has %d lines, want header + 2 events:\n%s", len(lines), got)
+	}
+
+	var header castHeader
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("header: %v", err)
+	}
+	if header.Version != 2 || header.Width != 4 || header.Height != 2 || header.Title != "demo" {
+		t.Errorf("unexpected header %+v", header)
+	}
+
+	var event []any
+	if err := json.Unmarshal([]byte(lines[2]), &event); err != nil {
+		t.Fatalf("event: %v", err)
+	}
+	if len(event) != 3 || event[1] != "o" || !strings.HasSuffix(event[2].(string), "\r\n") {
+		t.Errorf("unexpected event %v", event)
+	}
+}
+
+func TestHistoryLinesFromEmulator(t *testing.T) {
+	emu := vt.NewEmulator(20, 3)
+	emu.SetScrollbackMaxLines(100)
+	_, _ = emu.Write([]byte("one\r\n\x1b[31mtwo\x1b[0m\r\nthree\r\nfour"))
+
+	lines := HistoryLines(emu, 0, 100)
+	var texts []string
+	for _, line := range lines {
+		texts = append(texts, lineToText(line))
+	}
+	if got := strings.Join(texts, "|"); got != "one|two|three|four" {
+		t.Fatalf("HistoryLines = %q", got)
+	}
+
+	blocks := []CommandBlock{{StartLine: 3, EndLine: 3}, {StartLine: 1, EndLine: 1}}
+	got := exportText(BlockLines(emu, blocks))
+	if got != "two\nfour\n" {
+		t.Errorf("BlockLines export = %q", got)
+	}
+}
@@ -62,6 +62,22 @@ func ParseBlocks(term *vt.Emulator) []CommandBlock {
 	return parseWithRegex(term)
 }
 
+// TranscriptBlock returns the terminal's whole scrollback and screen as a
+// single block. Used when no commands can be detected, for example when
+// browsing a saved history that was not recorded with shell integration.
+func TranscriptBlock(term *vt.Emulator, title string) CommandBlock {
+	last := max(term.ScrollbackLen()+term.Height()-1, 0)
+	return CommandBlock{
+		Command:      title,
+		Output:       extractLinesText(term, 0, last),
+		StyledOutput: extractLinesStyledText(term, 0, last),
+		ExitCode:     -1,
+		StartLine:    0,
+		EndLine:      last,
+		Method:       "transcript",
+	}
+}
+
 // parseWithMarkers uses OSC 133 markers to precisely segment commands.
 // Marker sequence: A (prompt start) -> B (command start) -> C (output start) -> D (command done)
 func parseWithMarkers(term *vt.Emulator, markers []vt.SemanticMarker) []CommandBlock {
@@ -178,7 +178,11 @@ func createEphemeralTUIOSInstance(sshSession ssh.Session, width, height int) (te
 		Height:          height,
 		IsSSHMode:       true,
 		SSHSession:      sshSession,
+		WatchConfig:     true,
 	})
+	if err := tuiosInstance.ApplyStartup(userConfig.Startup); err != nil {
+		log.Printf("Warning: %v", err)
+	}
 
 	return tuiosInstance, []tea.ProgramOption{
 		tea.WithFPS(config.NormalFPS),
@@ -261,6 +265,7 @@ func createDaemonTUIOSInstance(sshSession ssh.Session, sessionName string, width
 		DaemonClient:              client,
 		SessionName:               sessionName,
 		EnableGraphicsPassthrough: true,
+		WatchConfig:               true,
 	})
 
 	// Restore state from daemon if available
@@ -286,6 +291,13 @@ func createDaemonTUIOSInstance(sshSession ssh.Session, sessionName string, width
 		tuiosInstance.SyncDaemonPTYDimensions()
 	}
 
+	// Only a session this client created is set up by [startup]
+	if client.SessionCreated() {
+		if err := tuiosInstance.ApplyStartup(userConfig.Startup); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
 	// Register multi-client handlers
 	registerMultiClientHandlers(tuiosInstance, client)
 
@@ -412,12 +412,14 @@ func (d *Daemon) handleAttach(cs *connState, msg *Message) error {
 	}
 
 	var session *Session
+	var created bool
 	var err error
 
 	if payload.SessionName == "" {
+		created = !d.manager.HasSessions()
 		session, err = d.manager.GetDefaultSession(cfg, payload.Width, payload.Height)
 	} else if payload.CreateNew {
-		session, _, err = d.manager.GetOrCreateSession(payload.SessionName, cfg, payload.Width, payload.Height)
+		session, created, err = d.manager.GetOrCreateSession(payload.SessionName, cfg, payload.Width, payload.Height)
 	} else {
 		session = d.manager.GetSession(payload.SessionName)
 		if session == nil {
@@ -495,6 +497,7 @@ func (d *Daemon) handleAttach(cs *connState, msg *Message) error {
 		Width:       effectiveWidth,
 		Height:      effectiveHeight,
 		WindowCount: len(state.Windows),
+		Created:     created,
 		State:       state,
 	})
 }
@@ -673,7 +676,7 @@ func (d *Daemon) handleCreatePTY(cs *connState, msg *Message) error {
 	}
 
 	debugLog("[DEBUG] Creating PTY %dx%d for session %s", width, height, session.Name)
-	pty, err := session.CreatePTY(width, height)
+	pty, err := session.CreateCommandPTY(width, height, payload.Command)
 	if err != nil {
 		debugLog("[DEBUG] handleCreatePTY: failed to create PTY: %v", err)
 		return d.sendError(cs, ErrCodeInternal, fmt.Sprintf("failed to create PTY: %v", err))
@@ -738,10 +741,12 @@ func (d *Daemon) handleListPTYs(cs *connState) error {
 	for _, id := range ptyIDs {
 		pty := session.GetPTY(id)
 		if pty != nil {
-			ptys = append(ptys, PTYInfo{
+			info := PTYInfo{
 				ID:     pty.ID,
 				Exited: pty.IsExited(),
-			})
+			}
+			info.ForegroundPID, info.ForegroundCmd = pty.Foreground()
+			ptys = append(ptys, info)
 		}
 	}
 
@@ -1401,6 +1406,10 @@ func (d *Daemon) handleQueryWindows(cs *connState, msg *Message) error {
 			"display_name": displayName,
 			"workspace":    w.Workspace,
 			"minimized":    w.Minimized,
+			"floating":     w.Floating,
+			"sticky":       w.Sticky,
+			"scratchpad":   w.Scratchpad,
+			"zoomed":       w.Zoomed,
 			"focused":      w.ID == state.FocusedWindowID,
 			"x":            w.X,
 			"y":            w.Y,
@@ -1471,7 +1480,7 @@ func (d *Daemon) handleQuerySession(cs *connState, msg *Message) error {
 	// Build session info from state
 	tilingMode := "floating"
 	if state.AutoTiling {
-		tilingMode = "tiling"
+		tilingMode = state.TilingLayout(state.CurrentWorkspace)
 	}
 
 	resultData := map[string]any{
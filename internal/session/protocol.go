@@ -116,12 +116,13 @@ type AttachPayload struct {
 
 // AttachedPayload confirms successful session attachment.
 type AttachedPayload struct {
-	SessionName string        `json:"session_name"`    // Attached session name
-	SessionID   string        `json:"session_id"`      // Session unique ID
-	Width       int           `json:"width"`           // Current session width
-	Height      int           `json:"height"`          // Current session height
-	WindowCount int           `json:"window_count"`    // Number of windows in session
-	State       *SessionState `json:"state,omitempty"` // Session state for restore
+	SessionName string        `json:"session_name"`      // Attached session name
+	SessionID   string        `json:"session_id"`        // Session unique ID
+	Width       int           `json:"width"`             // Current session width
+	Height      int           `json:"height"`            // Current session height
+	WindowCount int           `json:"window_count"`      // Number of windows in session
+	Created     bool          `json:"created,omitempty"` // Whether the session was created by this attach
+	State       *SessionState `json:"state,omitempty"`   // Session state for restore
 }
 
 // NewPayload requests creation of a new session.
@@ -169,11 +170,13 @@ type ErrorPayload struct {
 
 // PTYInfo describes a single PTY.
 type PTYInfo struct {
-	ID     string `json:"id"`
-	Title  string `json:"title"`
-	Width  int    `json:"width"`
-	Height int    `json:"height"`
-	Exited bool   `json:"exited"`
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	Width         int    `json:"width"`
+	Height        int    `json:"height"`
+	Exited        bool   `json:"exited"`
+	ForegroundPID int    `json:"foreground_pid,omitempty"` // PID of foreground process
+	ForegroundCmd string `json:"foreground_cmd,omitempty"` // Command of foreground process
 }
 
 // PTYListPayload contains list of PTYs in a session.
@@ -183,9 +186,10 @@ type PTYListPayload struct {
 
 // CreatePTYPayload requests creation of a new PTY.
 type CreatePTYPayload struct {
-	Title  string `json:"title,omitempty"`
-	Width  int    `json:"width,omitempty"`
-	Height int    `json:"height,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Width   int    `json:"width,omitempty"`
+	Height  int    `json:"height,omitempty"`
+	Command string `json:"command,omitempty"` // Run instead of the shell (optional)
 }
 
 // PTYCreatedPayload confirms PTY creation.
@@ -324,6 +328,8 @@ type WindowInfo struct {
 	Focused         bool   `json:"focused"`                    // Is this the focused window
 	Minimized       bool   `json:"minimized"`                  // Is window minimized
 	Fullscreen      bool   `json:"fullscreen"`                 // Is window fullscreen
+	Zoomed          bool   `json:"zoomed"`                     // Is window zoomed over its workspace
+	Sticky          bool   `json:"sticky"`                     // Is window shown on every workspace
 	X               int    `json:"x"`                          // X position
 	Y               int    `json:"y"`                          // Y position
 	Width           int    `json:"width"`                      // Width in columns
@@ -17,6 +17,7 @@ import (
 	xpty "github.com/charmbracelet/x/xpty"
 	"github.com/google/uuid"
 
+	"github.com/Gaurav-Gosain/tuios/internal/system"
 	"github.com/Gaurav-Gosain/tuios/internal/vt"
 )
 
@@ -44,10 +45,19 @@ type WindowState struct {
 	Z            int    `json:"z"`
 	Workspace    int    `json:"workspace"`
 	Minimized    bool   `json:"minimized,omitempty"`
+	Floating     bool   `json:"floating,omitempty"`
+	Sticky       bool   `json:"sticky,omitempty"`
+	Scratchpad   string `json:"scratchpad,omitempty"`
+	Zoomed       bool   `json:"zoomed,omitempty"`
+	BorderColor  string `json:"border_color,omitempty"`
 	PreMinimizeX int    `json:"pre_minimize_x,omitempty"`
 	PreMinimizeY int    `json:"pre_minimize_y,omitempty"`
 	PreMinimizeW int    `json:"pre_minimize_w,omitempty"`
 	PreMinimizeH int    `json:"pre_minimize_h,omitempty"`
+	ZoomRestoreX int    `json:"zoom_restore_x,omitempty"` // Geometry to restore when unzooming
+	ZoomRestoreY int    `json:"zoom_restore_y,omitempty"`
+	ZoomRestoreW int    `json:"zoom_restore_w,omitempty"`
+	ZoomRestoreH int    `json:"zoom_restore_h,omitempty"`
 	PTYID        string `json:"pty_id"`                  // Reference to daemon-managed PTY
 	IsAltScreen  bool   `json:"is_alt_screen,omitempty"` // Alternate screen buffer active (for mouse forwarding)
 }
@@ -57,6 +67,8 @@ type SerializedBSPNode struct {
 	WindowID   int                `json:"window_id"`
 	SplitType  int                `json:"split_type"`
 	SplitRatio float64            `json:"split_ratio"`
+	Group      []int              `json:"group,omitempty"`     // Windows of a tabbed/stacked leaf
+	Container  int                `json:"container,omitempty"` // 0=split, 1=tabbed, 2=stacked
 	Left       *SerializedBSPNode `json:"left,omitempty"`
 	Right      *SerializedBSPNode `json:"right,omitempty"`
 }
@@ -75,6 +87,8 @@ type SessionState struct {
 	FocusedWindowID  string         `json:"focused_window_id,omitempty"`
 	CurrentWorkspace int            `json:"current_workspace"`
 	WorkspaceFocus   map[int]string `json:"workspace_focus,omitempty"` // workspace -> focused window ID
+	NumWorkspaces    int            `json:"num_workspaces,omitempty"`  // Number of workspaces, when more were created
+	WorkspaceNames   map[int]string `json:"workspace_names,omitempty"` // Workspace names set at runtime ("" = unnamed)
 	MasterRatio      float64        `json:"master_ratio"`
 	AutoTiling       bool           `json:"auto_tiling"`
 	Width            int            `json:"width"`
@@ -86,6 +100,17 @@ type SessionState struct {
 	WindowToBSPID   map[string]int             `json:"window_to_bsp_id,omitempty"` // Window UUID -> BSP int ID
 	NextBSPWindowID int                        `json:"next_bsp_window_id,omitempty"`
 	TilingScheme    int                        `json:"tiling_scheme,omitempty"` // Default auto-insertion scheme
+	// Registered tiling layouts
+	WorkspaceTilingLayouts map[int]string `json:"workspace_tiling_layouts,omitempty"` // Layout name per workspace (absent = bsp)
+	WorkspaceMasterCounts  map[int]int    `json:"workspace_master_counts,omitempty"`  // Number of master windows per workspace (absent = 1)
+}
+
+// TilingLayout returns the tiling layout name for a workspace.
+func (s *SessionState) TilingLayout(workspace int) string {
+	if name := s.WorkspaceTilingLayouts[workspace]; name != "" {
+		return name
+	}
+	return "bsp"
 }
 
 // PTY represents a daemon-managed pseudo-terminal.
@@ -188,6 +213,13 @@ func NewSession(name string, cfg *SessionConfig, width, height int) (*Session, e
 
 // CreatePTY creates a new PTY in this session.
 func (s *Session) CreatePTY(width, height int) (*PTY, error) {
+	return s.CreateCommandPTY(width, height, "")
+}
+
+// CreateCommandPTY creates a new PTY in this session running command with
+// the platform shell instead of the user's shell. An empty command starts
+// the user's shell.
+func (s *Session) CreateCommandPTY(width, height int, command string) (*PTY, error) {
 	s.ptysMu.Lock()
 	defer s.ptysMu.Unlock()
 
@@ -205,6 +237,11 @@ func (s *Session) CreatePTY(width, height int) (*PTY, error) {
 
 	// Create command
 	cmd := exec.Command(shell)
+	if command != "" {
+		name, args := system.ShellCommand(command)
+		// #nosec G204 - running the user's configured command is intentional
+		cmd = exec.Command(name, args...)
+	}
 	cmd.Env = s.buildEnv()
 
 	// Set up the command to use the PTY as controlling terminal
@@ -699,6 +736,15 @@ func (p *PTY) Close() error {
 	return nil
 }
 
+// Foreground returns the PID and command name of the PTY's foreground
+// process, or 0 and "" when it cannot be determined.
+func (p *PTY) Foreground() (int, string) {
+	if p.pty == nil || p.IsExited() {
+		return 0, ""
+	}
+	return system.ForegroundProcess(p.pty.Fd())
+}
+
 // IsExited returns true if the shell process has exited.
 func (p *PTY) IsExited() bool {
 	p.exitedMu.RLock()
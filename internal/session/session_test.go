@@ -1509,6 +1509,7 @@ func TestAttachedPayloadWithState(t *testing.T) {
 		Width:       160,
 		Height:      48,
 		WindowCount: 2,
+		Created:     true,
 		State:       state,
 	}
 
@@ -1542,6 +1543,9 @@ func TestAttachedPayloadWithState(t *testing.T) {
 	if decoded.Height != original.Height {
 		t.Errorf("Height mismatch: got %d, want %d", decoded.Height, original.Height)
 	}
+	if !decoded.Created {
+		t.Error("Created should be true")
+	}
 
 	// Verify state is included
 	if decoded.State == nil {
@@ -38,6 +38,9 @@ type TUIClient struct {
 	sessionID   string
 	sessionName string
 
+	// Whether attaching created the session
+	sessionCreated bool
+
 	// Effective session dimensions (min of all connected clients)
 	effectiveWidth  int
 	effectiveHeight int
@@ -213,6 +216,7 @@ func (c *TUIClient) AttachSession(name string, createNew bool, width, height int
 		}
 		c.sessionID = payload.SessionID
 		c.sessionName = payload.SessionName
+		c.sessionCreated = payload.Created
 		c.effectiveWidth = payload.Width
 		c.effectiveHeight = payload.Height
 		return payload.State, nil
@@ -238,10 +242,17 @@ func (c *TUIClient) Detach() error {
 
 // CreatePTY creates a new PTY in the session.
 func (c *TUIClient) CreatePTY(title string, width, height int) (string, error) {
+	return c.CreateCommandPTY(title, width, height, "")
+}
+
+// CreateCommandPTY creates a new PTY in the session running command instead
+// of the shell. An empty command starts the shell.
+func (c *TUIClient) CreateCommandPTY(title string, width, height int, command string) (string, error) {
 	msg, err := NewMessageWithCodec(MsgCreatePTY, &CreatePTYPayload{
-		Title:  title,
-		Width:  width,
-		Height: height,
+		Title:   title,
+		Width:   width,
+		Height:  height,
+		Command: command,
 	}, c.codec)
 	if err != nil {
 		return "", err
@@ -270,6 +281,26 @@ func (c *TUIClient) CreatePTY(title string, width, height int) (string, error) {
 	}
 }
 
+// ListPTYs returns the PTYs of the attached session with their foreground
+// processes. Only the list response is waited for, so a poll never takes over
+// the error response another request is waiting for.
+func (c *TUIClient) ListPTYs() ([]PTYInfo, error) {
+	msg, err := NewMessageWithCodec(MsgListPTYs, nil, c.codec)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.sendAndWaitResponse(msg, MsgPTYList)
+	if err != nil {
+		return nil, err
+	}
+	var payload PTYListPayload
+	if err := resp.ParsePayloadWithCodec(&payload, c.codec); err != nil {
+		return nil, err
+	}
+	return payload.PTYs, nil
+}
+
 // ClosePTY closes a PTY.
 func (c *TUIClient) ClosePTY(ptyID string) error {
 	msg, err := NewMessageWithCodec(MsgClosePTY, &ClosePTYPayload{PTYID: ptyID}, c.codec)
@@ -788,6 +819,11 @@ func (c *TUIClient) SessionName() string {
 	return c.sessionName
 }
 
+// SessionCreated reports whether attaching created a new session.
+func (c *TUIClient) SessionCreated() bool {
+	return c.sessionCreated
+}
+
 // EffectiveWidth returns the effective session width (min of all connected clients).
 // Returns 0 if not yet set (before attach).
 func (c *TUIClient) EffectiveWidth() int {
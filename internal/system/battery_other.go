@@ -0,0 +1,10 @@
+//go:build !linux
+
+package system
+
+// readBattery reports no battery: the charge is only read from sysfs on
+// Linux, so the battery status segment stays empty and takes no space on
+// other platforms.
+func readBattery() (Battery, bool) {
+	return Battery{}, false
+}
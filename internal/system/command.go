@@ -0,0 +1,14 @@
+package system
+
+import "runtime"
+
+// ShellCommand returns the program and arguments that run a command line
+// with the platform's standard shell: sh -c, or cmd /C on Windows. The
+// user's own shell is not used, so the command behaves the same whatever
+// shell it is.
+func ShellCommand(command string) (string, []string) {
+	if runtime.GOOS == "windows" {
+		return "cmd", []string{"/C", command}
+	}
+	return "sh", []string{"-c", command}
+}
@@ -0,0 +1,47 @@
+//go:build !windows
+
+package system
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// ForegroundProcess returns the PID and command name of the foreground
+// process group of the terminal open as fd, which is the shell itself when
+// nothing else is running. It returns 0 and "" when it cannot be determined.
+func ForegroundProcess(fd uintptr) (int, string) {
+	var fgpgrp int32
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		fd,
+		uintptr(unix.TIOCGPGRP),
+		uintptr(unsafe.Pointer(&fgpgrp)),
+	)
+	if errno != 0 || fgpgrp <= 0 {
+		return 0, ""
+	}
+	return int(fgpgrp), processName(int(fgpgrp))
+}
+
+// processName returns the command name of a process, using /proc where it
+// exists and ps elsewhere.
+func processName(pid int) string {
+	if comm, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid)); err == nil {
+		return strings.TrimSpace(string(comm))
+	}
+	// #nosec G204 - pid is an integer from the kernel
+	out, err := exec.Command("ps", "-o", "comm=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(strings.TrimSpace(string(out)))
+}
@@ -0,0 +1,39 @@
+//go:build !windows
+
+package system
+
+import (
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/xpty"
+)
+
+func TestForegroundProcess(t *testing.T) {
+	pty, err := xpty.NewPty(80, 24)
+	if err != nil {
+		t.Skipf("no pty available: %v", err)
+	}
+	defer func() { _ = pty.Close() }()
+
+	cmd := exec.Command("sleep", "5")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true}
+	if err := pty.Start(cmd); err != nil {
+		t.Skipf("could not start sleep: %v", err)
+	}
+	defer func() { _ = cmd.Process.Kill(); _ = cmd.Wait() }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		pid, name := ForegroundProcess(pty.Fd())
+		if pid == cmd.Process.Pid && name == "sleep" {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("ForegroundProcess = %d, %q, want %d, \"sleep\"", pid, name, cmd.Process.Pid)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package system
+
+// ForegroundProcess is not supported on Windows, where ConPTY has no
+// foreground process group. It always returns 0 and "".
+func ForegroundProcess(fd uintptr) (int, string) {
+	return 0, ""
+}
@@ -0,0 +1,85 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/load"
+)
+
+// Battery is the charge of the system battery.
+type Battery struct {
+	Percent  int
+	Charging bool
+}
+
+// LoadAverage returns the one minute load average.
+func LoadAverage() (float64, error) {
+	avg, err := load.Avg()
+	if err != nil {
+		return 0, err
+	}
+	return avg.Load1, nil
+}
+
+// ReadBattery returns the charge of the first battery, or false if the
+// system has none or is not Linux, the only platform it is read on.
+func ReadBattery() (Battery, bool) {
+	return readBattery()
+}
+
+// GitBranch returns the branch checked out in the git repository containing
+// dir, the short commit hash when HEAD is detached, or "" outside a
+// repository. It reads .git/HEAD directly so it can run on every refresh.
+func GitBranch(dir string) string {
+	for dir != "" {
+		gitPath := filepath.Join(dir, ".git")
+		info, err := os.Stat(gitPath)
+		if err == nil {
+			headPath := filepath.Join(gitPath, "HEAD")
+			if !info.IsDir() {
+				// Worktrees and submodules have a .git file pointing at the git dir
+				headPath = gitDirFromFile(gitPath, dir)
+				if headPath == "" {
+					return ""
+				}
+				headPath = filepath.Join(headPath, "HEAD")
+			}
+			// #nosec G304 - reading the HEAD of the window's repository is intentional
+			head, err := os.ReadFile(headPath)
+			if err != nil {
+				return ""
+			}
+			ref := strings.TrimSpace(string(head))
+			if branch, ok := strings.CutPrefix(ref, "ref: refs/heads/"); ok {
+				return branch
+			}
+			return ref[:min(len(ref), 7)]
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return ""
+}
+
+// gitDirFromFile reads the "gitdir:" line of a .git file.
+func gitDirFromFile(path, dir string) string {
+	// #nosec G304 - path is the .git file of the window's repository
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(dir, gitDir)
+	}
+	return gitDir
+}
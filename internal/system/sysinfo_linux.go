@@ -5,6 +5,7 @@ package system
 import (
 	"bufio"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -86,3 +87,24 @@ func (c *CPUMonitor) readCPUUsage() (float64, error) {
 
 	return usage, nil
 }
+
+// readBattery reads the first battery under /sys/class/power_supply.
+func readBattery() (Battery, bool) {
+	matches, _ := filepath.Glob("/sys/class/power_supply/BAT*")
+	for _, dir := range matches {
+		capacity, err := os.ReadFile(filepath.Join(dir, "capacity")) // #nosec G304 - sysfs path
+		if err != nil {
+			continue
+		}
+		percent, err := strconv.Atoi(strings.TrimSpace(string(capacity)))
+		if err != nil {
+			continue
+		}
+		status, _ := os.ReadFile(filepath.Join(dir, "status")) // #nosec G304 - sysfs path
+		return Battery{
+			Percent:  percent,
+			Charging: strings.TrimSpace(string(status)) == "Charging",
+		}, true
+	}
+	return Battery{}, false
+}
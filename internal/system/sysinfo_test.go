@@ -1,6 +1,8 @@
 package system
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -363,3 +365,34 @@ func BenchmarkUpdateCPUHistory(b *testing.B) {
 		}
 	}
 }
+
+// TestGitBranch tests reading the branch of a repository from a subdirectory.
+func TestGitBranch(t *testing.T) {
+	repo := t.TempDir()
+	sub := filepath.Join(repo, "src", "pkg")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	head := filepath.Join(repo, ".git", "HEAD")
+
+	if err := os.WriteFile(head, []byte("ref: refs/heads/feature/status\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := GitBranch(sub); got != "feature/status" {
+		t.Errorf("GitBranch = %q, want feature/status", got)
+	}
+
+	if err := os.WriteFile(head, []byte("0123456789abcdef0123456789abcdef01234567\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := GitBranch(repo); got != "0123456" {
+		t.Errorf("GitBranch on a detached HEAD = %q, want the short hash", got)
+	}
+
+	if got := GitBranch(t.TempDir()); got != "" {
+		t.Errorf("GitBranch outside a repository = %q, want empty", got)
+	}
+}
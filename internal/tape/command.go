@@ -72,6 +72,24 @@ const (
 	CommandTypeEnableTiling CommandType = "EnableTiling"
 	// CommandTypeDisableTiling represents the DisableTiling command.
 	CommandTypeDisableTiling CommandType = "DisableTiling"
+	// CommandTypeSetLayout represents the SetLayout command.
+	CommandTypeSetLayout CommandType = "SetLayout"
+	// CommandTypeToggleFloating represents the ToggleFloating command.
+	CommandTypeToggleFloating CommandType = "ToggleFloating"
+	// CommandTypeToggleZoom represents the ToggleZoom command.
+	CommandTypeToggleZoom CommandType = "ToggleZoom"
+	// CommandTypeToggleSticky represents the ToggleSticky command.
+	CommandTypeToggleSticky CommandType = "ToggleSticky"
+	// CommandTypeToggleScratchpad represents the ToggleScratchpad command.
+	CommandTypeToggleScratchpad CommandType = "ToggleScratchpad"
+	// CommandTypeUndoLayout represents the UndoLayout command.
+	CommandTypeUndoLayout CommandType = "UndoLayout"
+	// CommandTypeRedoLayout represents the RedoLayout command.
+	CommandTypeRedoLayout CommandType = "RedoLayout"
+	// CommandTypeSaveLayout represents the SaveLayout command.
+	CommandTypeSaveLayout CommandType = "SaveLayout"
+	// CommandTypeLoadLayout represents the LoadLayout command.
+	CommandTypeLoadLayout CommandType = "LoadLayout"
 	// CommandTypeSnapLeft represents the SnapLeft command.
 	CommandTypeSnapLeft CommandType = "SnapLeft"
 	// CommandTypeSnapRight represents the SnapRight command.
@@ -85,6 +103,8 @@ const (
 	CommandTypeMoveToWS CommandType = "MoveToWorkspace"
 	// CommandTypeMoveAndFollowWS represents the MoveAndFollowWorkspace command.
 	CommandTypeMoveAndFollowWS CommandType = "MoveAndFollowWorkspace"
+	// CommandTypeRenameWorkspace represents the RenameWorkspace command.
+	CommandTypeRenameWorkspace CommandType = "RenameWorkspace"
 
 	// CommandTypeSplit represents the Split command (horizontal/vertical).
 	CommandTypeSplit CommandType = "Split"
@@ -172,8 +192,8 @@ func (ct CommandType) IsCommand() bool {
 		CommandTypePrevWindow, CommandTypeFocusWindow, CommandTypeRenameWindow,
 		CommandTypeMinimizeWindow, CommandTypeRestoreWindow,
 		CommandTypeToggleTiling, CommandTypeEnableTiling, CommandTypeDisableTiling,
-		CommandTypeSnapLeft, CommandTypeSnapRight, CommandTypeSnapFullscreen,
-		CommandTypeSwitchWS, CommandTypeMoveToWS, CommandTypeMoveAndFollowWS,
+		CommandTypeSetLayout, CommandTypeToggleFloating, CommandTypeToggleZoom, CommandTypeToggleSticky, CommandTypeToggleScratchpad, CommandTypeUndoLayout, CommandTypeRedoLayout, CommandTypeSaveLayout, CommandTypeLoadLayout, CommandTypeSnapLeft, CommandTypeSnapRight, CommandTypeSnapFullscreen,
+		CommandTypeSwitchWS, CommandTypeMoveToWS, CommandTypeMoveAndFollowWS, CommandTypeRenameWorkspace,
 		CommandTypeSplit, CommandTypeFocus, CommandTypeRotateSplit,
 		CommandTypeEqualizeSplits, CommandTypePreselect,
 		CommandTypeWait, CommandTypeWaitUntilRegex,
@@ -2,6 +2,7 @@ package tape
 
 import (
 	"fmt"
+	"runtime"
 	"strings"
 	"unicode"
 )
@@ -41,6 +42,15 @@ type Executor interface {
 	ToggleTiling() error
 	EnableTiling() error
 	DisableTiling() error
+	SetLayout(name string) error            // Registered layout name, or "bsp"
+	ToggleFloating() error                  // Float the focused window above the layout, or tile it again
+	ToggleZoom() error                      // Show the focused window over its whole workspace, or stop
+	ToggleSticky() error                    // Show the focused window on every workspace, or pin it
+	ToggleScratchpad(name string) error     // Show or hide a scratchpad from the config
+	UndoLayout() error                      // Restore the workspace's previous layout
+	RedoLayout() error                      // Reapply the last undone layout change
+	SaveNamedLayout(name string) error      // Save the workspace layout to disk under a name
+	LoadNamedLayout(name string) error      // Apply a saved layout to the workspace's windows
 	SnapByDirection(direction string) error // "left", "right", "fullscreen"
 
 	// BSP Tiling
@@ -54,6 +64,8 @@ type Executor interface {
 	SwitchWorkspace(workspace int) error
 	MoveWindowToWorkspaceByID(windowID string, workspace int) error
 	MoveAndFollowWorkspaceByID(windowID string, workspace int) error
+	ResolveWorkspace(ref string) (int, error) // Workspace number or name, created if workspaces are dynamic
+	RenameCurrentWorkspace(name string) error // Empty name clears it
 
 	// Animations
 	EnableAnimations() error
@@ -91,12 +103,12 @@ func (ce *CommandExecutor) Execute(cmd *Command) error {
 			return ce.executor.SendToWindow(ce.executor.GetFocusedWindowID(), []byte(cmd.Args[0]))
 		}
 
-    case CommandTypeEnter:
-        // Windows requires \r\n, Unix accepts \n
-        if runtime.GOOS == "windows" {
-            return ce.executor.SendToWindow(ce.executor.GetFocusedWindowID(), []byte{'\r', '\n'})
-        }
-        return ce.executor.SendToWindow(ce.executor.GetFocusedWindowID(), []byte{'\n'})
+	case CommandTypeEnter:
+		// Windows requires \r\n, Unix accepts \n
+		if runtime.GOOS == "windows" {
+			return ce.executor.SendToWindow(ce.executor.GetFocusedWindowID(), []byte{'\r', '\n'})
+		}
+		return ce.executor.SendToWindow(ce.executor.GetFocusedWindowID(), []byte{'\n'})
 
 	case CommandTypeSpace:
 		return ce.executor.SendToWindow(ce.executor.GetFocusedWindowID(), []byte{' '})
@@ -163,7 +175,11 @@ func (ce *CommandExecutor) Execute(cmd *Command) error {
 
 	case CommandTypeRestoreWindow:
 		if len(cmd.Args) > 0 && cmd.Args[0] != "" {
-			return ce.executor.RestoreWindowByName(cmd.Args[0])
+			// Try as name first, fall back to ID as FocusWindow does
+			if err := ce.executor.RestoreWindowByName(cmd.Args[0]); err != nil {
+				return ce.executor.RestoreWindowByID(cmd.Args[0])
+			}
+			return nil
 		}
 		return ce.executor.RestoreWindowByID(ce.executor.GetFocusedWindowID())
 
@@ -177,6 +193,45 @@ func (ce *CommandExecutor) Execute(cmd *Command) error {
 	case CommandTypeDisableTiling:
 		return ce.executor.DisableTiling()
 
+	case CommandTypeSetLayout:
+		if len(cmd.Args) > 0 {
+			return ce.executor.SetLayout(strings.ToLower(cmd.Args[0]))
+		}
+		return nil
+
+	case CommandTypeToggleFloating:
+		return ce.executor.ToggleFloating()
+
+	case CommandTypeToggleZoom:
+		return ce.executor.ToggleZoom()
+
+	case CommandTypeToggleSticky:
+		return ce.executor.ToggleSticky()
+
+	case CommandTypeToggleScratchpad:
+		if len(cmd.Args) > 0 {
+			return ce.executor.ToggleScratchpad(cmd.Args[0])
+		}
+		return nil
+
+	case CommandTypeUndoLayout:
+		return ce.executor.UndoLayout()
+
+	case CommandTypeRedoLayout:
+		return ce.executor.RedoLayout()
+
+	case CommandTypeSaveLayout:
+		if len(cmd.Args) > 0 {
+			return ce.executor.SaveNamedLayout(cmd.Args[0])
+		}
+		return nil
+
+	case CommandTypeLoadLayout:
+		if len(cmd.Args) > 0 {
+			return ce.executor.LoadNamedLayout(cmd.Args[0])
+		}
+		return nil
+
 	case CommandTypeSnapLeft:
 		return ce.executor.SnapByDirection("left")
 
@@ -214,25 +269,36 @@ func (ce *CommandExecutor) Execute(cmd *Command) error {
 	// Workspace
 	case CommandTypeSwitchWS:
 		if len(cmd.Args) > 0 {
-			ws := 0
-			_, _ = fmt.Sscanf(cmd.Args[0], "%d", &ws)
+			ws, err := ce.executor.ResolveWorkspace(cmd.Args[0])
+			if err != nil {
+				return err
+			}
 			return ce.executor.SwitchWorkspace(ws)
 		}
 
 	case CommandTypeMoveToWS:
 		if len(cmd.Args) > 0 {
-			ws := 0
-			_, _ = fmt.Sscanf(cmd.Args[0], "%d", &ws)
+			ws, err := ce.executor.ResolveWorkspace(cmd.Args[0])
+			if err != nil {
+				return err
+			}
 			return ce.executor.MoveWindowToWorkspaceByID(ce.executor.GetFocusedWindowID(), ws)
 		}
 
 	case CommandTypeMoveAndFollowWS:
 		if len(cmd.Args) > 0 {
-			ws := 0
-			_, _ = fmt.Sscanf(cmd.Args[0], "%d", &ws)
+			ws, err := ce.executor.ResolveWorkspace(cmd.Args[0])
+			if err != nil {
+				return err
+			}
 			return ce.executor.MoveAndFollowWorkspaceByID(ce.executor.GetFocusedWindowID(), ws)
 		}
 
+	case CommandTypeRenameWorkspace:
+		if len(cmd.Args) > 0 {
+			return ce.executor.RenameCurrentWorkspace(cmd.Args[0])
+		}
+
 	case CommandTypeKeyCombo:
 		if len(cmd.Args) > 0 {
 			comboStr := cmd.Args[0]
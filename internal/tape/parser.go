@@ -125,6 +125,24 @@ func (p *Parser) parseCommand() (Command, bool) {
 		return p.parseBasicCommand(CommandTypeEnableTiling)
 	case TokenDisableTiling:
 		return p.parseBasicCommand(CommandTypeDisableTiling)
+	case TokenSetLayout:
+		return p.parseNameArgCommand(CommandTypeSetLayout, "a layout name")
+	case TokenToggleFloating:
+		return p.parseBasicCommand(CommandTypeToggleFloating)
+	case TokenToggleZoom:
+		return p.parseBasicCommand(CommandTypeToggleZoom)
+	case TokenToggleSticky:
+		return p.parseBasicCommand(CommandTypeToggleSticky)
+	case TokenToggleScratchpad:
+		return p.parseNameArgCommand(CommandTypeToggleScratchpad, "a scratchpad name")
+	case TokenUndoLayout:
+		return p.parseBasicCommand(CommandTypeUndoLayout)
+	case TokenRedoLayout:
+		return p.parseBasicCommand(CommandTypeRedoLayout)
+	case TokenSaveLayout:
+		return p.parseNameArgCommand(CommandTypeSaveLayout, "a layout name")
+	case TokenLoadLayout:
+		return p.parseNameArgCommand(CommandTypeLoadLayout, "a layout name")
 	case TokenSnapLeft:
 		return p.parseBasicCommand(CommandTypeSnapLeft)
 	case TokenSnapRight:
@@ -132,11 +150,13 @@ func (p *Parser) parseCommand() (Command, bool) {
 	case TokenSnapFullscreen:
 		return p.parseBasicCommand(CommandTypeSnapFullscreen)
 	case TokenSwitchWS:
-		return p.parseSwitchWorkspaceCommand()
+		return p.parseWorkspaceCommand(CommandTypeSwitchWS)
 	case TokenMoveToWS:
-		return p.parseMoveToWorkspaceCommand()
+		return p.parseWorkspaceCommand(CommandTypeMoveToWS)
 	case TokenMoveAndFollowWS:
-		return p.parseMoveAndFollowWorkspaceCommand()
+		return p.parseWorkspaceCommand(CommandTypeMoveAndFollowWS)
+	case TokenRenameWorkspace:
+		return p.parseNameArgCommand(CommandTypeRenameWorkspace, "a workspace name")
 	case TokenSplit:
 		return p.parseBasicCommand(CommandTypeSplit)
 	case TokenFocus:
@@ -352,76 +372,28 @@ func (p *Parser) parseFocusCommand() (Command, bool) {
 	return cmd, true
 }
 
-// parseSwitchWorkspaceCommand parses SwitchWorkspace <n> or Alt+N commands
-func (p *Parser) parseSwitchWorkspaceCommand() (Command, bool) {
+// parseWorkspaceCommand parses commands that take a workspace, given by
+// number or name, like SwitchWorkspace <n|name>
+func (p *Parser) parseWorkspaceCommand(cmdType CommandType) (Command, bool) {
 	cmd := Command{
-		Type:   CommandTypeSwitchWS,
-		Line:   p.curTok.Line,
-		Column: p.curTok.Column,
-	}
-
-	p.nextToken() // consume SwitchWorkspace
-
-	if p.curTok.Type == TokenNumber {
-		cmd.Args = []string{p.curTok.Literal}
-		cmd.Raw = fmt.Sprintf("SwitchWorkspace %s", p.curTok.Literal)
-		p.nextToken()
-	} else {
-		p.addError(fmt.Sprintf("SwitchWorkspace expects a number, got %v", p.curTok.Type))
-		p.skipToNextLine()
-		return cmd, false
-	}
-
-	if p.curTok.Type != TokenNewline && p.curTok.Type != TokenEOF {
-		p.skipToNextLine()
-	}
-
-	return cmd, true
-}
-
-// parseMoveToWorkspaceCommand parses MoveToWorkspace <n> commands
-func (p *Parser) parseMoveToWorkspaceCommand() (Command, bool) {
-	cmd := Command{
-		Type:   CommandTypeMoveToWS,
+		Type:   cmdType,
 		Line:   p.curTok.Line,
 		Column: p.curTok.Column,
 	}
 
-	p.nextToken() // consume MoveToWorkspace
+	p.nextToken() // consume command
 
-	if p.curTok.Type == TokenNumber {
+	switch p.curTok.Type {
+	case TokenNumber:
 		cmd.Args = []string{p.curTok.Literal}
-		cmd.Raw = fmt.Sprintf("MoveToWorkspace %s", p.curTok.Literal)
+		cmd.Raw = fmt.Sprintf("%s %s", cmdType, p.curTok.Literal)
 		p.nextToken()
-	} else {
-		p.addError(fmt.Sprintf("MoveToWorkspace expects a number, got %v", p.curTok.Type))
-		p.skipToNextLine()
-		return cmd, false
-	}
-
-	if p.curTok.Type != TokenNewline && p.curTok.Type != TokenEOF {
-		p.skipToNextLine()
-	}
-
-	return cmd, true
-}
-
-// parseMoveAndFollowWorkspaceCommand parses MoveAndFollowWorkspace <n> commands
-func (p *Parser) parseMoveAndFollowWorkspaceCommand() (Command, bool) {
-	cmd := Command{
-		Type:   CommandTypeMoveAndFollowWS,
-		Line:   p.curTok.Line,
-		Column: p.curTok.Column,
-	}
-
-	p.nextToken() // consume MoveAndFollowWorkspace
-
-	if p.curTok.Type == TokenNumber {
+	case TokenString, TokenIdentifier:
 		cmd.Args = []string{p.curTok.Literal}
-		cmd.Raw = fmt.Sprintf("MoveAndFollowWorkspace %s", p.curTok.Literal)
+		cmd.Raw = fmt.Sprintf("%s %q", cmdType, p.curTok.Literal)
 		p.nextToken()
-	} else {
-		p.addError(fmt.Sprintf("MoveAndFollowWorkspace expects a number, got %v", p.curTok.Type))
+	default:
+		p.addError(fmt.Sprintf("%s expects a workspace number or name, got %v", cmdType, p.curTok.Type))
 		p.skipToNextLine()
 		return cmd, false
 	}
@@ -618,6 +590,34 @@ func (p *Parser) parseOutputCommand() (Command, bool) {
 	return cmd, true
 }
 
+// parseNameArgCommand parses commands that take a single name, quoted or
+// bare, such as SetLayout "<layout>" or ToggleScratchpad <name>
+func (p *Parser) parseNameArgCommand(cmdType CommandType, what string) (Command, bool) {
+	cmd := Command{
+		Type:   cmdType,
+		Line:   p.curTok.Line,
+		Column: p.curTok.Column,
+	}
+
+	p.nextToken() // consume command
+
+	if p.curTok.Type == TokenString || p.curTok.Type == TokenIdentifier {
+		cmd.Args = []string{p.curTok.Literal}
+		cmd.Raw = fmt.Sprintf("%s %q", cmdType, p.curTok.Literal)
+		p.nextToken()
+	} else {
+		p.addError(fmt.Sprintf("%s command expects %s", cmdType, what))
+		p.skipToNextLine()
+		return cmd, false
+	}
+
+	if p.curTok.Type != TokenNewline && p.curTok.Type != TokenEOF {
+		p.skipToNextLine()
+	}
+
+	return cmd, true
+}
+
 // parseSourceCommand parses Source <file> commands
 func (p *Parser) parseSourceCommand() (Command, bool) {
 	cmd := Command{
@@ -199,6 +199,46 @@ func TestParserTuiosActions(t *testing.T) {
 			input:        `ToggleTiling`,
 			expectedType: CommandTypeToggleTiling,
 		},
+		{
+			name:         "ToggleFloating",
+			input:        `ToggleFloating`,
+			expectedType: CommandTypeToggleFloating,
+		},
+		{
+			name:         "ToggleZoom",
+			input:        `ToggleZoom`,
+			expectedType: CommandTypeToggleZoom,
+		},
+		{
+			name:         "ToggleSticky",
+			input:        `ToggleSticky`,
+			expectedType: CommandTypeToggleSticky,
+		},
+		{
+			name:         "ToggleScratchpad",
+			input:        `ToggleScratchpad "notes"`,
+			expectedType: CommandTypeToggleScratchpad,
+		},
+		{
+			name:         "UndoLayout",
+			input:        `UndoLayout`,
+			expectedType: CommandTypeUndoLayout,
+		},
+		{
+			name:         "RedoLayout",
+			input:        `RedoLayout`,
+			expectedType: CommandTypeRedoLayout,
+		},
+		{
+			name:         "SaveLayout",
+			input:        `SaveLayout "review"`,
+			expectedType: CommandTypeSaveLayout,
+		},
+		{
+			name:         "LoadLayout",
+			input:        `LoadLayout review`,
+			expectedType: CommandTypeLoadLayout,
+		},
 	}
 
 	for _, tt := range tests {
@@ -239,6 +279,60 @@ func TestParserSwitchWorkspace(t *testing.T) {
 	}
 }
 
+func TestParserWorkspaceNames(t *testing.T) {
+	commands, errs := ParseFile(`SwitchWorkspace api
+MoveToWorkspace "db"
+MoveAndFollowWorkspace 3
+RenameWorkspace infra`)
+
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	want := []struct {
+		typ CommandType
+		arg string
+	}{
+		{CommandTypeSwitchWS, "api"},
+		{CommandTypeMoveToWS, "db"},
+		{CommandTypeMoveAndFollowWS, "3"},
+		{CommandTypeRenameWorkspace, "infra"},
+	}
+	if len(commands) != len(want) {
+		t.Fatalf("Expected %d commands, got %d", len(want), len(commands))
+	}
+	for i, w := range want {
+		if commands[i].Type != w.typ || len(commands[i].Args) == 0 || commands[i].Args[0] != w.arg {
+			t.Errorf("Command %d: expected %s %s, got %s %v", i, w.typ, w.arg, commands[i].Type, commands[i].Args)
+		}
+	}
+}
+
+func TestParserSetLayout(t *testing.T) {
+	commands, errs := ParseFile(`SetLayout "centered-master"
+SetLayout monocle`)
+
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	if len(commands) != 2 {
+		t.Fatalf("Expected 2 commands, got %d", len(commands))
+	}
+
+	for i, want := range []string{"centered-master", "monocle"} {
+		cmd := commands[i]
+		if cmd.Type != CommandTypeSetLayout {
+			t.Errorf("Expected CommandTypeSetLayout, got %v", cmd.Type)
+		}
+		if len(cmd.Args) != 1 || cmd.Args[0] != want {
+			t.Errorf("Expected layout %q, got %v", want, cmd.Args)
+		}
+	}
+
+	if _, errs := ParseFile(`SetLayout`); len(errs) == 0 {
+		t.Error("Expected error for SetLayout without a layout name")
+	}
+}
+
 func TestParserComments(t *testing.T) {
 	input := `# This is a comment
 Type "hello"
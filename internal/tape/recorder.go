@@ -200,6 +200,11 @@ var actionToCommand = map[string]struct {
 	"minimize_window": {CommandTypeMinimizeWindow, "MinimizeWindow"},
 	"restore_all":     {CommandTypeRestoreWindow, "RestoreWindow"},
 	"toggle_tiling":   {CommandTypeToggleTiling, "ToggleTiling"},
+	"toggle_floating": {CommandTypeToggleFloating, "ToggleFloating"},
+	"toggle_zoom":     {CommandTypeToggleZoom, "ToggleZoom"},
+	"toggle_sticky":   {CommandTypeToggleSticky, "ToggleSticky"},
+	"undo_layout":     {CommandTypeUndoLayout, "UndoLayout"},
+	"redo_layout":     {CommandTypeRedoLayout, "RedoLayout"},
 	"snap_left":       {CommandTypeSnapLeft, "SnapLeft"},
 	"snap_right":      {CommandTypeSnapRight, "SnapRight"},
 	"snap_fullscreen": {CommandTypeSnapFullscreen, "SnapFullscreen"},
@@ -0,0 +1,72 @@
+package tape
+
+import "strings"
+
+// CommandInfo describes a command that controls TUIOS, for listings such as
+// shell completion and the command palette.
+type CommandInfo struct {
+	Type        CommandType
+	Args        string // Argument synopsis, empty for commands without arguments
+	Description string
+}
+
+// NeedsArgs reports whether the command takes a required argument.
+func (c CommandInfo) NeedsArgs() bool {
+	return len(c.Args) > 0 && c.Args[0] == '<'
+}
+
+// Commands lists the commands that control TUIOS. Commands that type into
+// windows or only make sense in scripts, such as Type or Wait, are left out.
+var Commands = []CommandInfo{
+	{CommandTypeNewWindow, "[name]", "Create a new terminal window"},
+	{CommandTypeCloseWindow, "[id-or-name]", "Close the focused window"},
+	{CommandTypeNextWindow, "", "Focus the next window"},
+	{CommandTypePrevWindow, "", "Focus the previous window"},
+	{CommandTypeFocusWindow, "<id-or-name>", "Focus a specific window"},
+	{CommandTypeFocusDirection, "<left|right|up|down>", "Focus window in direction"},
+	{CommandTypeRenameWindow, "<name>", "Rename the focused window"},
+	{CommandTypeMinimizeWindow, "[id-or-name]", "Minimize the focused window"},
+	{CommandTypeRestoreWindow, "[id-or-name]", "Restore the focused window"},
+	{CommandTypeTerminalMode, "", "Switch to terminal mode"},
+	{CommandTypeWindowManagementMode, "", "Switch to window management mode"},
+	{CommandTypeToggleTiling, "", "Toggle tiling mode"},
+	{CommandTypeEnableTiling, "", "Enable tiling mode"},
+	{CommandTypeDisableTiling, "", "Disable tiling mode"},
+	{CommandTypeSetLayout, "<layout>", "Set the tiling layout of the current workspace"},
+	{CommandTypeToggleFloating, "", "Float or tile the focused window"},
+	{CommandTypeToggleZoom, "", "Zoom the focused window over the layout"},
+	{CommandTypeToggleSticky, "", "Show the focused window on every workspace"},
+	{CommandTypeToggleScratchpad, "<name>", "Show or hide a configured scratchpad"},
+	{CommandTypeUndoLayout, "", "Undo the last layout change"},
+	{CommandTypeRedoLayout, "", "Redo the last undone layout change"},
+	{CommandTypeSaveLayout, "<name>", "Save the workspace layout under a name"},
+	{CommandTypeLoadLayout, "<name>", "Apply a saved layout to the workspace"},
+	{CommandTypeSnapLeft, "", "Snap window to left"},
+	{CommandTypeSnapRight, "", "Snap window to right"},
+	{CommandTypeSnapFullscreen, "", "Snap window fullscreen"},
+	{CommandTypeSplit, "<horizontal|vertical>", "Split window (horizontal/vertical)"},
+	{CommandTypeRotateSplit, "", "Rotate split direction"},
+	{CommandTypeEqualizeSplits, "", "Equalize all splits"},
+	{CommandTypeSwitchWS, "<number-or-name>", "Switch to workspace by number or name"},
+	{CommandTypeMoveToWS, "<number-or-name>", "Move window to workspace by number or name"},
+	{CommandTypeMoveAndFollowWS, "<number-or-name>", "Move and follow to workspace by number or name"},
+	{CommandTypeRenameWorkspace, "[name]", "Name the current workspace"},
+	{CommandTypeEnableAnimations, "", "Enable animations"},
+	{CommandTypeDisableAnimations, "", "Disable animations"},
+	{CommandTypeToggleAnimations, "", "Toggle animations"},
+	{CommandTypeSetConfig, "<path> <value>", "Set a config option"},
+	{CommandTypeSetTheme, "<theme>", "Change theme"},
+	{CommandTypeSetDockbarPosition, "<top|bottom|hidden>", "Change dockbar position"},
+	{CommandTypeSetBorderStyle, "<style>", "Change border style"},
+	{CommandTypeShowNotification, "<message> [type]", "Show a notification"},
+}
+
+// LookupCommand returns the command with the given name, ignoring case.
+func LookupCommand(name string) (CommandInfo, bool) {
+	for _, c := range Commands {
+		if strings.EqualFold(string(c.Type), name) {
+			return c, true
+		}
+	}
+	return CommandInfo{}, false
+}
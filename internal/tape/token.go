@@ -94,6 +94,24 @@ const (
 	TokenEnableTiling TokenType = "EnableTiling"
 	// TokenDisableTiling represents the DisableTiling command token.
 	TokenDisableTiling TokenType = "DisableTiling"
+	// TokenSetLayout represents the SetLayout command token.
+	TokenSetLayout TokenType = "SetLayout"
+	// TokenToggleFloating represents the ToggleFloating command token.
+	TokenToggleFloating TokenType = "ToggleFloating"
+	// TokenToggleZoom represents the ToggleZoom command token.
+	TokenToggleZoom TokenType = "ToggleZoom"
+	// TokenToggleSticky represents the ToggleSticky command token.
+	TokenToggleSticky TokenType = "ToggleSticky"
+	// TokenToggleScratchpad represents the ToggleScratchpad command token.
+	TokenToggleScratchpad TokenType = "ToggleScratchpad"
+	// TokenUndoLayout represents the UndoLayout command token.
+	TokenUndoLayout TokenType = "UndoLayout"
+	// TokenRedoLayout represents the RedoLayout command token.
+	TokenRedoLayout TokenType = "RedoLayout"
+	// TokenSaveLayout represents the SaveLayout command token.
+	TokenSaveLayout TokenType = "SaveLayout"
+	// TokenLoadLayout represents the LoadLayout command token.
+	TokenLoadLayout TokenType = "LoadLayout"
 	// TokenSnapLeft represents the SnapLeft command token.
 	TokenSnapLeft TokenType = "SnapLeft"
 	// TokenSnapRight represents the SnapRight command token.
@@ -106,6 +124,8 @@ const (
 	TokenMoveToWS TokenType = "MoveToWorkspace"
 	// TokenMoveAndFollowWS represents the MoveAndFollowWorkspace command token.
 	TokenMoveAndFollowWS TokenType = "MoveAndFollowWorkspace"
+	// TokenRenameWorkspace represents the RenameWorkspace command token.
+	TokenRenameWorkspace TokenType = "RenameWorkspace"
 	// TokenSplit represents the Split command token.
 	TokenSplit TokenType = "Split"
 	// TokenFocus represents the Focus command token.
@@ -150,9 +170,9 @@ func (tt TokenType) IsCommand() bool {
 		TokenTerminalMode, TokenWindowManagementMode,
 		TokenNewWindow, TokenCloseWindow, TokenNextWindow, TokenPrevWindow,
 		TokenFocusWindow, TokenRenameWindow, TokenMinimizeWindow, TokenRestoreWindow,
-		TokenToggleTiling, TokenEnableTiling, TokenDisableTiling,
+		TokenToggleTiling, TokenEnableTiling, TokenDisableTiling, TokenSetLayout, TokenToggleFloating, TokenToggleZoom, TokenToggleSticky, TokenToggleScratchpad, TokenUndoLayout, TokenRedoLayout, TokenSaveLayout, TokenLoadLayout,
 		TokenSnapLeft, TokenSnapRight, TokenSnapFullscreen,
-		TokenSwitchWS, TokenMoveToWS, TokenMoveAndFollowWS,
+		TokenSwitchWS, TokenMoveToWS, TokenMoveAndFollowWS, TokenRenameWorkspace,
 		TokenSplit, TokenFocus,
 		TokenWait, TokenWaitUntilRegex,
 		TokenSet, TokenOutput, TokenSource,
@@ -220,17 +240,27 @@ var KeywordTokenMap = map[string]TokenType{
 	"RestoreWindow":  TokenRestoreWindow,
 
 	// Tiling
-	"ToggleTiling":   TokenToggleTiling,
-	"EnableTiling":   TokenEnableTiling,
-	"DisableTiling":  TokenDisableTiling,
-	"SnapLeft":       TokenSnapLeft,
-	"SnapRight":      TokenSnapRight,
-	"SnapFullscreen": TokenSnapFullscreen,
+	"ToggleTiling":     TokenToggleTiling,
+	"EnableTiling":     TokenEnableTiling,
+	"DisableTiling":    TokenDisableTiling,
+	"SetLayout":        TokenSetLayout,
+	"ToggleFloating":   TokenToggleFloating,
+	"ToggleZoom":       TokenToggleZoom,
+	"ToggleSticky":     TokenToggleSticky,
+	"ToggleScratchpad": TokenToggleScratchpad,
+	"UndoLayout":       TokenUndoLayout,
+	"RedoLayout":       TokenRedoLayout,
+	"SaveLayout":       TokenSaveLayout,
+	"LoadLayout":       TokenLoadLayout,
+	"SnapLeft":         TokenSnapLeft,
+	"SnapRight":        TokenSnapRight,
+	"SnapFullscreen":   TokenSnapFullscreen,
 
 	// Workspace
 	"SwitchWorkspace":        TokenSwitchWS,
 	"MoveToWorkspace":        TokenMoveToWS,
 	"MoveAndFollowWorkspace": TokenMoveAndFollowWS,
+	"RenameWorkspace":        TokenRenameWorkspace,
 
 	// Other actions
 	"Split": TokenSplit,
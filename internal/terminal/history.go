@@ -0,0 +1,171 @@
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Gaurav-Gosain/tuios/internal/config"
+	"github.com/Gaurav-Gosain/tuios/internal/vt"
+)
+
+// historyFileExt is the extension of on-disk scrollback history files.
+// Each history file has a JSON sidecar (same name, .json) written on close.
+const historyFileExt = ".tsh"
+
+// HistoryInfo describes a scrollback history file saved by a window.
+type HistoryInfo struct {
+	Path     string              `json:"-"`                 // Path of the history file
+	WindowID string              `json:"window_id"`         // ID of the window that wrote it
+	Title    string              `json:"title"`             // Window name when it was closed
+	Started  time.Time           `json:"started"`           // When the window was created
+	Closed   time.Time           `json:"closed,omitzero"`   // When the window was closed (zero if never closed cleanly)
+	Width    int                 `json:"width"`             // Terminal width when closed
+	Lines    int                 `json:"lines"`             // Number of lines in the file
+	Markers  []vt.SemanticMarker `json:"markers,omitempty"` // OSC 133 markers in file line numbers
+}
+
+// historyMetaPath returns the path of the JSON sidecar for a history file.
+func historyMetaPath(path string) string {
+	return strings.TrimSuffix(path, historyFileExt) + ".json"
+}
+
+// enableHistory attaches an on-disk history file to the window's terminal
+// when scrollback persistence is enabled. Failures leave the window with
+// in-memory scrollback only and are reported by HistoryError.
+func (w *Window) enableHistory() {
+	if !config.ScrollbackPersist || w.Terminal == nil {
+		return
+	}
+
+	started := time.Now()
+	name := fmt.Sprintf("%s-%s%s", started.Format("20060102-150405"), w.ID[:min(8, len(w.ID))], historyFileExt)
+	path := filepath.Join(config.GetHistoryDirectory(), name)
+
+	h, err := vt.CreateHistoryFile(path)
+	if err != nil {
+		w.historyErr = err
+		return
+	}
+	w.Terminal.SetHistoryFile(h)
+	w.historyPath = path
+	w.historyStarted = started
+}
+
+// HistoryPath returns the path of the window's on-disk history file,
+// or "" if scrollback persistence is disabled for this window.
+func (w *Window) HistoryPath() string {
+	return w.historyPath
+}
+
+// HistoryError returns why the window's history file could not be created,
+// or nil.
+func (w *Window) HistoryError() error {
+	return w.historyErr
+}
+
+// SaveHistory writes the window's remaining scrollback and screen to its
+// history file, closes it and writes the sidecar metadata used to reopen it.
+// Safe to call more than once; only the first call has an effect.
+func (w *Window) SaveHistory() error {
+	return w.saveHistory(w.Terminal)
+}
+
+func (w *Window) saveHistory(term *vt.Emulator) error {
+	if term == nil || term.HistoryFile() == nil {
+		return nil
+	}
+
+	h := term.HistoryFile()
+	width := term.Width()
+	markers, err := term.SaveHistory()
+	if err != nil {
+		return fmt.Errorf("failed to save history: %w", err)
+	}
+
+	title := w.CustomName
+	if title == "" {
+		title = w.Title
+	}
+	info := HistoryInfo{
+		WindowID: w.ID,
+		Title:    title,
+		Started:  w.historyStarted,
+		Closed:   time.Now(),
+		Width:    width,
+		Lines:    h.Len(),
+		Markers:  markers,
+	}
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode history metadata: %w", err)
+	}
+	if err := os.WriteFile(historyMetaPath(h.Path()), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write history metadata: %w", err)
+	}
+	return nil
+}
+
+// ListHistory returns the saved history files in dir, newest first.
+// Files without metadata (for example from a crash) are listed with their
+// file name as title.
+func ListHistory(dir string) ([]HistoryInfo, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+historyFileExt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history files: %w", err)
+	}
+
+	infos := make([]HistoryInfo, 0, len(paths))
+	for _, path := range paths {
+		info := HistoryInfo{Path: path}
+		if data, err := os.ReadFile(historyMetaPath(path)); err == nil && json.Unmarshal(data, &info) == nil {
+			info.Path = path
+		} else {
+			info.Title = strings.TrimSuffix(filepath.Base(path), historyFileExt)
+			if stat, err := os.Stat(path); err == nil {
+				info.Started = stat.ModTime()
+			}
+		}
+		infos = append(infos, info)
+	}
+
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].lastActive().After(infos[j].lastActive())
+	})
+	return infos, nil
+}
+
+// lastActive returns when the history was last written to.
+func (info HistoryInfo) lastActive() time.Time {
+	if !info.Closed.IsZero() {
+		return info.Closed
+	}
+	return info.Started
+}
+
+// OpenHistory opens a saved history read-only as a closed emulator whose
+// scrollback is the history file. The caller must close the returned file.
+func OpenHistory(info HistoryInfo) (*vt.Emulator, *vt.HistoryFile, error) {
+	h, err := vt.OpenHistoryFile(info.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	width := info.Width
+	if width <= 0 {
+		width = 80
+	}
+	return vt.NewHistoryEmulator(h, width, info.Markers), h, nil
+}
+
+// DeleteHistory removes a history file and its metadata.
+func DeleteHistory(info HistoryInfo) error {
+	if err := os.Remove(info.Path); err != nil {
+		return fmt.Errorf("failed to delete history: %w", err)
+	}
+	_ = os.Remove(historyMetaPath(info.Path))
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"image/color"
 	"io"
+	"net/url"
 	"os"
 	"os/exec"
 	"runtime"
@@ -22,6 +23,7 @@ import (
 
 	"github.com/Gaurav-Gosain/tuios/internal/config"
 	"github.com/Gaurav-Gosain/tuios/internal/pool"
+	"github.com/Gaurav-Gosain/tuios/internal/system"
 	"github.com/Gaurav-Gosain/tuios/internal/theme"
 	"github.com/Gaurav-Gosain/tuios/internal/vt"
 )
@@ -111,6 +113,14 @@ type Window struct {
 	PreMinimizeWidth       int                // Store size before minimizing
 	PreMinimizeHeight      int                // Store size before minimizing
 	Workspace              int                // Workspace this window belongs to
+	GroupHidden            bool               // True when hidden behind the active tab of a tabbed or stacked container
+	Floating               bool               // True when the window floats above the tiling layout
+	Sticky                 bool               // True when the window is shown on every workspace
+	Scratchpad             string             // Name of the scratchpad this window belongs to, if any
+	BorderColor            string             // Border color set by a window rule, overriding the theme
+	titleChanged           atomic.Bool        // Set by the title callback until TakeTitleChange is called
+	workingDirMu           sync.Mutex         // Protects workingDir; not ioMu, which is held while the OSC 7 callback runs
+	workingDir             string             // Working directory reported by the shell with OSC 7
 	SelectionStart         struct{ X, Y int } // Selection start position
 	SelectionEnd           struct{ X, Y int } // Selection end position
 	IsSelecting            bool               // True when selecting text
@@ -139,6 +149,8 @@ type Window struct {
 	// Daemon session support
 	PTYID             string               // ID of daemon-managed PTY (empty for local PTYs)
 	DaemonMode        bool                 // True when PTY is managed by daemon
+	ForegroundPID     int                  // Foreground process of a daemon PTY, as last reported by the daemon
+	ForegroundCmd     string               // Command name of ForegroundPID
 	DaemonWriteFunc   func([]byte) error   // Callback for sending input to daemon PTY
 	DaemonResizeFunc  func(w, h int) error // Callback for resizing daemon PTY
 	DaemonCloseFunc   func()               // Callback when window is closed (to notify daemon)
@@ -158,6 +170,11 @@ type Window struct {
 	cmdWaitOnce sync.Once
 	// ioWg tracks I/O goroutines for clean shutdown
 	ioWg sync.WaitGroup
+
+	// On-disk scrollback history (empty when scrollback persistence is off)
+	historyPath    string
+	historyStarted time.Time
+	historyErr     error
 }
 
 // CopyModeState represents the current state within copy mode
@@ -172,6 +189,8 @@ const (
 	CopyModeVisualChar
 	// CopyModeVisualLine is line-wise visual selection
 	CopyModeVisualLine
+	// CopyModeVisualBlock is block-wise (rectangular) visual selection
+	CopyModeVisualBlock
 )
 
 // Position represents a 2D coordinate
@@ -211,7 +230,7 @@ type CopyMode struct {
 	SearchQuery     string        // Current search query
 	SearchMatches   []SearchMatch // All search results
 	CurrentMatch    int           // Index of current match
-	CaseSensitive   bool          // Case-sensitive search
+	CaseSensitive   bool          // Case-sensitive search (smartcase: query has uppercase)
 	SearchBackward  bool          // True for ? (backward), false for / (forward)
 	SearchCache     SearchCache   // Cached search results (exported for copymode package)
 	PendingGCount   bool          // Waiting for second 'g' in 'gg'
@@ -226,12 +245,34 @@ type CopyMode struct {
 	// Count prefix (e.g., 10j means move down 10 times)
 	PendingCount   int       // Accumulated count (0 means no count)
 	CountStartTime time.Time // When count entry started (for timeout)
+
+	// Register selection ("a, "A, etc.)
+	PendingRegister bool // Waiting for register name after "
+	Register        rune // Register for the next yank (0 means unnamed)
+
+	// Marks (m{a-z}, '{a-z}) and multi-key motions ([[, ]], io, ao)
+	Marks      map[rune]Position // Mark positions; Y is a stable line (absolute + trimmed count)
+	PendingKey string            // First key of a two-key command (m, ', `, [, ], i, a)
+}
+
+// InVisualMode reports whether copy mode is in any visual selection state.
+func (cm *CopyMode) InVisualMode() bool {
+	return cm.State == CopyModeVisualChar ||
+		cm.State == CopyModeVisualLine ||
+		cm.State == CopyModeVisualBlock
 }
 
 // NewWindow creates a new terminal window with the specified properties.
 // It spawns a shell process, sets up PTY communication, and initializes the virtual terminal.
 // Returns nil if window creation fails.
 func NewWindow(id, title string, x, y, width, height, z int, exitChan chan string) *Window {
+	return NewCommandWindow(id, title, x, y, width, height, z, exitChan, "")
+}
+
+// NewCommandWindow creates a terminal window whose process is command, run
+// with the platform shell, instead of the user's shell. The window closes
+// when the command exits. An empty command starts the user's shell.
+func NewCommandWindow(id, title string, x, y, width, height, z int, exitChan chan string, command string) *Window {
 	if title == "" {
 		title = "Terminal " + id[:8]
 	}
@@ -266,6 +307,7 @@ func NewWindow(id, title string, x, y, width, height, z int, exitChan chan strin
 		IsBeingManipulated: false,
 		IsAltScreen:        false,
 	}
+	window.enableHistory()
 
 	// Apply theme colors to the terminal (only if theming is enabled)
 	if theme.IsEnabled() {
@@ -297,10 +339,14 @@ func NewWindow(id, title string, x, y, width, height, z int, exitChan chan strin
 		},
 		Title: func(title string) {
 			// Update window title from terminal escape sequence
-			if title != "" {
+			if title != "" && title != window.Title {
 				window.Title = title
+				window.titleChanged.Store(true)
 			}
 		},
+		WorkingDirectory: func(uri string) {
+			window.SetWorkingDir(workingDirFromURI(uri))
+		},
 	})
 
 	// Detect shell
@@ -309,6 +355,11 @@ func NewWindow(id, title string, x, y, width, height, z int, exitChan chan strin
 	// Set up environment
 	// #nosec G204 - shell is intentionally user-controlled for terminal functionality
 	cmd := exec.Command(shell)
+	if command != "" {
+		name, args := system.ShellCommand(command)
+		// #nosec G204 - running the user's configured command is intentional
+		cmd = exec.Command(name, args...)
+	}
 
 	// Get cached terminal environment (detected once on first window creation)
 	termType, colorTerm := getTerminalEnv()
@@ -450,6 +501,7 @@ func NewDaemonWindow(id, title string, x, y, width, height, z int, ptyID string)
 		outputDone:         make(chan struct{}),
 		// suppressCallbacks defaults to false (zero value)
 	}
+	window.enableHistory()
 
 	// Start output writer goroutine to serialize writes
 	go window.outputWriter()
@@ -483,10 +535,14 @@ func NewDaemonWindow(id, title string, x, y, width, height, z int, ptyID string)
 		},
 		Title: func(title string) {
 			// Update window title from terminal escape sequence
-			if title != "" {
+			if title != "" && title != window.Title {
 				window.Title = title
+				window.titleChanged.Store(true)
 			}
 		},
+		WorkingDirectory: func(uri string) {
+			window.SetWorkingDir(workingDirFromURI(uri))
+		},
 	})
 
 	return window
@@ -1058,6 +1114,7 @@ func (w *Window) Close() {
 	// - PTY close unblocks the PTY->Terminal goroutine
 	// - Terminal close unblocks the Terminal->PTY goroutine (reads from emulator response pipe)
 	w.ioMu.Lock()
+	term := w.Terminal
 	if w.Pty != nil {
 		_ = w.Pty.Close()
 		w.Pty = nil
@@ -1079,6 +1136,9 @@ func (w *Window) Close() {
 	case <-time.After(10 * time.Millisecond):
 	}
 
+	// Flush on-disk scrollback history now that no more output arrives
+	_ = w.saveHistory(term)
+
 	// Kill the process
 	if w.Cmd != nil && w.Cmd.Process != nil {
 		_ = w.Cmd.Process.Kill()
@@ -1099,6 +1159,40 @@ func (w *Window) Close() {
 	}
 }
 
+// ForegroundCommand returns the name of the terminal's foreground process,
+// the shell itself when nothing else is running. Daemon windows return what
+// the daemon last reported. It returns "" when the process cannot be
+// determined.
+func (w *Window) ForegroundCommand() string {
+	if w.Pty == nil {
+		return w.ForegroundCmd
+	}
+	_, name := system.ForegroundProcess(w.Pty.Fd())
+	return name
+}
+
+// WorkingDir returns the working directory the shell last reported with
+// OSC 7, or "".
+func (w *Window) WorkingDir() string {
+	w.workingDirMu.Lock()
+	defer w.workingDirMu.Unlock()
+	return w.workingDir
+}
+
+// SetWorkingDir records the shell's working directory. It is called from the
+// PTY reader goroutine.
+func (w *Window) SetWorkingDir(dir string) {
+	w.workingDirMu.Lock()
+	w.workingDir = dir
+	w.workingDirMu.Unlock()
+}
+
+// TakeTitleChange reports whether the terminal changed the window title since
+// the last call.
+func (w *Window) TakeTitleChange() bool {
+	return w.titleChanged.Swap(false)
+}
+
 // SendInput sends input to the window's terminal with enhanced error handling.
 func (w *Window) SendInput(input []byte) error {
 	if w == nil {
@@ -1207,6 +1301,15 @@ func (w *Window) ScrollbackLine(index int) uv.Line {
 	return w.Terminal.ScrollbackLine(index)
 }
 
+// ScrollbackTrimmed returns the number of lines trimmed from the front of the
+// scrollback since the window was created (see vt.Emulator.ScrollbackTrimmed).
+func (w *Window) ScrollbackTrimmed() int {
+	if w.Terminal == nil {
+		return 0
+	}
+	return w.Terminal.ScrollbackTrimmed()
+}
+
 // ClearScrollback clears the scrollback buffer.
 func (w *Window) ClearScrollback() {
 	if w.Terminal != nil {
@@ -1278,6 +1381,9 @@ func (w *Window) EnterCopyMode() {
 	w.CopyMode.CurrentMatch = 0
 	w.CopyMode.CaseSensitive = false
 	w.CopyMode.PendingGCount = false
+	w.CopyMode.PendingRegister = false
+	w.CopyMode.Register = 0
+	w.CopyMode.PendingKey = ""
 
 	// Sync with window scrollback
 	w.ScrollbackOffset = 0
@@ -1314,3 +1420,13 @@ func (w *Window) EnableCallbacks() {
 func (w *Window) DisableCallbacks() {
 	w.suppressCallbacks.Store(true)
 }
+
+// workingDirFromURI returns the path of an OSC 7 working directory, which
+// shells report as a file:// URL. A bare path is returned as is.
+func workingDirFromURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return u.Path
+}
@@ -7,6 +7,7 @@ import (
 	"syscall"
 	"unsafe"
 
+	"github.com/Gaurav-Gosain/tuios/internal/system"
 	"golang.org/x/sys/unix"
 )
 
@@ -45,27 +46,10 @@ func (w *Window) HasForegroundProcess() bool {
 		return false
 	}
 
-	// Get the PTY file descriptor
-	ptyFd := w.Pty.Fd()
-
-	// Get the foreground process group of the PTY
-	// Using tcgetpgrp syscall via ioctl
-	var fgpgrp int
-	_, _, errno := syscall.Syscall(
-		syscall.SYS_IOCTL,
-		ptyFd,
-		uintptr(unix.TIOCGPGRP),
-		uintptr(unsafe.Pointer(&fgpgrp)),
-	)
-
-	if errno != 0 {
-		// If we can't determine, assume no foreground process
-		return false
-	}
-
-	// If foreground process group is different from shell's process group,
-	// there's an active foreground process running
-	return fgpgrp != w.ShellPgid
+	// If the foreground process group is different from the shell's process
+	// group, there's an active foreground process running
+	pgid, _ := system.ForegroundProcess(w.Pty.Fd())
+	return pgid != 0 && pgid != w.ShellPgid
 }
 
 // SetPtyPixelSize sets the pixel dimensions on the PTY using TIOCSWINSZ.
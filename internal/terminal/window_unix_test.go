@@ -102,3 +102,17 @@ func TestSetCellPixelDimensions(t *testing.T) {
 		t.Errorf("Expected Ypixel=%d, got %d", expectedYpixel, ws.Ypixel)
 	}
 }
+
+func TestNewCommandWindow(t *testing.T) {
+	exitChan := make(chan string, 1)
+	window := NewCommandWindow("test-id-command1", "Test", 0, 0, 80, 24, 0, exitChan, "echo started; exit 0")
+	if window == nil {
+		t.Skip("Failed to create window with PTY")
+	}
+	defer window.Close()
+
+	// The window's process is the command itself, not a shell it is typed into
+	if args := window.Cmd.Args; len(args) != 3 || args[0] != "sh" || args[2] != "echo started; exit 0" {
+		t.Errorf("window process = %q, want the command run by sh -c", args)
+	}
+}
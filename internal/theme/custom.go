@@ -40,13 +40,14 @@ func LoadCustomThemes(themesDir string) ([]string, error) {
 		}
 
 		path := filepath.Join(themesDir, entry.Name())
-		t, err := LoadCustomThemeFile(path)
+		t, roles, err := loadCustomTheme(path)
 		if err != nil {
 			log.Printf("Warning: skipping custom theme %s: %v", entry.Name(), err)
 			continue
 		}
 
 		tint.Register(t)
+		roleOverrides[t.ID] = roles
 		loaded = append(loaded, t.ID)
 	}
 
@@ -57,15 +58,33 @@ func LoadCustomThemes(themesDir string) ([]string, error) {
 // Derives ID from filename if the id field is empty.
 // Sets DisplayName from ID if empty. Fills missing color fields with defaults.
 func LoadCustomThemeFile(path string) (*tint.Tint, error) {
+	t, _, err := loadCustomTheme(path)
+	return t, err
+}
+
+// loadCustomTheme reads a custom theme file along with the role colors in
+// its "roles" object.
+func loadCustomTheme(path string) (*tint.Tint, map[string]*tint.Color, error) {
 	// #nosec G304 - path is from user's config directory, reading custom themes is intentional
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read theme file: %w", err)
+		return nil, nil, fmt.Errorf("failed to read theme file: %w", err)
 	}
 
 	var t tint.Tint
 	if err := json.Unmarshal(data, &t); err != nil {
-		return nil, fmt.Errorf("failed to parse theme JSON: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse theme JSON: %w", err)
+	}
+
+	var extra struct {
+		Roles map[string]string `json:"roles"`
+	}
+	if err := json.Unmarshal(data, &extra); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse theme JSON: %w", err)
+	}
+	roles, err := parseRoles(extra.Roles)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Derive ID from filename if not set in JSON
@@ -75,7 +94,7 @@ func LoadCustomThemeFile(path string) (*tint.Tint, error) {
 	}
 
 	if t.ID == "" {
-		return nil, fmt.Errorf("theme has no ID")
+		return nil, nil, fmt.Errorf("theme has no ID")
 	}
 
 	// Set DisplayName from ID if empty
@@ -85,7 +104,7 @@ func LoadCustomThemeFile(path string) (*tint.Tint, error) {
 
 	fillDefaults(&t)
 
-	return &t, nil
+	return &t, roles, nil
 }
 
 // fillDefaults fills nil color pointers with xterm defaults.
@@ -278,3 +278,57 @@ func TestCopyColor(t *testing.T) {
 		t.Error("copyColor(nil) should return nil")
 	}
 }
+
+// TestSaveCustomThemeFile_RoleOverrides tests that an edited theme and its
+// role colors survive saving and loading.
+func TestSaveCustomThemeFile_RoleOverrides(t *testing.T) {
+	dir := t.TempDir()
+	tint.NewDefaultRegistry()
+	roleOverrides = map[string]map[string]*tint.Color{}
+	enabled = true
+	defer func() { enabled = false }()
+
+	house := &tint.Tint{ID: "test-house", DisplayName: "House"}
+	fillDefaults(house)
+	SetPaletteColor(house, "bright_cyan", tint.FromHex("#123456"))
+	SetRoleOverride(house.ID, "border_focused_window", tint.FromHex("#ff8800"))
+
+	path := filepath.Join(dir, "test-house.json")
+	if err := SaveCustomThemeFile(path, house); err != nil {
+		t.Fatalf("SaveCustomThemeFile failed: %v", err)
+	}
+
+	roleOverrides = map[string]map[string]*tint.Color{}
+	if _, err := LoadCustomThemes(dir); err != nil {
+		t.Fatalf("LoadCustomThemes failed: %v", err)
+	}
+	if !tint.SetTintID("test-house") {
+		t.Fatal("saved theme was not registered")
+	}
+
+	if got := ColorToString(BorderFocusedWindow()); got != "#ff8800" {
+		t.Errorf("expected the role override #ff8800, got %s", got)
+	}
+	if bg, _ := CopyModeCursor(); ColorToString(bg) != "#123456" {
+		t.Errorf("roles without an override should use the palette, got %s", ColorToString(bg))
+	}
+
+	SetRoleOverride("test-house", "border_focused_window", nil)
+	if got := ColorToString(BorderFocusedWindow()); got != "#123456" {
+		t.Errorf("removing the override should fall back to bright cyan, got %s", got)
+	}
+}
+
+// TestLoadCustomThemeFile_UnknownRole tests that a typo in a role name is
+// reported instead of ignored.
+func TestLoadCustomThemeFile_UnknownRole(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "typo.json")
+	themeJSON := `{"id": "typo", "roles": {"border_focussed": "#ffffff"}}`
+	if err := os.WriteFile(path, []byte(themeJSON), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCustomThemeFile(path); err == nil {
+		t.Error("expected an error for an unknown role")
+	}
+}
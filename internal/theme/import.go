@@ -0,0 +1,346 @@
+package theme
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tint "github.com/lrstanley/bubbletint/v2"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ImportColorSchemes reads the color schemes in a terminal emulator's theme
+// file and converts them to themes. The format is picked by extension:
+// Alacritty (.toml), iTerm2 (.itermcolors), Windows Terminal (.json, either
+// a single scheme or a settings file with a "schemes" list) and kitty
+// (.conf). Themes are named after the scheme, or the file name.
+func ImportColorSchemes(path string) ([]*tint.Tint, error) {
+	// #nosec G304 - importing a file the user asked for is intentional
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read color scheme: %w", err)
+	}
+
+	base := filepath.Base(path)
+	name := strings.TrimSuffix(base, filepath.Ext(base))
+	var schemes []importedScheme
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		schemes, err = parseAlacritty(data, name)
+	case ".itermcolors":
+		schemes, err = parseITerm(data, name)
+	case ".json":
+		schemes, err = parseWindowsTerminal(data, name)
+	case ".yml", ".yaml":
+		return nil, fmt.Errorf("alacritty YAML configs are not supported, migrate them with 'alacritty migrate' first")
+	case ".conf":
+		schemes, err = parseKitty(data, name)
+	default:
+		return nil, fmt.Errorf("%s: unsupported format, expected .toml, .itermcolors, .json or .conf", base)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", base, err)
+	}
+	if len(schemes) == 0 {
+		return nil, fmt.Errorf("%s: no color scheme found", base)
+	}
+
+	themes := make([]*tint.Tint, 0, len(schemes))
+	for _, s := range schemes {
+		if len(s.colors) == 0 {
+			return nil, fmt.Errorf("%s: scheme %q has no colors", base, s.name)
+		}
+		themes = append(themes, s.toTheme())
+	}
+	return themes, nil
+}
+
+// importedScheme is a color scheme read from another terminal's file, with
+// colors by palette key.
+type importedScheme struct {
+	name   string
+	colors map[string]*tint.Color
+}
+
+// toTheme builds a theme from the scheme. Missing colors get the same
+// defaults as hand-written custom themes.
+func (s importedScheme) toTheme() *tint.Tint {
+	t := &tint.Tint{ID: ThemeID(s.name), DisplayName: s.name}
+	for key, c := range s.colors {
+		SetPaletteColor(t, key, c)
+	}
+	fillDefaults(t)
+	t.Dark = luminance(t.Bg) < 0.5
+	return t
+}
+
+// set parses a color for a palette key. Colors may be written as #rrggbb,
+// 0xrrggbb or rrggbb.
+func (s importedScheme) set(key, value string) error {
+	value = strings.Trim(strings.TrimSpace(value), `"'`)
+	if value == "" {
+		return nil
+	}
+	if rest, ok := strings.CutPrefix(strings.ToLower(value), "0x"); ok {
+		value = "#" + rest
+	} else if !strings.HasPrefix(value, "#") {
+		value = "#" + value
+	}
+	c, err := ParseHex(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	s.colors[key] = c
+	return nil
+}
+
+// ThemeID turns a scheme name into a theme ID in the style of the built-in
+// themes, such as "gruvbox_dark".
+func ThemeID(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		case sb.Len() > 0 && !strings.HasSuffix(sb.String(), "_"):
+			sb.WriteByte('_')
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "_")
+}
+
+// luminance returns the relative brightness of a color from 0 to 1.
+func luminance(c *tint.Color) float64 {
+	if c == nil {
+		return 0
+	}
+	return (0.2126*float64(c.R) + 0.7152*float64(c.G) + 0.0722*float64(c.B)) / 255
+}
+
+// ansiNames are the palette keys of the 8 normal ANSI colors, in order.
+var ansiNames = []string{"black", "red", "green", "yellow", "blue", "purple", "cyan", "white"}
+
+// ansiKey returns the palette key of ANSI color 0-15.
+func ansiKey(n int) string {
+	if n >= 8 {
+		return "bright_" + ansiNames[n-8]
+	}
+	return ansiNames[n]
+}
+
+// parseAlacritty reads the [colors] tables of an Alacritty TOML config.
+func parseAlacritty(data []byte, name string) ([]importedScheme, error) {
+	var file struct {
+		Colors struct {
+			Primary struct {
+				Foreground string `toml:"foreground"`
+				Background string `toml:"background"`
+			} `toml:"primary"`
+			Cursor struct {
+				Cursor string `toml:"cursor"`
+			} `toml:"cursor"`
+			Normal map[string]string `toml:"normal"`
+			Bright map[string]string `toml:"bright"`
+		} `toml:"colors"`
+	}
+	if err := toml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse alacritty config: %w", err)
+	}
+
+	s := importedScheme{name: name, colors: map[string]*tint.Color{}}
+	colors := file.Colors
+	values := map[string]string{
+		"fg":     colors.Primary.Foreground,
+		"bg":     colors.Primary.Background,
+		"cursor": colors.Cursor.Cursor,
+	}
+	for _, ansi := range ansiNames {
+		key := ansi
+		if ansi == "purple" {
+			key = "magenta"
+		}
+		values[ansi] = colors.Normal[key]
+		values["bright_"+ansi] = colors.Bright[key]
+	}
+	for key, value := range values {
+		if err := s.set(key, value); err != nil {
+			return nil, err
+		}
+	}
+	return []importedScheme{s}, nil
+}
+
+// parseKitty reads the color settings of a kitty theme or kitty.conf.
+func parseKitty(data []byte, name string) ([]importedScheme, error) {
+	s := importedScheme{name: name, colors: map[string]*tint.Color{}}
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		setting, value := fields[0], fields[1]
+		key := ""
+		switch setting {
+		case "foreground":
+			key = "fg"
+		case "background":
+			key = "bg"
+		case "cursor":
+			key = "cursor"
+		default:
+			if n, err := strconv.Atoi(strings.TrimPrefix(setting, "color")); err == nil && strings.HasPrefix(setting, "color") && 0 <= n && n < 16 {
+				key = ansiKey(n)
+			}
+		}
+		if key == "" || value == "none" {
+			continue
+		}
+		if err := s.set(key, value); err != nil {
+			return nil, err
+		}
+	}
+	return []importedScheme{s}, nil
+}
+
+// windowsTerminalKeys maps Windows Terminal scheme fields to palette keys.
+var windowsTerminalKeys = map[string]string{
+	"foreground": "fg", "background": "bg", "cursorColor": "cursor",
+	"black": "black", "red": "red", "green": "green", "yellow": "yellow",
+	"blue": "blue", "purple": "purple", "cyan": "cyan", "white": "white",
+	"brightBlack": "bright_black", "brightRed": "bright_red",
+	"brightGreen": "bright_green", "brightYellow": "bright_yellow",
+	"brightBlue": "bright_blue", "brightPurple": "bright_purple",
+	"brightCyan": "bright_cyan", "brightWhite": "bright_white",
+}
+
+// parseWindowsTerminal reads a Windows Terminal color scheme, or every
+// scheme of a settings.json.
+func parseWindowsTerminal(data []byte, name string) ([]importedScheme, error) {
+	var settings struct {
+		Schemes []map[string]any `json:"schemes"`
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse windows terminal scheme: %w", err)
+	}
+	if settings.Schemes == nil {
+		var scheme map[string]any
+		if err := json.Unmarshal(data, &scheme); err != nil {
+			return nil, fmt.Errorf("failed to parse windows terminal scheme: %w", err)
+		}
+		settings.Schemes = []map[string]any{scheme}
+	}
+
+	var schemes []importedScheme
+	for _, fields := range settings.Schemes {
+		s := importedScheme{name: name, colors: map[string]*tint.Color{}}
+		if n, ok := fields["name"].(string); ok && n != "" {
+			s.name = n
+		}
+		for field, key := range windowsTerminalKeys {
+			if value, ok := fields[field].(string); ok {
+				if err := s.set(key, value); err != nil {
+					return nil, err
+				}
+			}
+		}
+		schemes = append(schemes, s)
+	}
+	return schemes, nil
+}
+
+// parseITerm reads an iTerm2 .itermcolors property list.
+func parseITerm(data []byte, name string) ([]importedScheme, error) {
+	dec := xml.NewDecoder(strings.NewReader(string(data)))
+	var root map[string]any
+	for root == nil {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil, fmt.Errorf("no property list dictionary found")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse itermcolors: %w", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "dict" {
+			if root, err = parsePlistDict(dec); err != nil {
+				return nil, fmt.Errorf("failed to parse itermcolors: %w", err)
+			}
+		}
+	}
+
+	s := importedScheme{name: name, colors: map[string]*tint.Color{}}
+	keys := map[string]string{
+		"Foreground Color": "fg",
+		"Background Color": "bg",
+		"Cursor Color":     "cursor",
+	}
+	for n := range 16 {
+		keys[fmt.Sprintf("Ansi %d Color", n)] = ansiKey(n)
+	}
+	for entry, key := range keys {
+		components, ok := root[entry].(map[string]any)
+		if !ok {
+			continue
+		}
+		component := func(name string) uint8 {
+			v, _ := components[name+" Component"].(float64)
+			return uint8(max(min(v, 1), 0)*255 + 0.5)
+		}
+		s.colors[key] = &tint.Color{R: component("Red"), G: component("Green"), B: component("Blue"), A: 0xff}
+	}
+	return []importedScheme{s}, nil
+}
+
+// parsePlistDict reads the contents of a plist <dict> whose start element
+// was consumed. Only the value types color schemes use are kept.
+func parsePlistDict(dec *xml.Decoder) (map[string]any, error) {
+	dict := map[string]any{}
+	key := ""
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.EndElement:
+			if tok.Name.Local == "dict" {
+				return dict, nil
+			}
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "dict":
+				value, err := parsePlistDict(dec)
+				if err != nil {
+					return nil, err
+				}
+				dict[key] = value
+			case "key", "real", "integer", "string":
+				var text string
+				if err := dec.DecodeElement(&text, &tok); err != nil {
+					return nil, err
+				}
+				switch tok.Name.Local {
+				case "key":
+					key = text
+				case "string":
+					dict[key] = text
+				default:
+					v, _ := strconv.ParseFloat(strings.TrimSpace(text), 64)
+					dict[key] = v
+				}
+			default:
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+}
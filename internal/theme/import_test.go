@@ -0,0 +1,184 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestImportColorSchemes tests converting the theme files of other
+// terminals, one per supported format.
+func TestImportColorSchemes(t *testing.T) {
+	tests := []struct {
+		file    string
+		content string
+		id      string
+		fg      string
+		bg      string
+		red     string
+		bright  string // bright_blue
+	}{
+		{
+			file: "house.toml",
+			content: `[colors.primary]
+foreground = "#d8dee9"
+background = "0x2e3440"
+
+[colors.normal]
+red = "#bf616a"
+
+[colors.bright]
+blue = "#81a1c1"
+`,
+			id: "house", fg: "#d8dee9", bg: "#2e3440", red: "#bf616a", bright: "#81a1c1",
+		},
+		{
+			file: "House.conf",
+			content: `# kitty theme
+foreground   #d8dee9
+background   #2e3440
+color1       #bf616a
+color12      #81a1c1
+selection_background none
+`,
+			id: "house", fg: "#d8dee9", bg: "#2e3440", red: "#bf616a", bright: "#81a1c1",
+		},
+		{
+			file: "scheme.json",
+			content: `{
+  "name": "House Theme",
+  "foreground": "#D8DEE9",
+  "background": "#2E3440",
+  "red": "#BF616A",
+  "brightBlue": "#81A1C1"
+}`,
+			id: "house_theme", fg: "#d8dee9", bg: "#2e3440", red: "#bf616a", bright: "#81a1c1",
+		},
+		{
+			file: "House.itermcolors",
+			content: `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Ansi 1 Color</key>
+	<dict>
+		<key>Blue Component</key><real>0.415686</real>
+		<key>Color Space</key><string>sRGB</string>
+		<key>Green Component</key><real>0.380392</real>
+		<key>Red Component</key><real>0.749020</real>
+	</dict>
+	<key>Ansi 12 Color</key>
+	<dict>
+		<key>Blue Component</key><real>0.756863</real>
+		<key>Green Component</key><real>0.631373</real>
+		<key>Red Component</key><real>0.505882</real>
+	</dict>
+	<key>Background Color</key>
+	<dict>
+		<key>Blue Component</key><real>0.250980</real>
+		<key>Green Component</key><real>0.203922</real>
+		<key>Red Component</key><real>0.180392</real>
+	</dict>
+	<key>Foreground Color</key>
+	<dict>
+		<key>Blue Component</key><real>0.913725</real>
+		<key>Green Component</key><real>0.870588</real>
+		<key>Red Component</key><real>0.847059</real>
+	</dict>
+</dict>
+</plist>
+`,
+			id: "house", fg: "#d8dee9", bg: "#2e3440", red: "#bf616a", bright: "#81a1c1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			themes, err := ImportColorSchemes(path)
+			if err != nil {
+				t.Fatalf("ImportColorSchemes failed: %v", err)
+			}
+			if len(themes) != 1 {
+				t.Fatalf("expected 1 theme, got %d", len(themes))
+			}
+			th := themes[0]
+			if th.ID != tt.id {
+				t.Errorf("expected ID %q, got %q", tt.id, th.ID)
+			}
+			for name, want := range map[string]string{"fg": tt.fg, "bg": tt.bg, "red": tt.red, "bright_blue": tt.bright} {
+				if got := PaletteColor(th, name).Hex(); got != want {
+					t.Errorf("%s: expected %s, got %s", name, want, got)
+				}
+			}
+			if th.Green == nil || th.BrightWhite == nil {
+				t.Error("missing colors should be filled with defaults")
+			}
+			if !th.Dark {
+				t.Error("expected a dark theme for a dark background")
+			}
+		})
+	}
+}
+
+// TestImportColorSchemes_WindowsTerminalSettings tests importing every
+// scheme of a Windows Terminal settings file.
+func TestImportColorSchemes_WindowsTerminalSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	settings := `{"schemes": [
+		{"name": "One", "background": "#ffffff", "foreground": "#000000"},
+		{"name": "Two", "background": "#000000", "foreground": "#ffffff"}
+	]}`
+	if err := os.WriteFile(path, []byte(settings), 0600); err != nil {
+		t.Fatal(err)
+	}
+	themes, err := ImportColorSchemes(path)
+	if err != nil {
+		t.Fatalf("ImportColorSchemes failed: %v", err)
+	}
+	if len(themes) != 2 || themes[0].ID != "one" || themes[1].ID != "two" {
+		t.Fatalf("expected themes one and two, got %+v", themes)
+	}
+	if themes[0].Dark || !themes[1].Dark {
+		t.Error("expected one to be light and two to be dark")
+	}
+}
+
+// TestImportColorSchemes_InvalidColor tests that bad colors are reported.
+func TestImportColorSchemes_InvalidColor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.conf")
+	if err := os.WriteFile(path, []byte("foreground #zzzzzz\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ImportColorSchemes(path); err == nil {
+		t.Error("expected an error for an invalid color")
+	}
+}
+
+// TestImportColorSchemes_Kitty tests that kitty color numbers outside the 16
+// ANSI colors are skipped and unknown file types are rejected.
+func TestImportColorSchemes_Kitty(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "odd.conf")
+	if err := os.WriteFile(path, []byte("color-1 #000000\ncolor16 #000000\ncolor1 #bf616a\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	themes, err := ImportColorSchemes(path)
+	if err != nil {
+		t.Fatalf("ImportColorSchemes failed: %v", err)
+	}
+	if got := PaletteColor(themes[0], "red").Hex(); got != "#bf616a" {
+		t.Errorf("red: expected #bf616a, got %s", got)
+	}
+
+	path = filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(path, []byte("color1 #bf616a\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ImportColorSchemes(path); err == nil {
+		t.Error("expected an error for an unsupported file type")
+	}
+}
@@ -0,0 +1,231 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"os"
+	"strings"
+
+	tint "github.com/lrstanley/bubbletint/v2"
+)
+
+// ColorKey names a color of a theme that can be edited: a slot of its
+// palette or a role TUIOS draws with.
+type ColorKey struct {
+	Key  string // JSON field in a custom theme file
+	Name string
+
+	get func() color.Color // Role color of the current theme
+}
+
+// PaletteColors lists the palette slots of a theme.
+var PaletteColors = []ColorKey{
+	{Key: "fg", Name: "Foreground"},
+	{Key: "bg", Name: "Background"},
+	{Key: "cursor", Name: "Cursor"},
+	{Key: "black", Name: "Black"},
+	{Key: "red", Name: "Red"},
+	{Key: "green", Name: "Green"},
+	{Key: "yellow", Name: "Yellow"},
+	{Key: "blue", Name: "Blue"},
+	{Key: "purple", Name: "Purple"},
+	{Key: "cyan", Name: "Cyan"},
+	{Key: "white", Name: "White"},
+	{Key: "bright_black", Name: "Bright black"},
+	{Key: "bright_red", Name: "Bright red"},
+	{Key: "bright_green", Name: "Bright green"},
+	{Key: "bright_yellow", Name: "Bright yellow"},
+	{Key: "bright_blue", Name: "Bright blue"},
+	{Key: "bright_purple", Name: "Bright purple"},
+	{Key: "bright_cyan", Name: "Bright cyan"},
+	{Key: "bright_white", Name: "Bright white"},
+}
+
+// RoleColors lists the roles TUIOS draws with that a theme can set apart
+// from its palette. Without an override a role uses a palette slot, for
+// example the focused window border uses bright cyan.
+var RoleColors = []ColorKey{
+	{Key: "border_unfocused", Name: "Unfocused border", get: BorderUnfocused},
+	{Key: "border_focused_window", Name: "Focused border (window mode)", get: BorderFocusedWindow},
+	{Key: "border_focused_terminal", Name: "Focused border (terminal mode)", get: BorderFocusedTerminal},
+	{Key: "dock_window", Name: "Dock window mode", get: DockColorWindow},
+	{Key: "dock_terminal", Name: "Dock terminal mode", get: DockColorTerminal},
+	{Key: "dock_copy", Name: "Dock copy mode", get: DockColorCopy},
+	{Key: "copy_cursor_bg", Name: "Copy cursor", get: bgOf(CopyModeCursor)},
+	{Key: "copy_cursor_fg", Name: "Copy cursor text", get: fgOf(CopyModeCursor)},
+	{Key: "copy_selection_bg", Name: "Copy selection", get: bgOf(CopyModeVisualSelection)},
+	{Key: "copy_selection_fg", Name: "Copy selection text", get: fgOf(CopyModeVisualSelection)},
+	{Key: "copy_search_current_bg", Name: "Current search match", get: bgOf(CopyModeSearchCurrent)},
+	{Key: "copy_search_current_fg", Name: "Current search match text", get: fgOf(CopyModeSearchCurrent)},
+	{Key: "copy_search_other_bg", Name: "Search match", get: bgOf(CopyModeSearchOther)},
+	{Key: "copy_search_other_fg", Name: "Search match text", get: fgOf(CopyModeSearchOther)},
+}
+
+// bgOf and fgOf pick one color of a role getter returning (bg, fg).
+func bgOf(f func() (color.Color, color.Color)) func() color.Color {
+	return func() color.Color { c, _ := f(); return c }
+}
+
+func fgOf(f func() (color.Color, color.Color)) func() color.Color {
+	return func() color.Color { _, c := f(); return c }
+}
+
+// IsRole reports whether the key is a role rather than a palette slot.
+func (k ColorKey) IsRole() bool {
+	return k.get != nil
+}
+
+// Color returns the color of the key in the current theme.
+func (k ColorKey) Color() color.Color {
+	if k.IsRole() {
+		return k.get()
+	}
+	if t := Current(); t != nil {
+		if c := PaletteColor(t, k.Key); c != nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// roleOverrides holds the role colors set by custom themes, by theme ID and
+// role key.
+var roleOverrides = map[string]map[string]*tint.Color{}
+
+// roleOr returns the current theme's override for a role, or fallback.
+func roleOr(t *tint.Tint, key string, fallback *tint.Color) color.Color {
+	if c := roleOverrides[t.ID][key]; c != nil {
+		return c
+	}
+	return fallback
+}
+
+// RoleOverride returns a theme's override for a role, or nil.
+func RoleOverride(themeID, key string) *tint.Color {
+	return roleOverrides[themeID][key]
+}
+
+// SetRoleOverride sets a theme's color for a role. A nil color makes the
+// role use its palette slot again.
+func SetRoleOverride(themeID, key string, c *tint.Color) {
+	if c == nil {
+		delete(roleOverrides[themeID], key)
+		return
+	}
+	if roleOverrides[themeID] == nil {
+		roleOverrides[themeID] = map[string]*tint.Color{}
+	}
+	roleOverrides[themeID][key] = copyColor(c)
+}
+
+// parseRoles checks the roles object of a custom theme file.
+func parseRoles(roles map[string]string) (map[string]*tint.Color, error) {
+	parsed := map[string]*tint.Color{}
+	for key, value := range roles {
+		if !isRoleKey(key) {
+			return nil, fmt.Errorf("unknown color role %q", key)
+		}
+		c, err := ParseHex(value)
+		if err != nil {
+			return nil, fmt.Errorf("role %s: %w", key, err)
+		}
+		parsed[key] = c
+	}
+	return parsed, nil
+}
+
+func isRoleKey(key string) bool {
+	for _, k := range RoleColors {
+		if k.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseHex parses a #rrggbb or #rgb color.
+func ParseHex(s string) (*tint.Color, error) {
+	valid := len(s) == 7 || len(s) == 4
+	for i := 1; valid && i < len(s); i++ {
+		c := s[i]
+		valid = c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+	}
+	if !valid || s[0] != '#' {
+		return nil, fmt.Errorf("invalid color %q (use #rrggbb)", s)
+	}
+	return tint.FromHex(s), nil
+}
+
+// paletteField returns the field of a tint holding a palette slot.
+func paletteField(t *tint.Tint, key string) **tint.Color {
+	fields := map[string]**tint.Color{
+		"fg": &t.Fg, "bg": &t.Bg, "cursor": &t.Cursor,
+		"black": &t.Black, "red": &t.Red, "green": &t.Green, "yellow": &t.Yellow,
+		"blue": &t.Blue, "purple": &t.Purple, "cyan": &t.Cyan, "white": &t.White,
+		"bright_black": &t.BrightBlack, "bright_red": &t.BrightRed,
+		"bright_green": &t.BrightGreen, "bright_yellow": &t.BrightYellow,
+		"bright_blue": &t.BrightBlue, "bright_purple": &t.BrightPurple,
+		"bright_cyan": &t.BrightCyan, "bright_white": &t.BrightWhite,
+	}
+	return fields[key]
+}
+
+// PaletteColor returns a palette slot of a theme, or nil for an unknown key.
+func PaletteColor(t *tint.Tint, key string) *tint.Color {
+	if field := paletteField(t, key); field != nil {
+		return *field
+	}
+	return nil
+}
+
+// SetPaletteColor sets a palette slot of a theme.
+func SetPaletteColor(t *tint.Tint, key string, c *tint.Color) {
+	if field := paletteField(t, key); field != nil {
+		*field = copyColor(c)
+	}
+}
+
+// CopyTheme returns a copy of a theme's palette and names.
+func CopyTheme(t *tint.Tint) *tint.Tint {
+	dup := &tint.Tint{ID: t.ID, DisplayName: t.DisplayName, Dark: t.Dark}
+	for _, k := range PaletteColors {
+		SetPaletteColor(dup, k.Key, PaletteColor(t, k.Key))
+	}
+	return dup
+}
+
+// SaveCustomThemeFile writes a theme and its role overrides to a JSON file
+// that LoadCustomThemeFile reads back. Colors are written as hex strings in
+// palette order, like hand-written theme files.
+func SaveCustomThemeFile(path string, t *tint.Tint) error {
+	var fields []string
+	field := func(key string, value any) {
+		data, _ := json.Marshal(value)
+		fields = append(fields, fmt.Sprintf("  %q: %s", key, data))
+	}
+
+	field("id", t.ID)
+	if t.DisplayName != "" {
+		field("display_name", t.DisplayName)
+	}
+	field("dark", t.Dark)
+	for _, k := range PaletteColors {
+		if c := PaletteColor(t, k.Key); c != nil {
+			field(k.Key, c.Hex())
+		}
+	}
+	if overrides := roleOverrides[t.ID]; len(overrides) > 0 {
+		roles := map[string]string{}
+		for key, c := range overrides {
+			roles[key] = c.Hex()
+		}
+		field("roles", roles)
+	}
+
+	data := "{\n" + strings.Join(fields, ",\n") + "\n}\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		return fmt.Errorf("failed to write theme file: %w", err)
+	}
+	return nil
+}
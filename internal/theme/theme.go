@@ -24,6 +24,7 @@ func Initialize(themeName string) error {
 
 	enabled = true
 	tint.NewDefaultRegistry()
+	roleOverrides = map[string]map[string]*tint.Color{}
 
 	// Load custom themes from user's themes directory
 	if themesDir, err := GetThemesDir(); err == nil {
@@ -47,6 +48,15 @@ func IsEnabled() bool {
 	return enabled
 }
 
+// IDs returns the IDs of the themes that can be selected. Custom themes are
+// only included once theming is enabled, as they are loaded by Initialize.
+func IDs() []string {
+	if !enabled {
+		return tint.DefaultTintIDs()
+	}
+	return tint.TintIDs()
+}
+
 // Current returns the currently active theme.
 // Returns nil if theming is disabled.
 func Current() *tint.Tint {
@@ -124,7 +134,7 @@ func BorderUnfocused() color.Color {
 	}
 	// Light pinkish red - use theme's red (or bright red depending on theme)
 	// Using regular Red gives a softer, more muted tone for unfocused windows
-	return t.Red
+	return roleOr(t, "border_unfocused", t.Red)
 }
 
 // BorderFocusedWindow returns the color for focused window borders in window management mode.
@@ -134,7 +144,7 @@ func BorderFocusedWindow() color.Color {
 		return lipgloss.Color("#AFFFFF")
 	}
 	// Light cyan for window mode - use bright cyan
-	return t.BrightCyan
+	return roleOr(t, "border_focused_window", t.BrightCyan)
 }
 
 // BorderFocusedTerminal returns the color for focused window borders in terminal mode.
@@ -144,7 +154,7 @@ func BorderFocusedTerminal() color.Color {
 		return lipgloss.Color("#AAFFAA")
 	}
 	// Light green for terminal mode - use bright green
-	return t.BrightGreen
+	return roleOr(t, "border_focused_terminal", t.BrightGreen)
 }
 
 // DockColorWindow returns the dock indicator color for window management mode.
@@ -153,7 +163,7 @@ func DockColorWindow() color.Color {
 	if t == nil {
 		return lipgloss.Color("#5c5cff")
 	}
-	return t.BrightBlue
+	return roleOr(t, "dock_window", t.BrightBlue)
 }
 
 // DockColorTerminal returns the dock indicator color for terminal mode.
@@ -162,7 +172,7 @@ func DockColorTerminal() color.Color {
 	if t == nil {
 		return lipgloss.Color("#00ff00")
 	}
-	return t.BrightGreen
+	return roleOr(t, "dock_terminal", t.BrightGreen)
 }
 
 // DockColorCopy returns the dock indicator color for copy mode.
@@ -171,7 +181,7 @@ func DockColorCopy() color.Color {
 	if t == nil {
 		return lipgloss.Color("#ffff00")
 	}
-	return t.Yellow
+	return roleOr(t, "dock_copy", t.Yellow)
 }
 
 // CopyModeCursor returns background and foreground colors for the copy mode cursor.
@@ -180,7 +190,7 @@ func CopyModeCursor() (bg color.Color, fg color.Color) {
 	if t == nil {
 		return lipgloss.Color("#00ffff"), lipgloss.Color("#000000")
 	}
-	return t.BrightCyan, t.Black
+	return roleOr(t, "copy_cursor_bg", t.BrightCyan), roleOr(t, "copy_cursor_fg", t.Black)
 }
 
 // CopyModeVisualSelection returns colors for visually selected text in copy mode.
@@ -189,7 +199,7 @@ func CopyModeVisualSelection() (bg color.Color, fg color.Color) {
 	if t == nil {
 		return lipgloss.Color("#cd00cd"), lipgloss.Color("#ffffff")
 	}
-	return t.Purple, t.BrightWhite
+	return roleOr(t, "copy_selection_bg", t.Purple), roleOr(t, "copy_selection_fg", t.BrightWhite)
 }
 
 // CopyModeSearchCurrent returns colors for the current search match in copy mode.
@@ -198,7 +208,7 @@ func CopyModeSearchCurrent() (bg color.Color, fg color.Color) {
 	if t == nil {
 		return lipgloss.Color("#ff00ff"), lipgloss.Color("#000000")
 	}
-	return t.BrightPurple, t.Black
+	return roleOr(t, "copy_search_current_bg", t.BrightPurple), roleOr(t, "copy_search_current_fg", t.Black)
 }
 
 // CopyModeSearchOther returns colors for other search matches in copy mode.
@@ -207,7 +217,7 @@ func CopyModeSearchOther() (bg color.Color, fg color.Color) {
 	if t == nil {
 		return lipgloss.Color("#ffff00"), lipgloss.Color("#000000")
 	}
-	return t.Yellow, t.Black
+	return roleOr(t, "copy_search_other_bg", t.Yellow), roleOr(t, "copy_search_other_fg", t.Black)
 }
 
 // CopyModeTextSelection returns background and foreground colors for text selection in copy mode.
@@ -96,6 +96,11 @@ type Emulator struct {
 
 	// semanticMarkers tracks OSC 133 shell integration markers
 	semanticMarkers *SemanticMarkerList
+
+	// scrollbackTrimmed counts lines dropped from the front of the scrollback
+	// since creation. Adding it to an absolute line yields a stable line number
+	// that stays valid as the ring buffer overwrites old lines.
+	scrollbackTrimmed atomic.Int64
 }
 
 // NewEmulator creates a new virtual terminal emulator.
@@ -152,6 +157,7 @@ func NewEmulator(w, h int) *Emulator {
 	if sb := t.scrs[0].Scrollback(); sb != nil {
 		sb.SetOnTrim(func(n int) {
 			t.semanticMarkers.AdjustForScrollbackTrim(n)
+			t.scrollbackTrimmed.Add(int64(n))
 		})
 	}
 
@@ -221,6 +227,13 @@ func (e *Emulator) ScrollbackLen() int {
 	return e.scrs[0].ScrollbackLen()
 }
 
+// ScrollbackTrimmed returns the total number of lines trimmed from the front of
+// the scrollback buffer. Callers that need line references surviving trims store
+// absLine+ScrollbackTrimmed() and subtract the then-current value when resolving.
+func (e *Emulator) ScrollbackTrimmed() int {
+	return int(e.scrollbackTrimmed.Load())
+}
+
 // SemanticMarkers returns the list of OSC 133 semantic zone markers.
 func (e *Emulator) SemanticMarkers() *SemanticMarkerList {
 	return e.semanticMarkers
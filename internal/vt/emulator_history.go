@@ -0,0 +1,80 @@
+package vt
+
+import (
+	"strings"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// SetHistoryFile attaches an on-disk history tier to the main screen's
+// scrollback. Lines trimmed from the in-memory buffer are spilled to it and
+// stay reachable through ScrollbackLine.
+func (e *Emulator) SetHistoryFile(h *HistoryFile) {
+	e.scrs[0].Scrollback().SetHistory(h)
+}
+
+// HistoryFile returns the attached on-disk history tier, or nil.
+func (e *Emulator) HistoryFile() *HistoryFile {
+	return e.scrs[0].Scrollback().History()
+}
+
+// SaveHistory writes the remaining scrollback and the main screen contents to
+// the history file, closes it and detaches it from the scrollback.
+// It returns the semantic markers translated to history file line numbers so
+// they can be stored alongside the file. Does nothing if no history is attached.
+func (e *Emulator) SaveHistory() ([]SemanticMarker, error) {
+	sb := e.scrs[0].Scrollback()
+	h := sb.History()
+	if h == nil {
+		return nil, nil
+	}
+
+	offset := sb.HistoryStart()
+	sbLen := sb.Len()
+	sb.SpillToHistory()
+
+	// Append the main screen down to the last non-blank line or the cursor
+	scr := &e.scrs[0]
+	width := scr.Width()
+	last := -1
+	if e.scr == scr {
+		_, last = scr.CursorPosition()
+	}
+	lines := make([]uv.Line, scr.Height())
+	for y := range lines {
+		lines[y] = extractLine(scr.buf.Buffer, y, width)
+		if strings.TrimSpace(lines[y].String()) != "" {
+			last = max(last, y)
+		}
+	}
+	for _, line := range lines[:last+1] {
+		_ = h.Append(line)
+	}
+
+	markers := e.semanticMarkers.Markers()
+	for i := range markers {
+		markers[i].AbsLine += offset
+	}
+	filtered := markers[:0]
+	for _, m := range markers {
+		if m.AbsLine-offset < sbLen+last+1 {
+			filtered = append(filtered, m)
+		}
+	}
+
+	sb.SetHistory(nil)
+	return filtered, h.Close()
+}
+
+// NewHistoryEmulator creates a read-only emulator whose scrollback is a
+// previously saved history file. The emulator is closed, so writes fail;
+// it exists to feed copy mode style readers and the scrollback browser.
+func NewHistoryEmulator(h *HistoryFile, width int, markers []SemanticMarker) *Emulator {
+	e := NewEmulator(max(width, 1), 1)
+	e.SetHistoryFile(h)
+	for _, m := range markers {
+		e.semanticMarkers.Add(m)
+	}
+	_ = e.Close()
+	return e
+}
@@ -0,0 +1,532 @@
+package vt
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// historyMagic identifies a scrollback history file (format version 1).
+const historyMagic = "TUIOSHS1"
+
+// historyBlockLines is the number of lines compressed together in one block.
+// Larger blocks compress better; smaller blocks make random access cheaper.
+const historyBlockLines = 256
+
+// historyBlockHeaderSize is the size of a block header:
+// uint32 compressed length + uint32 line count (little endian).
+const historyBlockHeaderSize = 8
+
+// ErrHistoryReadOnly is returned when appending to a history file opened for reading.
+var ErrHistoryReadOnly = errors.New("history file is read-only")
+
+// historyBlock describes one compressed block of lines in a history file.
+type historyBlock struct {
+	offset int64 // File offset of the compressed payload
+	length int   // Compressed payload length
+	first  int   // Index of the first line in the block
+	lines  int   // Number of lines in the block
+}
+
+// HistoryFile is an append-only, block-compressed file of scrollback lines.
+// It serves as the on-disk tier behind the in-memory Scrollback ring buffer:
+// lines the ring buffer drops are appended here instead of being lost.
+//
+// Lines are buffered in memory and written as gzip-compressed blocks of
+// historyBlockLines lines. Full blocks are compressed and written by a
+// background goroutine, so appending never waits on the disk. An in-memory
+// index of blocks gives random access to any line while only decompressing
+// the block that contains it.
+type HistoryFile struct {
+	mu       sync.Mutex
+	file     *os.File
+	path     string
+	readOnly bool
+
+	blocks  []historyBlock // Index of blocks written to disk
+	flushed int            // Number of lines in written blocks
+	end     int64          // File offset where the next block is written
+	queued  [][]uv.Line    // Full blocks waiting to be written, oldest first
+	pending []uv.Line      // Lines not yet in a full block
+
+	writing  bool       // Whether the writer goroutine is running
+	written  *sync.Cond // Signalled when the writer goroutine stops
+	writeErr error      // First error of the writer goroutine
+
+	// Most recently decoded block, cached for sequential access
+	cachedBlock int
+	cachedLines []uv.Line
+}
+
+// CreateHistoryFile creates (or truncates) a history file at path for appending.
+// Parent directories are created as needed.
+func CreateHistoryFile(path string) (*HistoryFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create history file: %w", err)
+	}
+	if _, err := f.WriteString(historyMagic); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to write history header: %w", err)
+	}
+
+	h := &HistoryFile{
+		file:        f,
+		path:        path,
+		end:         int64(len(historyMagic)),
+		cachedBlock: -1,
+	}
+	h.written = sync.NewCond(&h.mu)
+	return h, nil
+}
+
+// OpenHistoryFile opens an existing history file read-only.
+// A truncated trailing block (for example after a crash) is ignored.
+func OpenHistoryFile(path string) (*HistoryFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+
+	h := &HistoryFile{
+		file:        f,
+		path:        path,
+		readOnly:    true,
+		cachedBlock: -1,
+	}
+	h.written = sync.NewCond(&h.mu)
+	if err := h.scan(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return h, nil
+}
+
+// scan reads the file header and builds the block index.
+func (h *HistoryFile) scan() error {
+	magic := make([]byte, len(historyMagic))
+	if _, err := io.ReadFull(h.file, magic); err != nil || string(magic) != historyMagic {
+		return fmt.Errorf("%s is not a history file", h.path)
+	}
+
+	info, err := h.file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat history file: %w", err)
+	}
+	size := info.Size()
+
+	offset := int64(len(historyMagic))
+	header := make([]byte, historyBlockHeaderSize)
+	for offset+historyBlockHeaderSize <= size {
+		if _, err := h.file.ReadAt(header, offset); err != nil {
+			break
+		}
+		length := int(binary.LittleEndian.Uint32(header[0:4]))
+		lines := int(binary.LittleEndian.Uint32(header[4:8]))
+		payload := offset + historyBlockHeaderSize
+		if payload+int64(length) > size {
+			break // Truncated block
+		}
+		h.blocks = append(h.blocks, historyBlock{
+			offset: payload,
+			length: length,
+			first:  h.flushed,
+			lines:  lines,
+		})
+		h.flushed += lines
+		offset = payload + int64(length)
+	}
+	h.end = offset
+	return nil
+}
+
+// Path returns the file path of the history file.
+func (h *HistoryFile) Path() string {
+	return h.path
+}
+
+// Len returns the total number of lines in the history, including lines
+// that are buffered but not yet written to disk.
+func (h *HistoryFile) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.flushed + h.queuedLen() + len(h.pending)
+}
+
+// queuedLen returns the number of lines in blocks waiting to be written.
+func (h *HistoryFile) queuedLen() int {
+	n := 0
+	for _, block := range h.queued {
+		n += len(block)
+	}
+	return n
+}
+
+// Append adds a line to the end of the history. Every historyBlockLines
+// lines are handed to the background writer as one block.
+func (h *HistoryFile) Append(line uv.Line) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.readOnly {
+		return ErrHistoryReadOnly
+	}
+	if h.file == nil {
+		return os.ErrClosed
+	}
+
+	h.pending = append(h.pending, line)
+	if len(h.pending) >= historyBlockLines {
+		h.queued = append(h.queued, h.pending)
+		h.pending = nil
+		if !h.writing && h.writeErr == nil {
+			h.writing = true
+			go h.writeQueued()
+		}
+	}
+	return h.writeErr
+}
+
+// writeQueued writes queued blocks until the queue is empty or a write
+// fails. Blocks stay readable from the queue until they are on disk.
+func (h *HistoryFile) writeQueued() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for len(h.queued) > 0 && h.file != nil {
+		lines, file, end := h.queued[0], h.file, h.end
+		h.mu.Unlock()
+		data, err := encodeHistoryBlock(lines)
+		if err == nil {
+			if _, werr := file.WriteAt(data, end); werr != nil {
+				err = fmt.Errorf("failed to write history block: %w", werr)
+			}
+		}
+		h.mu.Lock()
+		if err != nil {
+			h.writeErr = err
+			break
+		}
+		h.addBlock(lines, len(data))
+		h.queued = h.queued[1:]
+	}
+	h.writing = false
+	h.written.Broadcast()
+}
+
+// waitWritten waits for the background writer to stop. h.mu must be held.
+func (h *HistoryFile) waitWritten() {
+	for h.writing {
+		h.written.Wait()
+	}
+}
+
+// Flush writes buffered lines to disk, the last of them as a short block.
+func (h *HistoryFile) Flush() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.flushLocked()
+}
+
+// flushLocked waits for the background writer and writes the blocks it
+// left and the buffered lines. h.mu must be held.
+func (h *HistoryFile) flushLocked() error {
+	h.waitWritten()
+	if h.readOnly || h.file == nil {
+		return nil
+	}
+	if h.writeErr != nil {
+		return h.writeErr
+	}
+	if len(h.pending) > 0 {
+		h.queued = append(h.queued, h.pending)
+		h.pending = nil
+	}
+	for len(h.queued) > 0 {
+		lines := h.queued[0]
+		data, err := encodeHistoryBlock(lines)
+		if err != nil {
+			return err
+		}
+		if _, err := h.file.WriteAt(data, h.end); err != nil {
+			return fmt.Errorf("failed to write history block: %w", err)
+		}
+		h.addBlock(lines, len(data))
+		h.queued = h.queued[1:]
+	}
+	return nil
+}
+
+// addBlock records a block of lines written at the end of the file.
+func (h *HistoryFile) addBlock(lines []uv.Line, size int) {
+	h.blocks = append(h.blocks, historyBlock{
+		offset: h.end + historyBlockHeaderSize,
+		length: size - historyBlockHeaderSize,
+		first:  h.flushed,
+		lines:  len(lines),
+	})
+	h.end += int64(size)
+	h.flushed += len(lines)
+}
+
+// encodeHistoryBlock compresses lines into a block, header included.
+func encodeHistoryBlock(lines []uv.Line) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(make([]byte, historyBlockHeaderSize)) // Header placeholder
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(encodeHistoryLines(lines)); err != nil {
+		return nil, fmt.Errorf("failed to compress history block: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress history block: %w", err)
+	}
+
+	data := buf.Bytes()
+	binary.LittleEndian.PutUint32(data[0:4], uint32(len(data)-historyBlockHeaderSize))
+	binary.LittleEndian.PutUint32(data[4:8], uint32(len(lines)))
+	return data, nil
+}
+
+// Line returns the line at index (0 is the oldest line).
+// Returns nil if the index is out of bounds or the block cannot be read.
+func (h *HistoryFile) Line(index int) uv.Line {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if index < 0 {
+		return nil
+	}
+	if index >= h.flushed {
+		index -= h.flushed
+		for _, block := range h.queued {
+			if index < len(block) {
+				return block[index]
+			}
+			index -= len(block)
+		}
+		if index < len(h.pending) {
+			return h.pending[index]
+		}
+		return nil
+	}
+
+	blockIdx := sort.Search(len(h.blocks), func(i int) bool {
+		return h.blocks[i].first+h.blocks[i].lines > index
+	})
+	if blockIdx >= len(h.blocks) {
+		return nil
+	}
+
+	if blockIdx != h.cachedBlock {
+		lines, err := h.readBlock(h.blocks[blockIdx])
+		if err != nil {
+			return nil
+		}
+		h.cachedBlock = blockIdx
+		h.cachedLines = lines
+	}
+
+	offset := index - h.blocks[blockIdx].first
+	if offset >= len(h.cachedLines) {
+		return nil
+	}
+	return h.cachedLines[offset]
+}
+
+// readBlock reads and decompresses one block.
+func (h *HistoryFile) readBlock(block historyBlock) ([]uv.Line, error) {
+	if h.file == nil {
+		return nil, os.ErrClosed
+	}
+
+	compressed := make([]byte, block.length)
+	if _, err := h.file.ReadAt(compressed, block.offset); err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = zr.Close() }()
+
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	return decodeHistoryLines(data, block.lines)
+}
+
+// Close flushes buffered lines and closes the file.
+func (h *HistoryFile) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.file == nil {
+		return nil
+	}
+	flushErr := h.flushLocked()
+	closeErr := h.file.Close()
+	h.file = nil
+	h.cachedLines = nil
+	h.cachedBlock = -1
+	if flushErr != nil {
+		return flushErr
+	}
+	return closeErr
+}
+
+// Color encoding tags used by the line codec.
+const (
+	historyColorNone byte = iota
+	historyColorBasic
+	historyColorIndexed
+	historyColorRGB
+)
+
+// encodeHistoryLines serializes lines into the uncompressed block format.
+// Each line is a cell count followed by the cells; each cell stores its
+// content, width, attributes, colors and hyperlink.
+func encodeHistoryLines(lines []uv.Line) []byte {
+	var buf []byte
+	for _, line := range lines {
+		buf = binary.AppendUvarint(buf, uint64(len(line)))
+		for i := range line {
+			cell := &line[i]
+			buf = appendHistoryString(buf, cell.Content)
+			buf = binary.AppendUvarint(buf, uint64(max(cell.Width, 0)))
+			buf = append(buf, cell.Style.Attrs, byte(cell.Style.Underline))
+			buf = appendHistoryColor(buf, cell.Style.Fg)
+			buf = appendHistoryColor(buf, cell.Style.Bg)
+			buf = appendHistoryColor(buf, cell.Style.UnderlineColor)
+			buf = appendHistoryString(buf, cell.Link.URL)
+			buf = appendHistoryString(buf, cell.Link.Params)
+		}
+	}
+	return buf
+}
+
+func appendHistoryString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func appendHistoryColor(buf []byte, c color.Color) []byte {
+	switch c := c.(type) {
+	case nil:
+		return append(buf, historyColorNone)
+	case ansi.BasicColor:
+		return append(buf, historyColorBasic, byte(c))
+	case ansi.IndexedColor:
+		return append(buf, historyColorIndexed, byte(c))
+	default:
+		r, g, b, a := c.RGBA()
+		return append(buf, historyColorRGB, byte(r>>8), byte(g>>8), byte(b>>8), byte(a>>8))
+	}
+}
+
+// historyDecoder reads values written by encodeHistoryLines.
+type historyDecoder struct {
+	data []byte
+	err  error
+}
+
+var errHistoryCorrupt = errors.New("corrupt history block")
+
+func (d *historyDecoder) uvarint() int {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.err = errHistoryCorrupt
+		return 0
+	}
+	d.data = d.data[n:]
+	return int(v)
+}
+
+func (d *historyDecoder) bytes(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.data) {
+		d.err = errHistoryCorrupt
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *historyDecoder) byte() byte {
+	b := d.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (d *historyDecoder) string() string {
+	return string(d.bytes(d.uvarint()))
+}
+
+func (d *historyDecoder) color() color.Color {
+	switch d.byte() {
+	case historyColorBasic:
+		return ansi.BasicColor(d.byte())
+	case historyColorIndexed:
+		return ansi.IndexedColor(d.byte())
+	case historyColorRGB:
+		rgba := d.bytes(4)
+		if rgba == nil {
+			return nil
+		}
+		return color.RGBA{R: rgba[0], G: rgba[1], B: rgba[2], A: rgba[3]}
+	default:
+		return nil
+	}
+}
+
+// decodeHistoryLines deserializes count lines from an uncompressed block.
+func decodeHistoryLines(data []byte, count int) ([]uv.Line, error) {
+	d := &historyDecoder{data: data}
+	lines := make([]uv.Line, 0, count)
+	for range count {
+		n := d.uvarint()
+		if d.err != nil || n > len(d.data) {
+			return nil, errHistoryCorrupt
+		}
+		line := make(uv.Line, n)
+		for i := range line {
+			cell := &line[i]
+			cell.Content = d.string()
+			cell.Width = d.uvarint()
+			cell.Style.Attrs = d.byte()
+			cell.Style.Underline = uv.Underline(d.byte())
+			cell.Style.Fg = d.color()
+			cell.Style.Bg = d.color()
+			cell.Style.UnderlineColor = d.color()
+			cell.Link.URL = d.string()
+			cell.Link.Params = d.string()
+		}
+		if d.err != nil {
+			return nil, d.err
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
@@ -0,0 +1,263 @@
+package vt
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func historyTestLine(text string) uv.Line {
+	line := make(uv.Line, len(text))
+	for i, r := range text {
+		line[i] = uv.Cell{Content: string(r), Width: 1}
+	}
+	return line
+}
+
+func TestHistoryFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history", "test.tsh")
+
+	h, err := CreateHistoryFile(path)
+	if err != nil {
+		t.Fatalf("CreateHistoryFile: %v", err)
+	}
+
+	styled := uv.Line{
+		{Content: "a", Width: 1, Style: uv.Style{Fg: ansi.BasicColor(1), Attrs: uv.AttrBold}},
+		{Content: "界", Width: 2, Style: uv.Style{Bg: ansi.IndexedColor(200), Underline: uv.UnderlineCurly}},
+		{Content: "", Width: 0},
+		{Content: "z", Width: 1, Style: uv.Style{Fg: color.RGBA{R: 10, G: 20, B: 30, A: 255}}, Link: uv.Link{URL: "https://example.com"}},
+	}
+
+	total := historyBlockLines*2 + 10
+	for i := range total {
+		line := historyTestLine(fmt.Sprintf("line %d", i))
+		if i == 5 {
+			line = styled
+		}
+		if err := h.Append(line); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	// Lines are readable before and after being flushed to disk
+	if got := h.Line(total - 1).String(); got != fmt.Sprintf("line %d", total-1) {
+		t.Errorf("pending line = %q", got)
+	}
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	r, err := OpenHistoryFile(path)
+	if err != nil {
+		t.Fatalf("OpenHistoryFile: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if r.Len() != total {
+		t.Fatalf("Len() = %d, want %d", r.Len(), total)
+	}
+	for _, i := range []int{0, 300, historyBlockLines, total - 1, 1} {
+		if got, want := r.Line(i).String(), fmt.Sprintf("line %d", i); got != want {
+			t.Errorf("Line(%d) = %q, want %q", i, got, want)
+		}
+	}
+
+	got := r.Line(5)
+	if len(got) != len(styled) {
+		t.Fatalf("styled line has %d cells, want %d", len(got), len(styled))
+	}
+	for i := range styled {
+		if got[i].Content != styled[i].Content || got[i].Width != styled[i].Width ||
+			!got[i].Style.Equal(&styled[i].Style) || got[i].Link != styled[i].Link {
+			t.Errorf("cell %d = %+v, want %+v", i, got[i], styled[i])
+		}
+	}
+
+	if err := r.Append(historyTestLine("x")); err != ErrHistoryReadOnly {
+		t.Errorf("Append on read-only file = %v, want ErrHistoryReadOnly", err)
+	}
+}
+
+func TestHistoryFileWritesInBackground(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.tsh")
+	h, err := CreateHistoryFile(path)
+	if err != nil {
+		t.Fatalf("CreateHistoryFile: %v", err)
+	}
+
+	// Lines are readable while their blocks wait for the writer
+	total := historyBlockLines*8 + 3
+	for i := range total {
+		if err := h.Append(historyTestLine(fmt.Sprintf("line %d", i))); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+		if i%historyBlockLines == 0 {
+			if got, want := h.Line(i/2).String(), fmt.Sprintf("line %d", i/2); got != want {
+				t.Fatalf("Line(%d) = %q, want %q", i/2, got, want)
+			}
+		}
+	}
+	if h.Len() != total {
+		t.Errorf("Len() = %d, want %d", h.Len(), total)
+	}
+
+	if err := h.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	r, err := OpenHistoryFile(path)
+	if err != nil {
+		t.Fatalf("OpenHistoryFile: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	if r.Len() != total {
+		t.Errorf("Len() after Flush = %d, want %d", r.Len(), total)
+	}
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestHistoryFileIgnoresTruncatedBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.tsh")
+
+	h, err := CreateHistoryFile(path)
+	if err != nil {
+		t.Fatalf("CreateHistoryFile: %v", err)
+	}
+	for i := range historyBlockLines + 1 {
+		_ = h.Append(historyTestLine(fmt.Sprintf("line %d", i)))
+	}
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Chop the last block in half, as a crash during a write would
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if err := os.Truncate(path, info.Size()-5); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+
+	r, err := OpenHistoryFile(path)
+	if err != nil {
+		t.Fatalf("OpenHistoryFile: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if r.Len() != historyBlockLines {
+		t.Errorf("Len() = %d, want %d", r.Len(), historyBlockLines)
+	}
+}
+
+func TestScrollbackSpillsToHistory(t *testing.T) {
+	h, err := CreateHistoryFile(filepath.Join(t.TempDir(), "test.tsh"))
+	if err != nil {
+		t.Fatalf("CreateHistoryFile: %v", err)
+	}
+	defer func() { _ = h.Close() }()
+
+	sb := NewScrollback(5)
+	trimmed := 0
+	sb.SetOnTrim(func(n int) { trimmed += n })
+	sb.SetHistory(h)
+
+	for i := range 12 {
+		sb.PushLine(historyTestLine(fmt.Sprintf("line %d", i)))
+	}
+
+	if sb.Len() != 12 {
+		t.Fatalf("Len() = %d, want 12", sb.Len())
+	}
+	if trimmed != 0 {
+		t.Errorf("onTrim fired for %d lines spilled to history", trimmed)
+	}
+	for i := range 12 {
+		if got, want := sb.Line(i).String(), fmt.Sprintf("line %d", i); got != want {
+			t.Errorf("Line(%d) = %q, want %q", i, got, want)
+		}
+	}
+
+	page := sb.Lines(3, 6)
+	if len(page) != 3 || page[0].String() != "line 3" || page[2].String() != "line 5" {
+		t.Errorf("Lines(3, 6) = %d lines starting %q", len(page), page[0].String())
+	}
+	if got := sb.Lines(10, 100); len(got) != 2 {
+		t.Errorf("Lines(10, 100) = %d lines, want 2", len(got))
+	}
+
+	// Downsizing spills instead of dropping
+	sb.SetMaxLines(2)
+	if sb.Len() != 12 || sb.Line(4).String() != "line 4" {
+		t.Errorf("after SetMaxLines: Len() = %d, Line(4) = %q", sb.Len(), sb.Line(4).String())
+	}
+
+	// Clear hides the lines but keeps them in the file
+	sb.Clear()
+	if sb.Len() != 0 {
+		t.Errorf("Len() after Clear = %d, want 0", sb.Len())
+	}
+	if trimmed != 12 {
+		t.Errorf("onTrim after Clear = %d, want 12", trimmed)
+	}
+	if h.Len() != 12 {
+		t.Errorf("history Len() after Clear = %d, want 12", h.Len())
+	}
+
+	sb.PushLine(historyTestLine("after clear"))
+	if sb.Len() != 1 || sb.Line(0).String() != "after clear" {
+		t.Errorf("after Clear: Len() = %d, Line(0) = %q", sb.Len(), sb.Line(0).String())
+	}
+}
+
+func TestEmulatorSaveHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.tsh")
+	h, err := CreateHistoryFile(path)
+	if err != nil {
+		t.Fatalf("CreateHistoryFile: %v", err)
+	}
+
+	emu := NewEmulator(20, 3)
+	emu.SetScrollbackMaxLines(2)
+	emu.SetHistoryFile(h)
+
+	for i := range 6 {
+		_, _ = emu.Write([]byte(fmt.Sprintf("line %d\r\n", i)))
+	}
+	_, _ = emu.Write([]byte("\x1b]133;A\x07$ "))
+
+	markers, err := emu.SaveHistory()
+	if err != nil {
+		t.Fatalf("SaveHistory: %v", err)
+	}
+	if emu.HistoryFile() != nil {
+		t.Error("history should be detached after SaveHistory")
+	}
+
+	r, err := OpenHistoryFile(path)
+	if err != nil {
+		t.Fatalf("OpenHistoryFile: %v", err)
+	}
+	view := NewHistoryEmulator(r, 20, markers)
+	defer func() { _ = r.Close() }()
+
+	if view.ScrollbackLen() != 7 {
+		t.Fatalf("ScrollbackLen() = %d, want 7", view.ScrollbackLen())
+	}
+	if got := view.ScrollbackLine(6).String(); got != "$" {
+		t.Errorf("last line = %q, want %q", got, "$")
+	}
+	if len(markers) != 1 || markers[0].AbsLine != 6 {
+		t.Errorf("markers = %+v, want one prompt on line 6", markers)
+	}
+	if _, err := view.Write([]byte("x")); err == nil {
+		t.Error("history emulator should be read-only")
+	}
+}
@@ -32,6 +32,13 @@ type Scrollback struct {
 	// onTrim is called when oldest lines are overwritten by the ring buffer.
 	// The argument is the number of lines trimmed (always 1 per overwrite).
 	onTrim func(int)
+	// history is an optional on-disk tier. When set, lines dropped from the
+	// ring buffer are spilled to it instead of being trimmed, so absolute line
+	// indices stay stable and onTrim only fires for Clear.
+	history *HistoryFile
+	// historyStart is the number of history lines hidden by Clear. They remain
+	// in the file (for reopening later) but are no longer part of the buffer.
+	historyStart int
 }
 
 // NewScrollback creates a new scrollback buffer with the specified maximum
@@ -65,6 +72,51 @@ func (sb *Scrollback) SetOnTrim(fn func(int)) {
 	sb.onTrim = fn
 }
 
+// SetHistory attaches an on-disk history tier. Lines already in the history
+// file become the oldest lines of the scrollback.
+func (sb *Scrollback) SetHistory(h *HistoryFile) {
+	sb.history = h
+	sb.historyStart = 0
+}
+
+// History returns the attached on-disk history tier, or nil.
+func (sb *Scrollback) History() *HistoryFile {
+	return sb.history
+}
+
+// HistoryStart returns the number of history file lines hidden by Clear.
+// Scrollback line i corresponds to history file line HistoryStart()+i.
+func (sb *Scrollback) HistoryStart() int {
+	return sb.historyStart
+}
+
+// SpillToHistory appends all ring buffer lines to the history tier and empties
+// the ring buffer. The lines stay visible through the history tier.
+// Does nothing if no history is attached.
+func (sb *Scrollback) SpillToHistory() {
+	if sb.history == nil {
+		return
+	}
+	for i := range sb.ringLen() {
+		_ = sb.history.Append(sb.ringLine(i))
+	}
+	sb.resetRing()
+}
+
+// dropOldest handles n lines leaving the front of the ring buffer: they are
+// spilled to the history tier if attached, otherwise reported via onTrim.
+func (sb *Scrollback) dropOldest(lines ...uv.Line) {
+	if sb.history != nil {
+		for _, line := range lines {
+			_ = sb.history.Append(line)
+		}
+		return
+	}
+	if sb.onTrim != nil && len(lines) > 0 {
+		sb.onTrim(len(lines))
+	}
+}
+
 // PushLineWithWrap adds a line with wrap information for soft-wrap support.
 func (sb *Scrollback) PushLineWithWrap(line uv.Line, isSoftWrapped bool) {
 	if len(line) == 0 {
@@ -75,6 +127,12 @@ func (sb *Scrollback) PushLineWithWrap(line uv.Line, isSoftWrapped bool) {
 	lineCopy := make(uv.Line, len(line))
 	copy(lineCopy, line)
 
+	// The oldest line is about to be overwritten
+	var dropped uv.Line
+	if sb.full {
+		dropped = sb.lines[sb.tail]
+	}
+
 	// Insert at tail position
 	sb.lines[sb.tail] = lineCopy
 	sb.softWrapped[sb.tail] = isSoftWrapped
@@ -85,9 +143,7 @@ func (sb *Scrollback) PushLineWithWrap(line uv.Line, isSoftWrapped bool) {
 	// If buffer is full, advance head (oldest line pointer) as well
 	if sb.full {
 		sb.head = (sb.head + 1) % sb.maxLines
-		if sb.onTrim != nil {
-			sb.onTrim(1)
-		}
+		sb.dropOldest(dropped)
 	}
 
 	// Mark as full when tail catches up to head
@@ -96,8 +152,22 @@ func (sb *Scrollback) PushLineWithWrap(line uv.Line, isSoftWrapped bool) {
 	}
 }
 
-// Len returns the number of lines currently in the scrollback buffer.
+// Len returns the number of lines currently in the scrollback buffer,
+// including lines in the on-disk history tier.
 func (sb *Scrollback) Len() int {
+	return sb.historyLen() + sb.ringLen()
+}
+
+// historyLen returns the number of visible lines in the history tier.
+func (sb *Scrollback) historyLen() int {
+	if sb.history == nil {
+		return 0
+	}
+	return max(sb.history.Len()-sb.historyStart, 0)
+}
+
+// ringLen returns the number of lines in the in-memory ring buffer.
+func (sb *Scrollback) ringLen() int {
 	if sb.full {
 		return sb.maxLines
 	}
@@ -109,9 +179,22 @@ func (sb *Scrollback) Len() int {
 
 // Line returns the line at the specified index in the scrollback buffer.
 // Index 0 is the oldest line, and Len()-1 is the newest (most recently scrolled).
+// Lines older than the ring buffer are read from the on-disk history tier.
 // Returns nil if the index is out of bounds.
 func (sb *Scrollback) Line(index int) uv.Line {
-	length := sb.Len()
+	if index < 0 {
+		return nil
+	}
+	historyLen := sb.historyLen()
+	if index < historyLen {
+		return sb.history.Line(sb.historyStart + index)
+	}
+	return sb.ringLine(index - historyLen)
+}
+
+// ringLine returns the line at index within the in-memory ring buffer.
+func (sb *Scrollback) ringLine(index int) uv.Line {
+	length := sb.ringLen()
 	if index < 0 || index >= length {
 		return nil
 	}
@@ -126,26 +209,42 @@ func (sb *Scrollback) Line(index int) uv.Line {
 	return sb.lines[physicalIndex]
 }
 
-// Lines returns a slice of all lines in the scrollback buffer, from oldest
-// to newest. The returned slice should not be modified.
-func (sb *Scrollback) Lines() []uv.Line {
-	length := sb.Len()
-	if length == 0 {
+// Lines returns the lines from start up to (not including) end, oldest
+// first; the range is clamped to the buffer. With a history tier attached
+// the buffer can be far larger than memory, so read it a page at a time.
+// The returned lines should not be modified.
+func (sb *Scrollback) Lines(start, end int) []uv.Line {
+	start = max(start, 0)
+	end = min(end, sb.Len())
+	if start >= end {
 		return nil
 	}
 
-	// Build a slice in correct order from the ring buffer
-	result := make([]uv.Line, length)
-	for i := range length {
-		physicalIndex := (sb.head + i) % sb.maxLines
-		result[i] = sb.lines[physicalIndex]
+	result := make([]uv.Line, end-start)
+	for i := range result {
+		result[i] = sb.Line(start + i)
 	}
 	return result
 }
 
 // Clear removes all lines from the scrollback buffer.
+// With a history tier attached, the lines are kept in the history file but
+// hidden from the buffer.
 func (sb *Scrollback) Clear() {
 	count := sb.Len()
+	if sb.history != nil {
+		sb.SpillToHistory()
+		sb.historyStart = sb.history.Len()
+	}
+	sb.resetRing()
+	// Notify marker list so stale markers are removed
+	if sb.onTrim != nil && count > 0 {
+		sb.onTrim(count)
+	}
+}
+
+// resetRing empties the in-memory ring buffer.
+func (sb *Scrollback) resetRing() {
 	sb.head = 0
 	sb.tail = 0
 	sb.full = false
@@ -154,10 +253,6 @@ func (sb *Scrollback) Clear() {
 		sb.lines[i] = nil
 		sb.softWrapped[i] = false
 	}
-	// Notify marker list so stale markers are removed
-	if sb.onTrim != nil && count > 0 {
-		sb.onTrim(count)
-	}
 }
 
 // Reflow reconstructs scrollback lines for a different terminal width.
@@ -213,7 +308,7 @@ func (sb *Scrollback) SetMaxLines(maxLines int) {
 		return // No change needed
 	}
 
-	oldLen := sb.Len()
+	oldLen := sb.ringLen()
 	if oldLen == 0 {
 		// Empty buffer, just resize
 		sb.lines = make([]uv.Line, maxLines)
@@ -232,6 +327,10 @@ func (sb *Scrollback) SetMaxLines(maxLines int) {
 
 	// Copy the most recent newLen lines
 	startIndex := oldLen - newLen // Skip oldest lines if downsizing
+	dropped := make([]uv.Line, startIndex)
+	for i := range startIndex {
+		dropped[i] = sb.lines[(sb.head+i)%sb.maxLines]
+	}
 	for i := range newLen {
 		physicalIndex := (sb.head + startIndex + i) % sb.maxLines
 		newLines[i] = sb.lines[physicalIndex]
@@ -244,6 +343,9 @@ func (sb *Scrollback) SetMaxLines(maxLines int) {
 	sb.head = 0
 	sb.tail = newLen % maxLines
 	sb.full = (newLen == maxLines)
+
+	// Spill or report lines dropped by downsizing
+	sb.dropOldest(dropped...)
 }
 
 // extractLine extracts a complete line from the buffer at the given Y coordinate.